@@ -0,0 +1,732 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.13.4 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/nalle631/fabric-network/application/shared/offline"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ErrorCode.
+const (
+	BadGateway     ErrorCode = "bad_gateway"
+	Conflict       ErrorCode = "conflict"
+	Forbidden      ErrorCode = "forbidden"
+	Internal       ErrorCode = "internal"
+	InvalidRequest ErrorCode = "invalid_request"
+	NotFound       ErrorCode = "not_found"
+	Timeout        ErrorCode = "timeout"
+	Unauthorized   ErrorCode = "unauthorized"
+)
+
+// Defines values for OfflineProposalFunction.
+const (
+	CreateGeneralContract OfflineProposalFunction = "CreateGeneralContract"
+	JobDoneCorrectError   OfflineProposalFunction = "JobDoneCorrectError"
+	JobDoneWrongError     OfflineProposalFunction = "JobDoneWrongError"
+	TakeJob               OfflineProposalFunction = "TakeJob"
+)
+
+// ClosePeriodParams defines model for ClosePeriodParams.
+type ClosePeriodParams struct {
+	// Period The month to close as YYYY-MM
+	Period       string `json:"Period"`
+	TechnicianID string `json:"TechnicianID"`
+}
+
+// Committed defines model for Committed.
+type Committed = offline.Committed
+
+// Error defines model for Error.
+type Error struct {
+	Code    ErrorCode     `json:"code"`
+	Details []ErrorDetail `json:"details,omitempty"`
+
+	// Error What went wrong, the message of the chaincode when it rejected the transaction
+	Error         string `json:"error"`
+	TransactionId string `json:"transactionId,omitempty"`
+}
+
+// ErrorCode defines model for Error.Code.
+type ErrorCode string
+
+// ErrorDetail The message of a peer or orderer that rejected the transaction
+type ErrorDetail struct {
+	Address string `json:"address,omitempty"`
+	Message string `json:"message"`
+	MspId   string `json:"mspId,omitempty"`
+}
+
+// GeneralContract defines model for GeneralContract.
+type GeneralContract struct {
+	JobAuthority []string `json:"JobAuthority"`
+	Jobs         []Job    `json:"Jobs"`
+
+	// MonthlyBalance An amount in a currency
+	MonthlyBalance Money `json:"MonthlyBalance"`
+
+	// PayoutWallet The token-sdk node and account settlements are paid to
+	PayoutWallet *PayoutWallet `json:"PayoutWallet,omitempty"`
+	Settlements  []Settlement  `json:"Settlements,omitempty"`
+	TechnicianID string        `json:"TechnicianID"`
+}
+
+// Job defines model for Job.
+type Job struct {
+	// Adress The address of the mower
+	Address  string    `json:"Adress"`
+	Deadline time.Time `json:"Deadline,omitempty"`
+	ID       string    `json:"ID"`
+
+	// InspectionPay An amount in a currency
+	InspectionPay Money `json:"InspectionPay"`
+
+	// JobPay An amount in a currency
+	JobPay Money  `json:"JobPay"`
+	Mower  string `json:"Mower"`
+	Status string `json:"Status"`
+	Type   string `json:"Type"`
+}
+
+// JobDoneParams defines model for JobDoneParams.
+type JobDoneParams struct {
+	JobID string `json:"JobID"`
+}
+
+// Message defines model for Message.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// Money An amount in a currency
+type Money = decimal.Money
+
+// OfflineProposal defines model for OfflineProposal.
+type OfflineProposal struct {
+	// Certificate The PEM certificate of the technician, which creates and signs the transaction
+	Certificate string                  `json:"certificate"`
+	Function    OfflineProposalFunction `json:"function"`
+
+	// JobId The ID of the job, for every function but CreateGeneralContract
+	JobID string `json:"jobId,omitempty"`
+
+	// MspId The MSP of the certificate, the organisation of the application by default
+	MSPID string `json:"mspId,omitempty"`
+}
+
+// OfflineProposalFunction defines model for OfflineProposal.Function.
+type OfflineProposalFunction string
+
+// PayoutWallet The token-sdk node and account settlements are paid to
+type PayoutWallet struct {
+	Account string `json:"Account"`
+	Node    string `json:"Node"`
+}
+
+// Settlement defines model for Settlement.
+type Settlement struct {
+	// Amount An amount in a currency
+	Amount   Money     `json:"Amount"`
+	ClosedAt time.Time `json:"ClosedAt"`
+	ID       string    `json:"ID"`
+
+	// PayoutWallet The token-sdk node and account settlements are paid to
+	PayoutWallet *PayoutWallet `json:"PayoutWallet,omitempty"`
+	Period       string        `json:"Period"`
+	SettledAt    time.Time     `json:"SettledAt"`
+	Status       string        `json:"Status"`
+	TechnicianID string        `json:"TechnicianID"`
+	TokenTxID    string        `json:"TokenTxID"`
+}
+
+// SettlementResult The outcome of paying out a single closed settlement
+type SettlementResult struct {
+	Error        string `json:"Error,omitempty"`
+	SettlementID string `json:"SettlementID"`
+	TokenTxID    string `json:"TokenTxID,omitempty"`
+}
+
+// Signed defines model for Signed.
+type Signed = offline.Signed
+
+// TakeJobParams defines model for TakeJobParams.
+type TakeJobParams struct {
+	// WorkId The ID of the job
+	JobID string `json:"workId"`
+}
+
+// Transaction A transaction that was submitted asynchronously. Every such transaction was endorsed and ordered, it
+// is committed once its status is committed or invalid, and only committed transactions changed the
+// ledger. The status is unknown when its commit could not be waited for.
+type Transaction = transaction.Status
+
+// Unsigned A proposal, transaction or commit status request to sign. The client signs the digest and sends the
+// bytes back with the signature.
+type Unsigned = offline.Unsigned
+
+// Accepted A transaction that was submitted asynchronously. Every such transaction was endorsed and ordered, it
+// is committed once its status is committed or invalid, and only committed transactions changed the
+// ledger. The status is unknown when its commit could not be waited for.
+type Accepted = Transaction
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse = Error
+
+// GeneralContractSuccess defines model for GeneralContractSuccess.
+type GeneralContractSuccess = GeneralContract
+
+// MessageSuccess defines model for MessageSuccess.
+type MessageSuccess = Message
+
+// SetPayoutWalletJSONRequestBody defines body for SetPayoutWallet for application/json ContentType.
+type SetPayoutWalletJSONRequestBody = PayoutWallet
+
+// FinishJobCorrectErrorJSONRequestBody defines body for FinishJobCorrectError for application/json ContentType.
+type FinishJobCorrectErrorJSONRequestBody = JobDoneParams
+
+// FinishJobWrongErrorJSONRequestBody defines body for FinishJobWrongError for application/json ContentType.
+type FinishJobWrongErrorJSONRequestBody = JobDoneParams
+
+// TakeJobJSONRequestBody defines body for TakeJob for application/json ContentType.
+type TakeJobJSONRequestBody = TakeJobParams
+
+// GetOfflineCommitStatusJSONRequestBody defines body for GetOfflineCommitStatus for application/json ContentType.
+type GetOfflineCommitStatusJSONRequestBody = Signed
+
+// EndorseOfflineProposalJSONRequestBody defines body for EndorseOfflineProposal for application/json ContentType.
+type EndorseOfflineProposalJSONRequestBody = Signed
+
+// CreateOfflineProposalJSONRequestBody defines body for CreateOfflineProposal for application/json ContentType.
+type CreateOfflineProposalJSONRequestBody = OfflineProposal
+
+// SubmitOfflineTransactionJSONRequestBody defines body for SubmitOfflineTransaction for application/json ContentType.
+type SubmitOfflineTransactionJSONRequestBody = Signed
+
+// ClosePeriodJSONRequestBody defines body for ClosePeriod for application/json ContentType.
+type ClosePeriodJSONRequestBody = ClosePeriodParams
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get the general contract of the technician organisation
+	// (GET /gc)
+	ReadGeneralContract(c *gin.Context)
+	// Create the general contract of the technician organisation
+	// (POST /gc/create)
+	CreateGeneralContract(c *gin.Context)
+	// Get every job
+	// (GET /gc/jobs)
+	GetAllJobs(c *gin.Context)
+	// Get the settlements of the technician organisation
+	// (GET /gc/settlements)
+	GetSettlements(c *gin.Context)
+	// Set the token wallet the technician organisation is paid to
+	// (PUT /gc/wallet)
+	SetPayoutWallet(c *gin.Context)
+	// Finish a job whose reported error was correct
+	// (POST /job/done_correct)
+	FinishJobCorrectError(c *gin.Context)
+	// Finish a job whose reported error was wrong
+	// (POST /job/done_wrong)
+	FinishJobWrongError(c *gin.Context)
+	// Add a job to the general contract
+	// (POST /job/take)
+	TakeJob(c *gin.Context)
+	// Get the status of a job of the general contract
+	// (GET /job/{id})
+	GetJob(c *gin.Context, id string)
+	// Wait for the commit of a transaction with a signed commit status request
+	// (POST /offline/commits)
+	GetOfflineCommitStatus(c *gin.Context)
+	// Have a signed proposal endorsed
+	// (POST /offline/endorsements)
+	EndorseOfflineProposal(c *gin.Context)
+	// Build the proposal of a transaction for the certificate of the technician
+	// (POST /offline/proposals)
+	CreateOfflineProposal(c *gin.Context)
+	// Submit a signed transaction to the orderer
+	// (POST /offline/transactions)
+	SubmitOfflineTransaction(c *gin.Context)
+	// Close a month of a technician, which can then be settled
+	// (POST /settlements/close)
+	ClosePeriod(c *gin.Context)
+	// Pay out every closed settlement
+	// (POST /settlements/run)
+	RunSettlement(c *gin.Context)
+	// Get the commit status of a transaction that was submitted asynchronously
+	// (GET /tx/{id})
+	GetTransaction(c *gin.Context, id string)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// ReadGeneralContract operation middleware
+func (siw *ServerInterfaceWrapper) ReadGeneralContract(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReadGeneralContract(c)
+}
+
+// CreateGeneralContract operation middleware
+func (siw *ServerInterfaceWrapper) CreateGeneralContract(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateGeneralContract(c)
+}
+
+// GetAllJobs operation middleware
+func (siw *ServerInterfaceWrapper) GetAllJobs(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAllJobs(c)
+}
+
+// GetSettlements operation middleware
+func (siw *ServerInterfaceWrapper) GetSettlements(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetSettlements(c)
+}
+
+// SetPayoutWallet operation middleware
+func (siw *ServerInterfaceWrapper) SetPayoutWallet(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetPayoutWallet(c)
+}
+
+// FinishJobCorrectError operation middleware
+func (siw *ServerInterfaceWrapper) FinishJobCorrectError(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FinishJobCorrectError(c)
+}
+
+// FinishJobWrongError operation middleware
+func (siw *ServerInterfaceWrapper) FinishJobWrongError(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.FinishJobWrongError(c)
+}
+
+// TakeJob operation middleware
+func (siw *ServerInterfaceWrapper) TakeJob(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.TakeJob(c)
+}
+
+// GetJob operation middleware
+func (siw *ServerInterfaceWrapper) GetJob(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetJob(c, id)
+}
+
+// GetOfflineCommitStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetOfflineCommitStatus(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetOfflineCommitStatus(c)
+}
+
+// EndorseOfflineProposal operation middleware
+func (siw *ServerInterfaceWrapper) EndorseOfflineProposal(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EndorseOfflineProposal(c)
+}
+
+// CreateOfflineProposal operation middleware
+func (siw *ServerInterfaceWrapper) CreateOfflineProposal(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateOfflineProposal(c)
+}
+
+// SubmitOfflineTransaction operation middleware
+func (siw *ServerInterfaceWrapper) SubmitOfflineTransaction(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"technician"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SubmitOfflineTransaction(c)
+}
+
+// ClosePeriod operation middleware
+func (siw *ServerInterfaceWrapper) ClosePeriod(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ClosePeriod(c)
+}
+
+// RunSettlement operation middleware
+func (siw *ServerInterfaceWrapper) RunSettlement(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RunSettlement(c)
+}
+
+// GetTransaction operation middleware
+func (siw *ServerInterfaceWrapper) GetTransaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTransaction(c, id)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.GET(options.BaseURL+"/gc", wrapper.ReadGeneralContract)
+	router.POST(options.BaseURL+"/gc/create", wrapper.CreateGeneralContract)
+	router.GET(options.BaseURL+"/gc/jobs", wrapper.GetAllJobs)
+	router.GET(options.BaseURL+"/gc/settlements", wrapper.GetSettlements)
+	router.PUT(options.BaseURL+"/gc/wallet", wrapper.SetPayoutWallet)
+	router.POST(options.BaseURL+"/job/done_correct", wrapper.FinishJobCorrectError)
+	router.POST(options.BaseURL+"/job/done_wrong", wrapper.FinishJobWrongError)
+	router.POST(options.BaseURL+"/job/take", wrapper.TakeJob)
+	router.GET(options.BaseURL+"/job/:id", wrapper.GetJob)
+	router.POST(options.BaseURL+"/offline/commits", wrapper.GetOfflineCommitStatus)
+	router.POST(options.BaseURL+"/offline/endorsements", wrapper.EndorseOfflineProposal)
+	router.POST(options.BaseURL+"/offline/proposals", wrapper.CreateOfflineProposal)
+	router.POST(options.BaseURL+"/offline/transactions", wrapper.SubmitOfflineTransaction)
+	router.POST(options.BaseURL+"/settlements/close", wrapper.ClosePeriod)
+	router.POST(options.BaseURL+"/settlements/run", wrapper.RunSettlement)
+	router.GET(options.BaseURL+"/tx/:id", wrapper.GetTransaction)
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xc23LbONJ+FRT//5KSPJ7JVK3vFDvJ2BMnLtszqVTsSkFkS0JMAlwAjKKd0rtvNQCC",
+	"4EmSHSfO7uxVZB4affjQ3ehu5q8oEXkhOHCtoqO/IgmqEFyB+WOaJFBoSPF3IrgGrvEnLYqMJVQzwSef",
+	"lOB4TSVLyCn++n8J8+go+r9JTXhi76rJtaRc0QTfjDabTRyloBLJCnPhKLpeAtH1I2RFFQGeCqkgJZSn",
+	"RMgUJKSx+UMvgUj4ZwlKE6ruICVcaKIFWVGmyVxIwrQiichzpsmK6eUNv5AwB3lErJTpiKo1T8bktH6O",
+	"KSKhEFLjitqs8VpYWcdRHC2BpiCNcqrL+LspxrslSDCvOqJiTnRLtGCdmEz0l8lfLN1EcaBHvS4gOoqU",
+	"lowvIlSW5R54AqMpmsAapn4DvtC8yPClhnxR3KGF1F5IKeSlM/ejWdhQHbJtZa05ZRmk0SaOXgEHSbNj",
+	"wbWkib4qkwSUejRuWuSH+FrYx0jin4ujc1CKLuCxOXJkd2lI4bKQopI2FSgME8eZUHABkon0gkqam4uF",
+	"FAVIzeyutXe7sET6ueB6iXskQTqEKvL+/fv3o/PzKA7gc3hw+Mvo4FkXOHF0DcmSs4RRfnrSj1IUgUnE",
+	"5ofm03HF2a2nK2afwKr72OwU52ua8swykdy9KfMZSPxzLmROdXQUMa5//aXmkXENC5BILNhop2kPl3H0",
+	"mWYsNfY7Fik0N8+f09eG2+2iNdeIG1x26HckjqMvo4UYVRfn84xxGNdKCO6PWI5+wuiF6mV0FC2YXpaz",
+	"cSLyCadZBr/+/NNkTmeSJSMOeiXk3SQEqFpSCenELRL5vd/VdFLpgpc5ysi4keOjQ2UURyWnpV4Kyf5l",
+	"mJwLOWNpCjyKIy70x7koOV5PBJ9nzMiJZpGcZqgimn5cUA0rukb1shxEqaPbjqZxY2jKMsMT05CrXbvK",
+	"yHNiXoo2nh6Vkq4bqlR3rBgJsyFoNiqEYS460rKETRxBpZW2O6earIBrspKCL2Ljy3O7jyvXniwp46g+",
+	"sloCJ0wTCWhoSNuev29T7cDrvhK0EGrFia1V+/ZcqLV+b1ELSUkBIImQLgZLopd0q5RNaNE0lc6PPky4",
+	"OHLc9O7nXBWPqLlqpT6ltUNKZxOdidnU7hG9bgC4a/YQppsY39wf8mdi1kfjHB18tn5OM8oT2BmNBAfz",
+	"2gVdi1K/Q2+id73UeHYTR1egdQZ5lUHuxX39zlfs16+KRS1FOe3HTfP1AQAV3zH61KO7u4sc9CtXkYuV",
+	"CRG9SOU0x4tT+woudwI0NX47DH0p1TBCBzpEZw/t9eosjk65KsDs4Qu63hs/Z2J2n8fPjQ76lr/SVJf9",
+	"2+XaXNhpZrzr6XjO2oIZ+StG4sp+A/Y+ERyGsq0zMdsHffaxPvrntVtrUh72d/dwV1blHVxOOaG5KLkm",
+	"jBNKklJK4Mm647an5qGe90kKCctpRniV8tQJ1E+H497c8bhapZFuXb34fWey5dgISOzIqBx3Yyv+12ZT",
+	"PrpXuZQjb/h8a/OqCykKoWjWk1XhzzlmY9DvIC5enJPgIX9c9A4rJqslS5YkkUA1KHP6VWzB1T7Jxbzk",
+	"SXVOrRK7Y0OoHcri6JreAfo3j/pjISUk+oVLJdzVd5gH2Wt92dsnMTsdOH+cnlTSfRKz2BzS4TPINam4",
+	"JLNSkyH2trlMu8HukU2oYojJ86sLn9fVZrF5n5ALypkymXX1UJBsk9mapDCnZbaL3/Ori3vw29oPIaQC",
+	"C/c5gHZk74qrxR3wkUrvCMcEFsFFk8Q4B1WHdkIlkIKylGjRdRP2+V63/cYdKrbvcPNU7An1SRLkDN34",
+	"6/3UXvHHnKLTqd4zqA5Hy6/Jm+pz+l4nbyv+vZiug2m9ghX9AUf7OLpGoFx/2SfcGWj3n/7j2pv7IO3t",
+	"ES4SirwdD5egymwA3aLUiciNUy3omvEFXiGUKMYXGdg6SBoAvYNtf1Z+aKJV83l/vT7QPzSW7NUdW/De",
+	"Wstaw0Aaa25VLq9w0S5uFDWFrCqeylg2KM8SCbqUvKoaWPAiyT4kYmyjupQD8dLfRm6YViRlC1ue2EG5",
+	"pSUrbLjcnnUap71vXKRxwXgo8UQSe4XZvQJnWzeOeh92wiJ+T2IYAsLUCLCKr8qZrWwRU5JeSsFFqbL1",
+	"mLww4V+VyXLP0j/TN5xV9XqkKHgCBgYOdM2bkrgqlm0aCJ6tg9vBkgqLOHxhaxk3PIN0AXJMDOA84ZLf",
+	"cbHiVZWnWokkosxs/2EGpv8AKSY34443aRUze7YZPtBpF6xotRRSZjyK96mD+ry11+14evcJKYM1snXY",
+	"8BhQx670tHNTDjh2U5Jr1t0q/+ILcB49LV0iXVUHRpcRO3hFgVpM8dJAxxQ+jeF7810P7vso8iE16qYS",
+	"fhMr64sBJHEPd8WNialne7UEICcW442z2/mfx8cfL19MTz4ev33z8vXp8fW9K+E16gLrep031bXD4Qak",
+	"xz5ZeHSn2wDHJo7+4MqHx7Z3u2fg08JEK+tGkowB18HJzcYte5wDnirreWygndHkznQtzZM+RPV4lCpk",
+	"7wyrdrl+t3P123R0+OzXiiXH9z7B+gF7NLY+O0wk0LlWO/Yh22VXd8aFeqeCPeO8B8I3jfToQSApseB4",
+	"hUcFZ1agEiSWIuu/Xla2OHt3XfWKkV97t1bbUuvCEmZ8LvoN3u55dqsOzbNu+6ArS64IVXGVZSh0Lpre",
+	"ufKEQXJua6yNY6TtsZuDJCbiDuFSlAuLdH8aHZPnNMGu/syGlg7DHlfjG37DT2xJxp5Tz67eviEWGsrm",
+	"FlSRG1OYuonG5B36QmxkAdeVPAZ/dJZB6ioSuMfsLQ6QKkKJVfMNNyxawVUiCpsVUx68QV3/X4rM3rX1",
+	"G7xUKpAVRU0yoEoTwa0MbysCymZO1lMS2koFOtMNYzL1Hse7DDuqQHpHHkwGRblaYcyzrxweHKKSlED2",
+	"Vd+8gguRhHGlgbp8ig2NTtzwaqrBOr854zQLMimaKUEKofBpLSy8ZkpkpQaC+EWfiv8q8sfla9uBOqZZ",
+	"hm5xhFeseEZtPTknThgopOt4m0FCSwVY6hTcVjt1460qLK7wbdO+QwkddG54NyzGoceyLrzOcReUcULn",
+	"GiShZCHFCs+dkvJU5CSFjK7jepME6fToErRkoCrbKbpWZClWRMw18NDKFh6aZRmpeq3KLWgCO6KKag15",
+	"gXa54U1b/3LwjzGZBol4Mw9G9HKBOkBuUqPjS7uwAybuczTQnHGmloSiA8CiERQYzchpCnkhNBZqR7/D",
+	"uhJHzElZoFEOnz0jhWRc434j06vj09MbniwpbmuQykVLHzbuAAqLyOAwrw2mpNJd5N+B0y81IqzrO4rm",
+	"cMPvYG1uz0S6djHJyVXRdxDHdWTJOVrP2DSuSYVmOz0JRPSM3fAQYLig14seXUKR0TWkRwTP72NyCaUy",
+	"yyD3ZntTLvQSpBcP94w39tg00LVJ2Z4fPg89M04fgFTW0/80PsDoKQrgtGDRUfTz+GD8cxSb2GXizGSR",
+	"4D8LW7/yLgyDbHQJNO1WYRsjYocHB0N1L//cZGDKxzT6ba10J4nmtFIYM6OjD81o+SGqY1h0u7nFjDPP",
+	"qVxHR9Er0P3BZHv0Q23ThULa1RvRLTIxWSQTW5U3CYFQPVocqmbfX4+tmaRNjPXC3a/5Mb4nULgV/tF1",
+	"/sm1ynth+wr0NMtcP7dPy3tPcj28E98/3mXYfiLY26zG1YOcYg0/Xqmq2cgf0m3Y7/8e+t0yKzCg5lCQ",
+	"J3QyYdq7N9ZD3r1lVr7BUJQ9RrkC3Wgu2LMQKP1cpOtHG11sLLFpnrhcqfsxQ8MP79qunJnNcYBYE209",
+	"QjEVNNCG7f1JzCap4PAxsc3XMLK0yxE9+U0n+6oznO0JFOYUTVy9NOndmZi12sDfAl3NMYvHgtd/YMR8",
+	"GebUq6VQUB+rTPXVFYMtNnp9uUeQGVj8AfATDAz8Dz0/AHosLgaxg8e7p0NNPYTyLZDS7Kr9jZEyTVMH",
+	"Ey168/NhfJhvVbZkiNZ6BaoYtPlc5sM+XUqGN0xRNY5cc9L0Xpr22faNzO1X5qM70/zBtP5J80xbVBNz",
+	"Z08xv4c9XR16Ygtlatu2b1bZbOMJm++201d1oEintEi5dT0xFp5MbUNiccUXrrou4BVoN1ZnP4nwvZ9v",
+	"4RFcN39/V/Aoq9bfegyAqrdJ/RQwe1cVnJtftLWq0sbQxPZL+pthAf4c6FoQdNVUfwDtx+FlEFgaiLQt",
+	"K1eiWw13n7pwe2EXbk9y/jfBzbeyBtBWd8u7Kn0K0P1GP0ONJ98lDFuEW7FUvbEFSNet9qOt4/l+U2f0",
+	"s2bWtXDr0aMxcWkF0sHsifsNM3QYFHNiRlC7aLQls+8DxvYqPxgqvXmeEInPS5alzV51x/t577htinsn",
+	"ZgOKe/q/PYYOBpp4OGad07XrIWG7rO6WdSF5ZVpbDizXjXnzv4+L3KrsJylAVe1h5yVbrtuOq6O55SD0",
+	"gurTxIzCbmlj1F8efyOzd79t/s4ICCvNAxjojAv/OEc9BfIzS2AkVhxkpw9jv/d2H4BbB9b9voSaxJ7j",
+	"1J4VMd1aqQzRI0s+7LNqxbpGa2NA0A+DUBnO8YVHh65Luiz5VTi0/T37EG7efM9uRFD9sD2YJnqeAAwX",
+	"dG30bdnpG4Eftrn+0j7+NyV+iwO2ftBltRTBXEQ7ZUe8KQCCEyz2iAd2+uAOCu2a4GQpShmMNgyFqFeg",
+	"m4HpHuWH5hdUP3YZ4n7/hcrXAqxTa2gGwU4itHPgO8BWI9253WwHtEUwyM/99pwdzka0KKI4KmXmJuCO",
+	"JpNMJDRbCqWPnh0cHESbW7/6I4zE1fAI/t+QNl3sQm8pyDgCri/cftl9o4wfrohS70Gn2fjsyBgoPDAQ",
+	"OlozikeZxhEUl8yyaqa1Jt+w2C76NieZrTsq5I46ztGngF6qXsFPRd5u/j0A0XP6tJpIAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,2496 @@
+// Package apiclient provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.13.4 DO NOT EDIT.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/nalle631/fabric-network/application/shared/offline"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ErrorCode.
+const (
+	BadGateway     ErrorCode = "bad_gateway"
+	Conflict       ErrorCode = "conflict"
+	Forbidden      ErrorCode = "forbidden"
+	Internal       ErrorCode = "internal"
+	InvalidRequest ErrorCode = "invalid_request"
+	NotFound       ErrorCode = "not_found"
+	Timeout        ErrorCode = "timeout"
+	Unauthorized   ErrorCode = "unauthorized"
+)
+
+// Defines values for OfflineProposalFunction.
+const (
+	CreateGeneralContract OfflineProposalFunction = "CreateGeneralContract"
+	JobDoneCorrectError   OfflineProposalFunction = "JobDoneCorrectError"
+	JobDoneWrongError     OfflineProposalFunction = "JobDoneWrongError"
+	TakeJob               OfflineProposalFunction = "TakeJob"
+)
+
+// ClosePeriodParams defines model for ClosePeriodParams.
+type ClosePeriodParams struct {
+	// Period The month to close as YYYY-MM
+	Period       string `json:"Period"`
+	TechnicianID string `json:"TechnicianID"`
+}
+
+// Committed defines model for Committed.
+type Committed = offline.Committed
+
+// Error defines model for Error.
+type Error struct {
+	Code    ErrorCode     `json:"code"`
+	Details []ErrorDetail `json:"details,omitempty"`
+
+	// Error What went wrong, the message of the chaincode when it rejected the transaction
+	Error         string `json:"error"`
+	TransactionId string `json:"transactionId,omitempty"`
+}
+
+// ErrorCode defines model for Error.Code.
+type ErrorCode string
+
+// ErrorDetail The message of a peer or orderer that rejected the transaction
+type ErrorDetail struct {
+	Address string `json:"address,omitempty"`
+	Message string `json:"message"`
+	MspId   string `json:"mspId,omitempty"`
+}
+
+// GeneralContract defines model for GeneralContract.
+type GeneralContract struct {
+	JobAuthority []string `json:"JobAuthority"`
+	Jobs         []Job    `json:"Jobs"`
+
+	// MonthlyBalance An amount in a currency
+	MonthlyBalance Money `json:"MonthlyBalance"`
+
+	// PayoutWallet The token-sdk node and account settlements are paid to
+	PayoutWallet *PayoutWallet `json:"PayoutWallet,omitempty"`
+	Settlements  []Settlement  `json:"Settlements,omitempty"`
+	TechnicianID string        `json:"TechnicianID"`
+}
+
+// Job defines model for Job.
+type Job struct {
+	// Adress The address of the mower
+	Address  string    `json:"Adress"`
+	Deadline time.Time `json:"Deadline,omitempty"`
+	ID       string    `json:"ID"`
+
+	// InspectionPay An amount in a currency
+	InspectionPay Money `json:"InspectionPay"`
+
+	// JobPay An amount in a currency
+	JobPay Money  `json:"JobPay"`
+	Mower  string `json:"Mower"`
+	Status string `json:"Status"`
+	Type   string `json:"Type"`
+}
+
+// JobDoneParams defines model for JobDoneParams.
+type JobDoneParams struct {
+	JobID string `json:"JobID"`
+}
+
+// Message defines model for Message.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// Money An amount in a currency
+type Money = decimal.Money
+
+// OfflineProposal defines model for OfflineProposal.
+type OfflineProposal struct {
+	// Certificate The PEM certificate of the technician, which creates and signs the transaction
+	Certificate string                  `json:"certificate"`
+	Function    OfflineProposalFunction `json:"function"`
+
+	// JobId The ID of the job, for every function but CreateGeneralContract
+	JobID string `json:"jobId,omitempty"`
+
+	// MspId The MSP of the certificate, the organisation of the application by default
+	MSPID string `json:"mspId,omitempty"`
+}
+
+// OfflineProposalFunction defines model for OfflineProposal.Function.
+type OfflineProposalFunction string
+
+// PayoutWallet The token-sdk node and account settlements are paid to
+type PayoutWallet struct {
+	Account string `json:"Account"`
+	Node    string `json:"Node"`
+}
+
+// Settlement defines model for Settlement.
+type Settlement struct {
+	// Amount An amount in a currency
+	Amount   Money     `json:"Amount"`
+	ClosedAt time.Time `json:"ClosedAt"`
+	ID       string    `json:"ID"`
+
+	// PayoutWallet The token-sdk node and account settlements are paid to
+	PayoutWallet *PayoutWallet `json:"PayoutWallet,omitempty"`
+	Period       string        `json:"Period"`
+	SettledAt    time.Time     `json:"SettledAt"`
+	Status       string        `json:"Status"`
+	TechnicianID string        `json:"TechnicianID"`
+	TokenTxID    string        `json:"TokenTxID"`
+}
+
+// SettlementResult The outcome of paying out a single closed settlement
+type SettlementResult struct {
+	Error        string `json:"Error,omitempty"`
+	SettlementID string `json:"SettlementID"`
+	TokenTxID    string `json:"TokenTxID,omitempty"`
+}
+
+// Signed defines model for Signed.
+type Signed = offline.Signed
+
+// TakeJobParams defines model for TakeJobParams.
+type TakeJobParams struct {
+	// WorkId The ID of the job
+	JobID string `json:"workId"`
+}
+
+// Transaction A transaction that was submitted asynchronously. Every such transaction was endorsed and ordered, it
+// is committed once its status is committed or invalid, and only committed transactions changed the
+// ledger. The status is unknown when its commit could not be waited for.
+type Transaction = transaction.Status
+
+// Unsigned A proposal, transaction or commit status request to sign. The client signs the digest and sends the
+// bytes back with the signature.
+type Unsigned = offline.Unsigned
+
+// Accepted A transaction that was submitted asynchronously. Every such transaction was endorsed and ordered, it
+// is committed once its status is committed or invalid, and only committed transactions changed the
+// ledger. The status is unknown when its commit could not be waited for.
+type Accepted = Transaction
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse = Error
+
+// GeneralContractSuccess defines model for GeneralContractSuccess.
+type GeneralContractSuccess = GeneralContract
+
+// MessageSuccess defines model for MessageSuccess.
+type MessageSuccess = Message
+
+// SetPayoutWalletJSONRequestBody defines body for SetPayoutWallet for application/json ContentType.
+type SetPayoutWalletJSONRequestBody = PayoutWallet
+
+// FinishJobCorrectErrorJSONRequestBody defines body for FinishJobCorrectError for application/json ContentType.
+type FinishJobCorrectErrorJSONRequestBody = JobDoneParams
+
+// FinishJobWrongErrorJSONRequestBody defines body for FinishJobWrongError for application/json ContentType.
+type FinishJobWrongErrorJSONRequestBody = JobDoneParams
+
+// TakeJobJSONRequestBody defines body for TakeJob for application/json ContentType.
+type TakeJobJSONRequestBody = TakeJobParams
+
+// GetOfflineCommitStatusJSONRequestBody defines body for GetOfflineCommitStatus for application/json ContentType.
+type GetOfflineCommitStatusJSONRequestBody = Signed
+
+// EndorseOfflineProposalJSONRequestBody defines body for EndorseOfflineProposal for application/json ContentType.
+type EndorseOfflineProposalJSONRequestBody = Signed
+
+// CreateOfflineProposalJSONRequestBody defines body for CreateOfflineProposal for application/json ContentType.
+type CreateOfflineProposalJSONRequestBody = OfflineProposal
+
+// SubmitOfflineTransactionJSONRequestBody defines body for SubmitOfflineTransaction for application/json ContentType.
+type SubmitOfflineTransactionJSONRequestBody = Signed
+
+// ClosePeriodJSONRequestBody defines body for ClosePeriod for application/json ContentType.
+type ClosePeriodJSONRequestBody = ClosePeriodParams
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ReadGeneralContract request
+	ReadGeneralContract(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateGeneralContract request
+	CreateGeneralContract(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAllJobs request
+	GetAllJobs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSettlements request
+	GetSettlements(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetPayoutWalletWithBody request with any body
+	SetPayoutWalletWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetPayoutWallet(ctx context.Context, body SetPayoutWalletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FinishJobCorrectErrorWithBody request with any body
+	FinishJobCorrectErrorWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	FinishJobCorrectError(ctx context.Context, body FinishJobCorrectErrorJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// FinishJobWrongErrorWithBody request with any body
+	FinishJobWrongErrorWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	FinishJobWrongError(ctx context.Context, body FinishJobWrongErrorJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TakeJobWithBody request with any body
+	TakeJobWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TakeJob(ctx context.Context, body TakeJobJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetJob request
+	GetJob(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOfflineCommitStatusWithBody request with any body
+	GetOfflineCommitStatusWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	GetOfflineCommitStatus(ctx context.Context, body GetOfflineCommitStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EndorseOfflineProposalWithBody request with any body
+	EndorseOfflineProposalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	EndorseOfflineProposal(ctx context.Context, body EndorseOfflineProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateOfflineProposalWithBody request with any body
+	CreateOfflineProposalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateOfflineProposal(ctx context.Context, body CreateOfflineProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubmitOfflineTransactionWithBody request with any body
+	SubmitOfflineTransactionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubmitOfflineTransaction(ctx context.Context, body SubmitOfflineTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ClosePeriodWithBody request with any body
+	ClosePeriodWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ClosePeriod(ctx context.Context, body ClosePeriodJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RunSettlement request
+	RunSettlement(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransaction request
+	GetTransaction(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ReadGeneralContract(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadGeneralContractRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateGeneralContract(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateGeneralContractRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAllJobs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAllJobsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSettlements(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSettlementsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPayoutWalletWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPayoutWalletRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPayoutWallet(ctx context.Context, body SetPayoutWalletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPayoutWalletRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FinishJobCorrectErrorWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFinishJobCorrectErrorRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FinishJobCorrectError(ctx context.Context, body FinishJobCorrectErrorJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFinishJobCorrectErrorRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FinishJobWrongErrorWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFinishJobWrongErrorRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) FinishJobWrongError(ctx context.Context, body FinishJobWrongErrorJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFinishJobWrongErrorRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TakeJobWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTakeJobRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TakeJob(ctx context.Context, body TakeJobJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTakeJobRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetJob(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJobRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOfflineCommitStatusWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOfflineCommitStatusRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOfflineCommitStatus(ctx context.Context, body GetOfflineCommitStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOfflineCommitStatusRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EndorseOfflineProposalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEndorseOfflineProposalRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EndorseOfflineProposal(ctx context.Context, body EndorseOfflineProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEndorseOfflineProposalRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateOfflineProposalWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOfflineProposalRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateOfflineProposal(ctx context.Context, body CreateOfflineProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateOfflineProposalRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitOfflineTransactionWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitOfflineTransactionRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitOfflineTransaction(ctx context.Context, body SubmitOfflineTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitOfflineTransactionRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ClosePeriodWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewClosePeriodRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ClosePeriod(ctx context.Context, body ClosePeriodJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewClosePeriodRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RunSettlement(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRunSettlementRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransaction(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewReadGeneralContractRequest generates requests for ReadGeneralContract
+func NewReadGeneralContractRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/gc")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateGeneralContractRequest generates requests for CreateGeneralContract
+func NewCreateGeneralContractRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/gc/create")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAllJobsRequest generates requests for GetAllJobs
+func NewGetAllJobsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/gc/jobs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSettlementsRequest generates requests for GetSettlements
+func NewGetSettlementsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/gc/settlements")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetPayoutWalletRequest calls the generic SetPayoutWallet builder with application/json body
+func NewSetPayoutWalletRequest(server string, body SetPayoutWalletJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetPayoutWalletRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSetPayoutWalletRequestWithBody generates requests for SetPayoutWallet with any type of body
+func NewSetPayoutWalletRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/gc/wallet")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewFinishJobCorrectErrorRequest calls the generic FinishJobCorrectError builder with application/json body
+func NewFinishJobCorrectErrorRequest(server string, body FinishJobCorrectErrorJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewFinishJobCorrectErrorRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewFinishJobCorrectErrorRequestWithBody generates requests for FinishJobCorrectError with any type of body
+func NewFinishJobCorrectErrorRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/job/done_correct")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewFinishJobWrongErrorRequest calls the generic FinishJobWrongError builder with application/json body
+func NewFinishJobWrongErrorRequest(server string, body FinishJobWrongErrorJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewFinishJobWrongErrorRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewFinishJobWrongErrorRequestWithBody generates requests for FinishJobWrongError with any type of body
+func NewFinishJobWrongErrorRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/job/done_wrong")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTakeJobRequest calls the generic TakeJob builder with application/json body
+func NewTakeJobRequest(server string, body TakeJobJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTakeJobRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewTakeJobRequestWithBody generates requests for TakeJob with any type of body
+func NewTakeJobRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/job/take")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetJobRequest generates requests for GetJob
+func NewGetJobRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/job/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOfflineCommitStatusRequest calls the generic GetOfflineCommitStatus builder with application/json body
+func NewGetOfflineCommitStatusRequest(server string, body GetOfflineCommitStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewGetOfflineCommitStatusRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewGetOfflineCommitStatusRequestWithBody generates requests for GetOfflineCommitStatus with any type of body
+func NewGetOfflineCommitStatusRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/offline/commits")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewEndorseOfflineProposalRequest calls the generic EndorseOfflineProposal builder with application/json body
+func NewEndorseOfflineProposalRequest(server string, body EndorseOfflineProposalJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewEndorseOfflineProposalRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewEndorseOfflineProposalRequestWithBody generates requests for EndorseOfflineProposal with any type of body
+func NewEndorseOfflineProposalRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/offline/endorsements")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateOfflineProposalRequest calls the generic CreateOfflineProposal builder with application/json body
+func NewCreateOfflineProposalRequest(server string, body CreateOfflineProposalJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateOfflineProposalRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateOfflineProposalRequestWithBody generates requests for CreateOfflineProposal with any type of body
+func NewCreateOfflineProposalRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/offline/proposals")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSubmitOfflineTransactionRequest calls the generic SubmitOfflineTransaction builder with application/json body
+func NewSubmitOfflineTransactionRequest(server string, body SubmitOfflineTransactionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubmitOfflineTransactionRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSubmitOfflineTransactionRequestWithBody generates requests for SubmitOfflineTransaction with any type of body
+func NewSubmitOfflineTransactionRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/offline/transactions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewClosePeriodRequest calls the generic ClosePeriod builder with application/json body
+func NewClosePeriodRequest(server string, body ClosePeriodJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewClosePeriodRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewClosePeriodRequestWithBody generates requests for ClosePeriod with any type of body
+func NewClosePeriodRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/settlements/close")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRunSettlementRequest generates requests for RunSettlement
+func NewRunSettlementRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/settlements/run")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionRequest generates requests for GetTransaction
+func NewGetTransactionRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tx/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ReadGeneralContractWithResponse request
+	ReadGeneralContractWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadGeneralContractResponse, error)
+
+	// CreateGeneralContractWithResponse request
+	CreateGeneralContractWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*CreateGeneralContractResponse, error)
+
+	// GetAllJobsWithResponse request
+	GetAllJobsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllJobsResponse, error)
+
+	// GetSettlementsWithResponse request
+	GetSettlementsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSettlementsResponse, error)
+
+	// SetPayoutWalletWithBodyWithResponse request with any body
+	SetPayoutWalletWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPayoutWalletResponse, error)
+
+	SetPayoutWalletWithResponse(ctx context.Context, body SetPayoutWalletJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPayoutWalletResponse, error)
+
+	// FinishJobCorrectErrorWithBodyWithResponse request with any body
+	FinishJobCorrectErrorWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*FinishJobCorrectErrorResponse, error)
+
+	FinishJobCorrectErrorWithResponse(ctx context.Context, body FinishJobCorrectErrorJSONRequestBody, reqEditors ...RequestEditorFn) (*FinishJobCorrectErrorResponse, error)
+
+	// FinishJobWrongErrorWithBodyWithResponse request with any body
+	FinishJobWrongErrorWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*FinishJobWrongErrorResponse, error)
+
+	FinishJobWrongErrorWithResponse(ctx context.Context, body FinishJobWrongErrorJSONRequestBody, reqEditors ...RequestEditorFn) (*FinishJobWrongErrorResponse, error)
+
+	// TakeJobWithBodyWithResponse request with any body
+	TakeJobWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TakeJobResponse, error)
+
+	TakeJobWithResponse(ctx context.Context, body TakeJobJSONRequestBody, reqEditors ...RequestEditorFn) (*TakeJobResponse, error)
+
+	// GetJobWithResponse request
+	GetJobWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetJobResponse, error)
+
+	// GetOfflineCommitStatusWithBodyWithResponse request with any body
+	GetOfflineCommitStatusWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetOfflineCommitStatusResponse, error)
+
+	GetOfflineCommitStatusWithResponse(ctx context.Context, body GetOfflineCommitStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*GetOfflineCommitStatusResponse, error)
+
+	// EndorseOfflineProposalWithBodyWithResponse request with any body
+	EndorseOfflineProposalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EndorseOfflineProposalResponse, error)
+
+	EndorseOfflineProposalWithResponse(ctx context.Context, body EndorseOfflineProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*EndorseOfflineProposalResponse, error)
+
+	// CreateOfflineProposalWithBodyWithResponse request with any body
+	CreateOfflineProposalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOfflineProposalResponse, error)
+
+	CreateOfflineProposalWithResponse(ctx context.Context, body CreateOfflineProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOfflineProposalResponse, error)
+
+	// SubmitOfflineTransactionWithBodyWithResponse request with any body
+	SubmitOfflineTransactionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitOfflineTransactionResponse, error)
+
+	SubmitOfflineTransactionWithResponse(ctx context.Context, body SubmitOfflineTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*SubmitOfflineTransactionResponse, error)
+
+	// ClosePeriodWithBodyWithResponse request with any body
+	ClosePeriodWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ClosePeriodResponse, error)
+
+	ClosePeriodWithResponse(ctx context.Context, body ClosePeriodJSONRequestBody, reqEditors ...RequestEditorFn) (*ClosePeriodResponse, error)
+
+	// RunSettlementWithResponse request
+	RunSettlementWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RunSettlementResponse, error)
+
+	// GetTransactionWithResponse request
+	GetTransactionWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error)
+}
+
+type ReadGeneralContractResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GeneralContractSuccess
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ReadGeneralContractResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReadGeneralContractResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateGeneralContractResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateGeneralContractResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateGeneralContractResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAllJobsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Job
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAllJobsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAllJobsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSettlementsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Settlement
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSettlementsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSettlementsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetPayoutWalletResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GeneralContractSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SetPayoutWalletResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetPayoutWalletResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FinishJobCorrectErrorResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r FinishJobCorrectErrorResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FinishJobCorrectErrorResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FinishJobWrongErrorResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r FinishJobWrongErrorResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FinishJobWrongErrorResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TakeJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r TakeJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TakeJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetJobResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Job
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJobResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJobResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOfflineCommitStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Committed
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOfflineCommitStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOfflineCommitStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EndorseOfflineProposalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Unsigned
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r EndorseOfflineProposalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EndorseOfflineProposalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateOfflineProposalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Unsigned
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateOfflineProposalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateOfflineProposalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubmitOfflineTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Unsigned
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SubmitOfflineTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubmitOfflineTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ClosePeriodResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Settlement
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ClosePeriodResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ClosePeriodResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RunSettlementResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]SettlementResult
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RunSettlementResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RunSettlementResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Transaction
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ReadGeneralContractWithResponse request returning *ReadGeneralContractResponse
+func (c *ClientWithResponses) ReadGeneralContractWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadGeneralContractResponse, error) {
+	rsp, err := c.ReadGeneralContract(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReadGeneralContractResponse(rsp)
+}
+
+// CreateGeneralContractWithResponse request returning *CreateGeneralContractResponse
+func (c *ClientWithResponses) CreateGeneralContractWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*CreateGeneralContractResponse, error) {
+	rsp, err := c.CreateGeneralContract(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateGeneralContractResponse(rsp)
+}
+
+// GetAllJobsWithResponse request returning *GetAllJobsResponse
+func (c *ClientWithResponses) GetAllJobsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllJobsResponse, error) {
+	rsp, err := c.GetAllJobs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAllJobsResponse(rsp)
+}
+
+// GetSettlementsWithResponse request returning *GetSettlementsResponse
+func (c *ClientWithResponses) GetSettlementsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSettlementsResponse, error) {
+	rsp, err := c.GetSettlements(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSettlementsResponse(rsp)
+}
+
+// SetPayoutWalletWithBodyWithResponse request with arbitrary body returning *SetPayoutWalletResponse
+func (c *ClientWithResponses) SetPayoutWalletWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPayoutWalletResponse, error) {
+	rsp, err := c.SetPayoutWalletWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPayoutWalletResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetPayoutWalletWithResponse(ctx context.Context, body SetPayoutWalletJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPayoutWalletResponse, error) {
+	rsp, err := c.SetPayoutWallet(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPayoutWalletResponse(rsp)
+}
+
+// FinishJobCorrectErrorWithBodyWithResponse request with arbitrary body returning *FinishJobCorrectErrorResponse
+func (c *ClientWithResponses) FinishJobCorrectErrorWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*FinishJobCorrectErrorResponse, error) {
+	rsp, err := c.FinishJobCorrectErrorWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFinishJobCorrectErrorResponse(rsp)
+}
+
+func (c *ClientWithResponses) FinishJobCorrectErrorWithResponse(ctx context.Context, body FinishJobCorrectErrorJSONRequestBody, reqEditors ...RequestEditorFn) (*FinishJobCorrectErrorResponse, error) {
+	rsp, err := c.FinishJobCorrectError(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFinishJobCorrectErrorResponse(rsp)
+}
+
+// FinishJobWrongErrorWithBodyWithResponse request with arbitrary body returning *FinishJobWrongErrorResponse
+func (c *ClientWithResponses) FinishJobWrongErrorWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*FinishJobWrongErrorResponse, error) {
+	rsp, err := c.FinishJobWrongErrorWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFinishJobWrongErrorResponse(rsp)
+}
+
+func (c *ClientWithResponses) FinishJobWrongErrorWithResponse(ctx context.Context, body FinishJobWrongErrorJSONRequestBody, reqEditors ...RequestEditorFn) (*FinishJobWrongErrorResponse, error) {
+	rsp, err := c.FinishJobWrongError(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFinishJobWrongErrorResponse(rsp)
+}
+
+// TakeJobWithBodyWithResponse request with arbitrary body returning *TakeJobResponse
+func (c *ClientWithResponses) TakeJobWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TakeJobResponse, error) {
+	rsp, err := c.TakeJobWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTakeJobResponse(rsp)
+}
+
+func (c *ClientWithResponses) TakeJobWithResponse(ctx context.Context, body TakeJobJSONRequestBody, reqEditors ...RequestEditorFn) (*TakeJobResponse, error) {
+	rsp, err := c.TakeJob(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTakeJobResponse(rsp)
+}
+
+// GetJobWithResponse request returning *GetJobResponse
+func (c *ClientWithResponses) GetJobWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetJobResponse, error) {
+	rsp, err := c.GetJob(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJobResponse(rsp)
+}
+
+// GetOfflineCommitStatusWithBodyWithResponse request with arbitrary body returning *GetOfflineCommitStatusResponse
+func (c *ClientWithResponses) GetOfflineCommitStatusWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GetOfflineCommitStatusResponse, error) {
+	rsp, err := c.GetOfflineCommitStatusWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOfflineCommitStatusResponse(rsp)
+}
+
+func (c *ClientWithResponses) GetOfflineCommitStatusWithResponse(ctx context.Context, body GetOfflineCommitStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*GetOfflineCommitStatusResponse, error) {
+	rsp, err := c.GetOfflineCommitStatus(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOfflineCommitStatusResponse(rsp)
+}
+
+// EndorseOfflineProposalWithBodyWithResponse request with arbitrary body returning *EndorseOfflineProposalResponse
+func (c *ClientWithResponses) EndorseOfflineProposalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EndorseOfflineProposalResponse, error) {
+	rsp, err := c.EndorseOfflineProposalWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEndorseOfflineProposalResponse(rsp)
+}
+
+func (c *ClientWithResponses) EndorseOfflineProposalWithResponse(ctx context.Context, body EndorseOfflineProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*EndorseOfflineProposalResponse, error) {
+	rsp, err := c.EndorseOfflineProposal(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEndorseOfflineProposalResponse(rsp)
+}
+
+// CreateOfflineProposalWithBodyWithResponse request with arbitrary body returning *CreateOfflineProposalResponse
+func (c *ClientWithResponses) CreateOfflineProposalWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateOfflineProposalResponse, error) {
+	rsp, err := c.CreateOfflineProposalWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOfflineProposalResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateOfflineProposalWithResponse(ctx context.Context, body CreateOfflineProposalJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateOfflineProposalResponse, error) {
+	rsp, err := c.CreateOfflineProposal(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateOfflineProposalResponse(rsp)
+}
+
+// SubmitOfflineTransactionWithBodyWithResponse request with arbitrary body returning *SubmitOfflineTransactionResponse
+func (c *ClientWithResponses) SubmitOfflineTransactionWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitOfflineTransactionResponse, error) {
+	rsp, err := c.SubmitOfflineTransactionWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitOfflineTransactionResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubmitOfflineTransactionWithResponse(ctx context.Context, body SubmitOfflineTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*SubmitOfflineTransactionResponse, error) {
+	rsp, err := c.SubmitOfflineTransaction(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitOfflineTransactionResponse(rsp)
+}
+
+// ClosePeriodWithBodyWithResponse request with arbitrary body returning *ClosePeriodResponse
+func (c *ClientWithResponses) ClosePeriodWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ClosePeriodResponse, error) {
+	rsp, err := c.ClosePeriodWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClosePeriodResponse(rsp)
+}
+
+func (c *ClientWithResponses) ClosePeriodWithResponse(ctx context.Context, body ClosePeriodJSONRequestBody, reqEditors ...RequestEditorFn) (*ClosePeriodResponse, error) {
+	rsp, err := c.ClosePeriod(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClosePeriodResponse(rsp)
+}
+
+// RunSettlementWithResponse request returning *RunSettlementResponse
+func (c *ClientWithResponses) RunSettlementWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RunSettlementResponse, error) {
+	rsp, err := c.RunSettlement(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRunSettlementResponse(rsp)
+}
+
+// GetTransactionWithResponse request returning *GetTransactionResponse
+func (c *ClientWithResponses) GetTransactionWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error) {
+	rsp, err := c.GetTransaction(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionResponse(rsp)
+}
+
+// ParseReadGeneralContractResponse parses an HTTP response from a ReadGeneralContractWithResponse call
+func ParseReadGeneralContractResponse(rsp *http.Response) (*ReadGeneralContractResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadGeneralContractResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GeneralContractSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateGeneralContractResponse parses an HTTP response from a CreateGeneralContractWithResponse call
+func ParseCreateGeneralContractResponse(rsp *http.Response) (*CreateGeneralContractResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateGeneralContractResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAllJobsResponse parses an HTTP response from a GetAllJobsWithResponse call
+func ParseGetAllJobsResponse(rsp *http.Response) (*GetAllJobsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAllJobsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSettlementsResponse parses an HTTP response from a GetSettlementsWithResponse call
+func ParseGetSettlementsResponse(rsp *http.Response) (*GetSettlementsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSettlementsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Settlement
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetPayoutWalletResponse parses an HTTP response from a SetPayoutWalletWithResponse call
+func ParseSetPayoutWalletResponse(rsp *http.Response) (*SetPayoutWalletResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetPayoutWalletResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GeneralContractSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseFinishJobCorrectErrorResponse parses an HTTP response from a FinishJobCorrectErrorWithResponse call
+func ParseFinishJobCorrectErrorResponse(rsp *http.Response) (*FinishJobCorrectErrorResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FinishJobCorrectErrorResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseFinishJobWrongErrorResponse parses an HTTP response from a FinishJobWrongErrorWithResponse call
+func ParseFinishJobWrongErrorResponse(rsp *http.Response) (*FinishJobWrongErrorResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FinishJobWrongErrorResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTakeJobResponse parses an HTTP response from a TakeJobWithResponse call
+func ParseTakeJobResponse(rsp *http.Response) (*TakeJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TakeJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetJobResponse parses an HTTP response from a GetJobWithResponse call
+func ParseGetJobResponse(rsp *http.Response) (*GetJobResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetJobResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOfflineCommitStatusResponse parses an HTTP response from a GetOfflineCommitStatusWithResponse call
+func ParseGetOfflineCommitStatusResponse(rsp *http.Response) (*GetOfflineCommitStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOfflineCommitStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Committed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEndorseOfflineProposalResponse parses an HTTP response from a EndorseOfflineProposalWithResponse call
+func ParseEndorseOfflineProposalResponse(rsp *http.Response) (*EndorseOfflineProposalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EndorseOfflineProposalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Unsigned
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateOfflineProposalResponse parses an HTTP response from a CreateOfflineProposalWithResponse call
+func ParseCreateOfflineProposalResponse(rsp *http.Response) (*CreateOfflineProposalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateOfflineProposalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Unsigned
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubmitOfflineTransactionResponse parses an HTTP response from a SubmitOfflineTransactionWithResponse call
+func ParseSubmitOfflineTransactionResponse(rsp *http.Response) (*SubmitOfflineTransactionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubmitOfflineTransactionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Unsigned
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseClosePeriodResponse parses an HTTP response from a ClosePeriodWithResponse call
+func ParseClosePeriodResponse(rsp *http.Response) (*ClosePeriodResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ClosePeriodResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Settlement
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRunSettlementResponse parses an HTTP response from a RunSettlementWithResponse call
+func ParseRunSettlementResponse(rsp *http.Response) (*RunSettlementResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RunSettlementResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []SettlementResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionResponse parses an HTTP response from a GetTransactionWithResponse call
+func ParseGetTransactionResponse(rsp *http.Response) (*GetTransactionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,236 @@
+// Package arrowhead registers the services of the application with the Service Registry of an Arrowhead local
+// cloud and asks its Orchestrator for the providers of other services. It talks to the REST APIs of the core
+// systems directly, unlike arrowheadfunctions, so every call can be cancelled and returns its error instead of
+// panicking.
+package arrowhead
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validityLayout is how the Service Registry expects the end of the validity of a registration
+const validityLayout = "2006-01-02 15:04:05"
+
+// System is an application system of the local cloud
+type System struct {
+	SystemName         string `json:"systemName"`
+	Address            string `json:"address"`
+	Port               int    `json:"port"`
+	AuthenticationInfo string `json:"authenticationInfo"`
+}
+
+// ServiceRegistration is a service of a provider system in the Service Registry
+type ServiceRegistration struct {
+	ServiceDefinition string            `json:"serviceDefinition"`
+	ProviderSystem    System            `json:"providerSystem"`
+	ServiceURI        string            `json:"serviceUri"`
+	EndOfValidity     string            `json:"endOfValidity,omitempty"`
+	Secure            string            `json:"secure"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	Version           int               `json:"version"`
+	Interfaces        []string          `json:"interfaces"`
+}
+
+// OrchestrationFlags change how the Orchestrator chooses providers
+type OrchestrationFlags struct {
+	OverrideStore    bool `json:"overrideStore"`
+	EnableInterCloud bool `json:"enableInterCloud"`
+}
+
+// RequestedService is the service a consumer asks the Orchestrator for
+type RequestedService struct {
+	ServiceDefinitionRequirement string   `json:"serviceDefinitionRequirement"`
+	InterfaceRequirements        []string `json:"interfaceRequirements,omitempty"`
+}
+
+type orchestrationRequest struct {
+	RequesterSystem    System             `json:"requesterSystem"`
+	RequestedService   RequestedService   `json:"requestedService"`
+	OrchestrationFlags OrchestrationFlags `json:"orchestrationFlags"`
+}
+
+// Interface is an interface a provider offers a service with, such as HTTP-SECURE-JSON
+type Interface struct {
+	InterfaceName string `json:"interfaceName"`
+}
+
+// Provision is a provider the Orchestrator chose for a requested service
+type Provision struct {
+	Provider   System            `json:"provider"`
+	ServiceURI string            `json:"serviceUri"`
+	Secure     string            `json:"secure"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Interfaces []Interface       `json:"interfaces"`
+}
+
+type orchestrationResponse struct {
+	Response []Provision `json:"response"`
+}
+
+// Client calls the Service Registry and the Orchestrator of a local cloud
+type Client struct {
+	serviceRegistry string
+	orchestrator    string
+	httpClient      *http.Client
+}
+
+// NewClient creates a client of the core systems at the base URLs, such as https://serviceregistry:8443.
+// The orchestrator URL can be empty when no orchestration is needed.
+func NewClient(serviceRegistryURL string, orchestratorURL string, httpClient *http.Client) *Client {
+	return &Client{
+		serviceRegistry: strings.TrimSuffix(serviceRegistryURL, "/"),
+		orchestrator:    strings.TrimSuffix(orchestratorURL, "/"),
+		httpClient:      httpClient,
+	}
+}
+
+// NewTLSClient creates an HTTP client that authenticates with the certificate of the system and trusts the
+// certificates of the truststore, as the core systems of a secure local cloud require
+func NewTLSClient(certPath string, keyPath string, truststorePath string) (*http.Client, error) {
+	certificate, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the Arrowhead certificate: %w", err)
+	}
+	truststore, err := os.ReadFile(truststorePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Arrowhead truststore: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(truststore) {
+		return nil, fmt.Errorf("the Arrowhead truststore %s has no PEM certificates", truststorePath)
+	}
+
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				Certificates: []tls.Certificate{certificate},
+				RootCAs:      pool,
+			},
+		},
+	}, nil
+}
+
+// RegisterSystem registers the system with the Service Registry. A system that is already registered is not an error.
+func (client *Client) RegisterSystem(ctx context.Context, system System) error {
+	err := client.call(ctx, http.MethodPost, client.serviceRegistry+"/serviceregistry/register-system", system, nil)
+	if isAlreadyRegistered(err) {
+		return nil
+	}
+	return err
+}
+
+// UnregisterSystem removes the system and its services from the Service Registry
+func (client *Client) UnregisterSystem(ctx context.Context, system System) error {
+	query := url.Values{
+		"system_name": {system.SystemName},
+		"address":     {system.Address},
+		"port":        {strconv.Itoa(system.Port)},
+	}
+	return client.call(ctx, http.MethodDelete, client.serviceRegistry+"/serviceregistry/unregister-system?"+query.Encode(), nil, nil)
+}
+
+// RegisterService registers the service with the Service Registry
+func (client *Client) RegisterService(ctx context.Context, service ServiceRegistration) error {
+	return client.call(ctx, http.MethodPost, client.serviceRegistry+"/serviceregistry/register", service, nil)
+}
+
+// UnregisterService removes the service from the Service Registry
+func (client *Client) UnregisterService(ctx context.Context, service ServiceRegistration) error {
+	query := url.Values{
+		"service_definition": {service.ServiceDefinition},
+		"system_name":        {service.ProviderSystem.SystemName},
+		"address":            {service.ProviderSystem.Address},
+		"port":               {strconv.Itoa(service.ProviderSystem.Port)},
+		"service_uri":        {service.ServiceURI},
+	}
+	return client.call(ctx, http.MethodDelete, client.serviceRegistry+"/serviceregistry/unregister?"+query.Encode(), nil, nil)
+}
+
+// Orchestrate asks the Orchestrator which providers the requester may consume the service from
+func (client *Client) Orchestrate(ctx context.Context, requester System, service RequestedService, flags OrchestrationFlags) ([]Provision, error) {
+	if client.orchestrator == "" {
+		return nil, fmt.Errorf("no Arrowhead orchestrator is configured")
+	}
+
+	request := orchestrationRequest{RequesterSystem: requester, RequestedService: service, OrchestrationFlags: flags}
+	var response orchestrationResponse
+	err := client.call(ctx, http.MethodPost, client.orchestrator+"/orchestrator/orchestration", request, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Response, nil
+}
+
+// Error is a response of a core system that is not a success
+type Error struct {
+	Method string
+	URL    string
+	Status int
+	Body   string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("arrowhead %s %s: %d %s", e.Method, e.URL, e.Status, e.Body)
+}
+
+// isAlreadyRegistered reports whether the Service Registry refused a registration it already has
+func isAlreadyRegistered(err error) bool {
+	var response *Error
+	return errors.As(err, &response) && response.Status == http.StatusBadRequest && strings.Contains(strings.ToLower(response.Body), "already exists")
+}
+
+func (client *Client) call(ctx context.Context, method string, target string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to reach the Arrowhead core system: %w", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read the response of the Arrowhead core system: %w", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return &Error{Method: method, URL: target, Status: response.StatusCode, Body: strings.TrimSpace(string(responseBody))}
+	}
+
+	if result == nil {
+		return nil
+	}
+	err = json.Unmarshal(responseBody, result)
+	if err != nil {
+		return fmt.Errorf("unexpected response from the Arrowhead core system: %w", err)
+	}
+	return nil
+}
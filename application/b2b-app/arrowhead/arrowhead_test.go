@@ -0,0 +1,221 @@
+package arrowhead
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockCloud is a Service Registry and Orchestrator of a local cloud that keeps its registrations in memory.
+// Like the real Service Registry it refuses a service that is already registered.
+type mockCloud struct {
+	mu            sync.Mutex
+	systems       map[string]System
+	services      map[string]ServiceRegistration
+	registrations int
+}
+
+func newMockCloud(t *testing.T) (*mockCloud, *httptest.Server) {
+	cloud := &mockCloud{systems: map[string]System{}, services: map[string]ServiceRegistration{}}
+	server := httptest.NewServer(cloud)
+	t.Cleanup(server.Close)
+	return cloud, server
+}
+
+func systemKey(name string, address string, port string) string {
+	return name + "@" + address + ":" + port
+}
+
+func serviceKey(definition string, system string, uri string) string {
+	return definition + "/" + system + uri
+}
+
+func (cloud *mockCloud) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cloud.mu.Lock()
+	defer cloud.mu.Unlock()
+
+	query := r.URL.Query()
+	switch r.Method + " " + r.URL.Path {
+	case "POST /serviceregistry/register-system":
+		var system System
+		if err := json.NewDecoder(r.Body).Decode(&system); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		key := systemKey(system.SystemName, system.Address, strconv.Itoa(system.Port))
+		if _, ok := cloud.systems[key]; ok {
+			http.Error(w, `{"errorMessage":"System with name: technician already exists."}`, http.StatusBadRequest)
+			return
+		}
+		cloud.systems[key] = system
+		json.NewEncoder(w).Encode(system)
+
+	case "DELETE /serviceregistry/unregister-system":
+		key := systemKey(query.Get("system_name"), query.Get("address"), query.Get("port"))
+		delete(cloud.systems, key)
+		for serviceKey, service := range cloud.services {
+			provider := service.ProviderSystem
+			if systemKey(provider.SystemName, provider.Address, strconv.Itoa(provider.Port)) == key {
+				delete(cloud.services, serviceKey)
+			}
+		}
+
+	case "POST /serviceregistry/register":
+		var service ServiceRegistration
+		if err := json.NewDecoder(r.Body).Decode(&service); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, err := time.Parse(validityLayout, service.EndOfValidity); err != nil {
+			http.Error(w, "invalid endOfValidity", http.StatusBadRequest)
+			return
+		}
+		key := serviceKey(service.ServiceDefinition, service.ProviderSystem.SystemName, service.ServiceURI)
+		if _, ok := cloud.services[key]; ok {
+			http.Error(w, `{"errorMessage":"Service Registry entry already exists."}`, http.StatusBadRequest)
+			return
+		}
+		cloud.services[key] = service
+		cloud.registrations++
+		json.NewEncoder(w).Encode(service)
+
+	case "DELETE /serviceregistry/unregister":
+		key := serviceKey(query.Get("service_definition"), query.Get("system_name"), query.Get("service_uri"))
+		if _, ok := cloud.services[key]; !ok {
+			http.Error(w, `{"errorMessage":"Service Registry entry does not exist."}`, http.StatusBadRequest)
+			return
+		}
+		delete(cloud.services, key)
+
+	case "POST /orchestrator/orchestration":
+		var request orchestrationRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response := orchestrationResponse{Response: []Provision{}}
+		for _, service := range cloud.services {
+			if service.ServiceDefinition != request.RequestedService.ServiceDefinitionRequirement {
+				continue
+			}
+			provision := Provision{Provider: service.ProviderSystem, ServiceURI: service.ServiceURI, Secure: service.Secure, Metadata: service.Metadata}
+			for _, name := range service.Interfaces {
+				provision.Interfaces = append(provision.Interfaces, Interface{InterfaceName: name})
+			}
+			response.Response = append(response.Response, provision)
+		}
+		json.NewEncoder(w).Encode(response)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (cloud *mockCloud) counts() (int, int, int) {
+	cloud.mu.Lock()
+	defer cloud.mu.Unlock()
+	return len(cloud.systems), len(cloud.services), cloud.registrations
+}
+
+var (
+	technician = System{SystemName: "technician", Address: "127.0.0.1", Port: 5000}
+	services   = []Service{
+		{Definition: "take-job", URI: "/job/take", Method: http.MethodPost},
+		{Definition: "job-status", URI: "/job/{id}", Method: http.MethodGet},
+	}
+)
+
+func TestRegistrar(t *testing.T) {
+	cloud, server := newMockCloud(t)
+	client := NewClient(server.URL, server.URL, server.Client())
+	registrar := NewRegistrar(client, technician, "HTTP-SECURE-JSON", "CERTIFICATE", services, time.Hour)
+	ctx := context.Background()
+
+	if err := registrar.Register(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if systems, registered, _ := cloud.counts(); systems != 1 || registered != 2 {
+		t.Fatalf("%d systems and %d services registered, want 1 and 2", systems, registered)
+	}
+
+	// renewing replaces the registrations even though the registry refuses duplicates
+	if err := registrar.Register(ctx); err != nil {
+		t.Fatalf("renewal failed: %v", err)
+	}
+	if systems, registered, registrations := cloud.counts(); systems != 1 || registered != 2 || registrations != 4 {
+		t.Fatalf("%d systems, %d services and %d registrations after renewal, want 1, 2 and 4", systems, registered, registrations)
+	}
+
+	provisions, err := client.Orchestrate(ctx, System{SystemName: "job-system"}, RequestedService{ServiceDefinitionRequirement: "job-status"}, OrchestrationFlags{OverrideStore: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(provisions) != 1 || provisions[0].Provider.Port != 5000 || provisions[0].ServiceURI != "/job/{id}" || provisions[0].Metadata["http-method"] != http.MethodGet {
+		t.Fatalf("provisions = %+v", provisions)
+	}
+
+	if err := registrar.Unregister(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if systems, registered, _ := cloud.counts(); systems != 0 || registered != 0 {
+		t.Fatalf("%d systems and %d services left after unregistering", systems, registered)
+	}
+}
+
+func TestRegistrarRunRenewsAndUnregistersOnShutdown(t *testing.T) {
+	cloud, server := newMockCloud(t)
+	client := NewClient(server.URL, "", server.Client())
+	registrar := NewRegistrar(client, technician, "HTTP-SECURE-JSON", "CERTIFICATE", services, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		registrar.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, _, registrations := cloud.counts(); registrations >= 6 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the services were not renewed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+	if systems, registered, _ := cloud.counts(); systems != 0 || registered != 0 {
+		t.Fatalf("%d systems and %d services left after shutdown", systems, registered)
+	}
+}
+
+func TestRegistrarRetriesUnreachableRegistry(t *testing.T) {
+	cloud, server := newMockCloud(t)
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	registrar := NewRegistrar(NewClient(unreachable.URL, "", unreachable.Client()), technician, "HTTP-SECURE-JSON", "CERTIFICATE", services, time.Hour)
+	if err := registrar.Register(context.Background()); err == nil {
+		t.Fatal("registering with an unreachable registry must fail")
+	}
+
+	registrar.client = NewClient(server.URL, "", server.Client())
+	if err := registrar.Register(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, registered, _ := cloud.counts(); registered != 2 {
+		t.Fatalf("%d services registered, want 2", registered)
+	}
+
+	if _, err := registrar.client.Orchestrate(context.Background(), technician, RequestedService{ServiceDefinitionRequirement: "take-job"}, OrchestrationFlags{}); err == nil {
+		t.Fatal("orchestrating without an orchestrator must fail")
+	}
+}
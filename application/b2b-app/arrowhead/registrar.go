@@ -0,0 +1,117 @@
+package arrowhead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// unregisterTimeout is how long unregistering may take once the application shuts down
+const unregisterTimeout = 10 * time.Second
+
+// Service is an endpoint of the application to offer in the local cloud
+type Service struct {
+	Definition string
+	URI        string
+	Method     string
+}
+
+// Registrar keeps the services of a system registered with the Service Registry. Registrations are valid for
+// twice the renewal interval, so the Service Registry drops them by itself when the application dies.
+type Registrar struct {
+	client     *Client
+	system     System
+	services   []ServiceRegistration
+	interval   time.Duration
+	registered bool
+}
+
+// NewRegistrar creates a registrar of the services of the system, offered with the interface, such as
+// HTTP-SECURE-JSON, and security, such as CERTIFICATE, and renewed every interval
+func NewRegistrar(client *Client, system System, interfaceName string, secure string, services []Service, interval time.Duration) *Registrar {
+	registrations := make([]ServiceRegistration, 0, len(services))
+	for _, service := range services {
+		registrations = append(registrations, ServiceRegistration{
+			ServiceDefinition: service.Definition,
+			ProviderSystem:    system,
+			ServiceURI:        service.URI,
+			Secure:            secure,
+			Metadata:          map[string]string{"http-method": service.Method},
+			Version:           1,
+			Interfaces:        []string{interfaceName},
+		})
+	}
+	return &Registrar{client: client, system: system, services: registrations, interval: interval}
+}
+
+// Register registers the system and registers its services again with a new end of validity. The Service
+// Registry refuses a service it already has, so the previous registration of each service is removed first.
+func (registrar *Registrar) Register(ctx context.Context) error {
+	err := registrar.client.RegisterSystem(ctx, registrar.system)
+	if err != nil {
+		return fmt.Errorf("failed to register the system %s: %w", registrar.system.SystemName, err)
+	}
+	registrar.registered = true
+
+	endOfValidity := time.Now().UTC().Add(2 * registrar.interval).Format(validityLayout)
+	var errs []error
+	for _, service := range registrar.services {
+		// the service may not be registered yet, which the registry answers with an error
+		_ = registrar.client.UnregisterService(ctx, service)
+
+		service.EndOfValidity = endOfValidity
+		err := registrar.client.RegisterService(ctx, service)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to register the service %s: %w", service.ServiceDefinition, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Unregister removes the services and the system from the Service Registry
+func (registrar *Registrar) Unregister(ctx context.Context) error {
+	var errs []error
+	for _, service := range registrar.services {
+		err := registrar.client.UnregisterService(ctx, service)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to unregister the service %s: %w", service.ServiceDefinition, err))
+		}
+	}
+	err := registrar.client.UnregisterSystem(ctx, registrar.system)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to unregister the system %s: %w", registrar.system.SystemName, err))
+	}
+	registrar.registered = false
+	return errors.Join(errs...)
+}
+
+// Run registers the services and renews them every interval until the context is done, then unregisters them.
+// A registration that fails is tried again at the next renewal.
+func (registrar *Registrar) Run(ctx context.Context) {
+	ticker := time.NewTicker(registrar.interval)
+	defer ticker.Stop()
+
+	for {
+		err := registrar.Register(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Arrowhead registration failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if !registrar.registered {
+				return
+			}
+			unregisterCtx, cancel := context.WithTimeout(context.Background(), unregisterTimeout)
+			defer cancel()
+			err := registrar.Unregister(unregisterCtx)
+			if err != nil {
+				log.Printf("Arrowhead unregistration failed: %v", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/fabric-network/application/b2b-app/api"
+	"github.com/gin-gonic/gin"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/offline"
+)
+
+// offlineRoutes are the routes of the transactions technicians sign on their own devices, so they need no
+// identity in the wallet of the application
+var offlineRoutes = []string{"/offline/proposals", "/offline/endorsements", "/offline/transactions", "/offline/commits"}
+
+// offlineArguments returns the arguments of the general contract function, as the handlers of the functions
+// pass them. Jobs are taken for the technician organisation of the certificate.
+func offlineArguments(params api.OfflineProposal, mspID string) ([]string, error) {
+	if params.Function != api.CreateGeneralContract && params.JobID == "" {
+		return nil, apierror.BadRequest(fmt.Errorf("%s needs a jobId", params.Function))
+	}
+
+	switch params.Function {
+	case api.CreateGeneralContract:
+		return nil, nil
+	case api.TakeJob:
+		return []string{params.JobID, mspID}, nil
+	case api.JobDoneCorrectError, api.JobDoneWrongError:
+		return []string{params.JobID}, nil
+	}
+	return nil, apierror.BadRequest(fmt.Errorf("%s cannot be signed offline", params.Function))
+}
+
+// CreateOfflineProposal builds the proposal of a general contract transaction for the certificate of the
+// technician, who signs its digest
+func (Server) CreateOfflineProposal(c *gin.Context) {
+	var params api.OfflineProposal
+	if err := c.ShouldBindJSON(&params); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+	mspID := params.MSPID
+	if mspID == "" {
+		mspID = technichianID
+	}
+	args, err := offlineArguments(params, mspID)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	id, err := offline.NewIdentity(mspID, []byte(params.Certificate))
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	chaincode := appConfig.Chaincode(gcChaincode)
+	unsigned, err := offline.Propose(fabricGateway, id, chaincode.Channel, chaincode.Name, string(params.Function), args...)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, unsigned)
+}
+
+// EndorseOfflineProposal has the signed proposal endorsed and returns the transaction to sign
+func (Server) EndorseOfflineProposal(c *gin.Context) {
+	var signed offline.Signed
+	if err := c.ShouldBindJSON(&signed); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+	unsigned, err := offline.Endorse(c.Request.Context(), fabricGateway, signed)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, unsigned)
+}
+
+// SubmitOfflineTransaction submits the signed transaction and returns the commit status request to sign
+func (Server) SubmitOfflineTransaction(c *gin.Context) {
+	var signed offline.Signed
+	if err := c.ShouldBindJSON(&signed); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+	unsigned, err := offline.Submit(c.Request.Context(), fabricGateway, signed)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, unsigned)
+}
+
+// GetOfflineCommitStatus waits for the commit of the transaction of the signed commit status request
+func (Server) GetOfflineCommitStatus(c *gin.Context) {
+	var signed offline.Signed
+	if err := c.ShouldBindJSON(&signed); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+	committed, err := offline.Status(c.Request.Context(), fabricGateway, signed)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, committed)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/fabric-network/application/b2b-app/arrowhead"
+)
+
+const (
+	arrowheadcertsPath  = "./certs"
+	arrowheadKey        = arrowheadcertsPath + "/technician-key.pem"
+	arrowheadCert       = arrowheadcertsPath + "/technician-cert.pem"
+	arrowheadTruststore = arrowheadcertsPath + "/truststore.pem"
+
+	// defaultRegistrationInterval is how often the services are registered again with the Service Registry
+	defaultRegistrationInterval = time.Hour
+)
+
+// arrowheadServices are the endpoints the application offers in the Arrowhead local cloud
+var arrowheadServices = []arrowhead.Service{
+	{Definition: "take-job", URI: "/job/take", Method: http.MethodPost},
+	{Definition: "job-status", URI: "/job/{id}", Method: http.MethodGet},
+}
+
+// newArrowheadRegistrar creates the registrar of the services of the application from SERVICEREGISTRYADDRESS and
+// the other Arrowhead variables, or returns nil when no Service Registry is configured. The system port is the
+// port the application listens on unless SYSTEMPORT is set.
+func newArrowheadRegistrar() (*arrowhead.Registrar, error) {
+	registryAddress := os.Getenv("SERVICEREGISTRYADDRESS")
+	if registryAddress == "" {
+		return nil, nil
+	}
+	registryPort := getenv("SERVICEREGISTRYPORT", "8443")
+
+	system := arrowhead.System{
+		SystemName:         getenv("SYSTEMNAME", "technician"),
+		Address:            os.Getenv("SYSTEMADDRESS"),
+		AuthenticationInfo: os.Getenv("SYSTEMAUTHENTICATIONINFO"),
+	}
+	if system.Address == "" {
+		return nil, fmt.Errorf("SYSTEMADDRESS is required to register with the Arrowhead Service Registry")
+	}
+
+	_, listenPort, err := net.SplitHostPort(appConfig.Server.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid listen address %q: %w", appConfig.Server.Address, err)
+	}
+	system.Port, err = strconv.Atoi(getenv("SYSTEMPORT", listenPort))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SYSTEMPORT: %w", err)
+	}
+
+	interval := defaultRegistrationInterval
+	if value := os.Getenv("ARROWHEAD_RENEW_INTERVAL"); value != "" {
+		interval, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ARROWHEAD_RENEW_INTERVAL: %w", err)
+		}
+	}
+
+	httpClient, err := arrowhead.NewTLSClient(arrowheadCert, arrowheadKey, arrowheadTruststore)
+	if err != nil {
+		return nil, err
+	}
+	client := arrowhead.NewClient("https://"+net.JoinHostPort(registryAddress, registryPort), "", httpClient)
+
+	interfaceName := getenv("SERVICEINTERFACE", "HTTP-SECURE-JSON")
+	secure := getenv("SERVICESECURE", "CERTIFICATE")
+	return arrowhead.NewRegistrar(client, system, interfaceName, secure, arrowheadServices, interval), nil
+}
+
+func getenv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
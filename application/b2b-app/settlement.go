@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fabric-network/application/b2b-app/api"
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/auth"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	// defaultTokenIssuerURL is the REST API of the issuer node of the token-sdk sample
+	defaultTokenIssuerURL = "http://localhost:9100/api/v1"
+)
+
+// tokenAmount and tokenCounterparty follow the TransferRequest schema of the token-sdk REST API
+type tokenAmount struct {
+	Code  string `json:"code"`
+	Value int64  `json:"value"`
+}
+
+type tokenCounterparty struct {
+	Node    string `json:"node"`
+	Account string `json:"account"`
+}
+
+type tokenIssueRequest struct {
+	Amount       tokenAmount       `json:"amount"`
+	Counterparty tokenCounterparty `json:"counterparty"`
+	Message      string            `json:"message"`
+}
+
+type tokenResponse struct {
+	Message string `json:"message"`
+	Payload string `json:"payload"`
+}
+
+// tokenIssuer pays out settlements by issuing tokens to the payout wallet of the technician
+// through the TokenService of the token-sdk issuer node
+type tokenIssuer struct {
+	baseURL    string
+	code       string
+	httpClient *http.Client
+}
+
+func newTokenIssuer() *tokenIssuer {
+	return &tokenIssuer{
+		baseURL:    appConfig.Settlement.IssuerURL,
+		code:       appConfig.Settlement.TokenCode,
+		httpClient: &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// issue issues the amount in cents to the wallet and returns the ID of the token transaction.
+// The token code is the currency of the amount unless settlement.tokenCode is set.
+func (issuer *tokenIssuer) issue(amount decimal.Money, wallet api.PayoutWallet, message string) (string, error) {
+	code := issuer.code
+	if code == "" {
+		code = amount.Currency
+	}
+
+	value, err := amount.Amount.Units()
+	if err != nil {
+		return "", err
+	}
+	request := tokenIssueRequest{
+		Amount:       tokenAmount{Code: code, Value: value},
+		Counterparty: tokenCounterparty{Node: wallet.Node, Account: wallet.Account},
+		Message:      message,
+	}
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := issuer.httpClient.Post(issuer.baseURL+"/issuer/issue", "application/json", bytes.NewReader(requestJSON))
+	if err != nil {
+		return "", fmt.Errorf("failed to reach the token issuer: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the response of the token issuer: %w", err)
+	}
+
+	var result tokenResponse
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return "", fmt.Errorf("unexpected response from the token issuer (%s): %s", response.Status, body)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("the token issuer refused the payout (%s): %s %s", response.Status, result.Message, result.Payload)
+	}
+	if result.Payload == "" {
+		return "", fmt.Errorf("the token issuer did not return a transaction ID")
+	}
+
+	return result.Payload, nil
+}
+
+// settleClosedPeriods pays out every closed settlement. A settlement is marked pending in the general contract
+// chaincode before the tokens are issued and the token transaction ID is recorded afterwards, so a settlement
+// is never paid twice. The identity of the application must be enrolled with the service owner role.
+func settleClosedPeriods(ctx context.Context, contract *client.Contract, issuer *tokenIssuer) ([]api.SettlementResult, error) {
+	fmt.Printf("\n--> Evaluate Transaction: GetClosedSettlements, function returns the settlements to pay out\n")
+	closedJSON, err := contract.EvaluateTransaction("GetClosedSettlements")
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
+	}
+
+	var closed []api.Settlement
+	err = json.Unmarshal(closedJSON, &closed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+
+	results := []api.SettlementResult{}
+	for _, settlement := range closed {
+		result := api.SettlementResult{SettlementID: settlement.ID}
+
+		tokenTxID, err := settle(ctx, contract, issuer, settlement)
+		if err != nil {
+			log.Printf("settlement %s failed: %v", settlement.ID, err)
+			result.Error = err.Error()
+		}
+		result.TokenTxID = tokenTxID
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// settle pays out the settlement. It is started and recorded through the tracker, so the transactions are submitted
+// again when they conflict with the other transactions of the general contract, and once the tokens have been
+// issued the settlement is recorded even when ctx is done.
+func settle(ctx context.Context, contract *client.Contract, issuer *tokenIssuer, settlement api.Settlement) (string, error) {
+	ctx = transaction.Synchronous(ctx)
+	pendingJSON, err := transactions.Submit(ctx, contract, "StartSettlement", settlement.TechnicianID, settlement.Period)
+	if err != nil {
+		return "", fmt.Errorf("failed to start settlement: %w", err)
+	}
+
+	var pending api.Settlement
+	err = json.Unmarshal(pendingJSON, &pending)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+
+	tokenTxID, err := issuer.issue(pending.Amount, *pending.PayoutWallet, "settlement "+pending.ID)
+	if err != nil {
+		return "", fmt.Errorf("settlement %s is pending but was not paid out: %w", pending.ID, err)
+	}
+
+	_, err = transactions.Submit(context.WithoutCancel(ctx), contract, "RecordSettlement", pending.TechnicianID, pending.Period, tokenTxID)
+	if err != nil {
+		return tokenTxID, fmt.Errorf("settlement %s was paid out in token transaction %s but could not be recorded: %w", pending.ID, tokenTxID, err)
+	}
+
+	return tokenTxID, nil
+}
+
+// runSettlementService pays out closed settlements every interval until the context is done
+func runSettlementService(ctx context.Context, interval time.Duration) {
+	issuer := newTokenIssuer()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		results, err := settleClosedPeriods(ctx, gcContract(fabricGateway), issuer)
+		if err != nil {
+			log.Printf("settlement run failed: %v", err)
+		} else if len(results) > 0 {
+			log.Printf("settlement run paid out %d settlements", len(results))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newGCContract returns the general contract chaincode as the identity of the user of the request
+func newGCContract(c *gin.Context) *client.Contract {
+	return gcContract(auth.GatewayFrom(c, fabricGateway))
+}
+
+// gcContract returns the general contract chaincode of the gateway
+func gcContract(gw *fabric.Gateway) *client.Contract {
+	chaincode := appConfig.Chaincode(gcChaincode)
+	return gw.Contract(chaincode.Channel, chaincode.Name)
+}
+
+// SetPayoutWallet sets the token wallet the technician organisation of the application is paid to
+func (Server) SetPayoutWallet(c *gin.Context) {
+	contract := newGCContract(c)
+
+	var wallet api.PayoutWallet
+	if err := c.BindJSON(&wallet); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+
+	fmt.Printf("\n--> Submit Transaction: SetPayoutWallet, function sets the wallet settlements are paid to\n")
+	result, err := transactions.Submit(c.Request.Context(), contract, "SetPayoutWallet", wallet.Node, wallet.Account)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+
+	var gc api.GeneralContract
+	err = json.Unmarshal(result, &gc)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to unmarshal result: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, gc)
+}
+
+// ClosePeriod closes a month of a technician, which can then be settled
+func (Server) ClosePeriod(c *gin.Context) {
+	contract := newGCContract(c)
+
+	var params api.ClosePeriodParams
+	if err := c.BindJSON(&params); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+
+	fmt.Printf("\n--> Submit Transaction: ClosePeriod, function closes a month of a technician\n")
+	result, err := transactions.Submit(c.Request.Context(), contract, "ClosePeriod", params.TechnicianID, params.Period)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+
+	var settlement api.Settlement
+	err = json.Unmarshal(result, &settlement)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to unmarshal result: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, settlement)
+}
+
+// RunSettlement pays out every closed settlement at once and reports the outcome of each
+func (Server) RunSettlement(c *gin.Context) {
+	contract := newGCContract(c)
+
+	results, err := settleClosedPeriods(c.Request.Context(), contract, newTokenIssuer())
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, results)
+}
+
+// GetSettlements returns the settlements of the technician organisation of the application
+func (Server) GetSettlements(c *gin.Context) {
+	contract := newGCContract(c)
+
+	fmt.Printf("\n--> Evaluate Transaction: GetSettlements, function returns the settlements of a technician\n")
+	result, err := contract.EvaluateTransaction("GetSettlements", technichianID)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to evaluate transaction: %w", err))
+		return
+	}
+
+	settlements := []api.Settlement{}
+	err = json.Unmarshal(result, &settlements)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to unmarshal result: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, settlements)
+}
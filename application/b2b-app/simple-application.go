@@ -9,840 +9,404 @@ package main
 import (
 	"bytes"
 	"context"
-	"crypto/x509"
 	"encoding/json"
-	"errors"
+	"flag"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
-	"path"
-	"strconv"
-	"time"
+	"os/signal"
+	"sync"
+	"syscall"
 
+	"github.com/fabric-network/application/b2b-app/api"
 	"github.com/gin-gonic/gin"
 	"github.com/hyperledger/fabric-gateway/pkg/client"
-	"github.com/hyperledger/fabric-gateway/pkg/identity"
-	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
 	"github.com/joho/godotenv"
-	"github.com/nalle631/arrowheadfunctions"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/status"
-)
-
-const (
-	mspID               = "Org1MSP"
-	cryptoPath          = "../../test-network/organizations/peerOrganizations/org1.example.com"
-	certPath            = cryptoPath + "/users/User1@org1.example.com/msp/signcerts/User1@org1.example.com-cert.pem"
-	keyPath             = cryptoPath + "/users/User1@org1.example.com/msp/keystore/"
-	tlsCertPath         = cryptoPath + "/peers/peer0.org1.example.com/tls/ca.crt"
-	tlsKeyPath          = cryptoPath + "/peers/peer0.org1.example.com/tls/server.key"
-	peerEndpoint        = "localhost:7051"
-	gatewayPeer         = "peer0.org1.example.com"
-	arrowheadcertsPath  = "./certs"
-	arrowheadKey        = arrowheadcertsPath + "/technician-key.pem"
-	arrowheadCert       = arrowheadcertsPath + "/technician-cert.pem"
-	arrowheadTruststore = arrowheadcertsPath + "/truststore.pem"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/config"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/health"
+	"github.com/nalle631/fabric-network/application/shared/metrics"
+	"github.com/nalle631/fabric-network/application/shared/server"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
 )
 
 type Contract struct {
 	Contract *client.Contract
 }
-type Job struct {
-	Type          string    `json:"Type"`
-	Status        string    `json:"Status"`
-	JobPay        int       `json:"JobPay"`
-	InspectionPay int       `json:"InspectionPay"`
-	Deadline      time.Time `json:"Deadline,omitempty"`
-	ID            string    `json:"ID"`
-	Mower         string    `json:"Mower"`
-	Address       string    `json:"Adress"`
-}
 
-type GeneralContract struct {
-	TechnicianID   string   `json:"TechnicianID"`
-	MonthlyBalance int      `json:"MonthlyBalance"`
-	Jobs           []Job    `json:"Jobs"`
-	JobAuthority   []string `json:"JobAuthority"`
-}
+// technichianID is the MSP ID of the service-provider organisation the application runs as
+var technichianID string
 
-type TakeJobParams struct {
-	JobID string `json:"workId"`
-}
-
-type JobDoneParams struct {
-	JobID string `json:"JobID"`
-}
-
-var technichianID = "Org1MSP"
+// fabricGateway is the gateway connection shared by every handler and the settlement service
+var fabricGateway *fabric.Gateway
 
 //var jobID = "9"
 
 func main() {
+	configPath := flag.String("config", "", "configuration file, "+config.DefaultPath+" if it exists")
+	profile := flag.String("profile", "", "profile of the configuration file to run as")
+	flag.Parse()
+
 	godotenv.Load()
-	serviceRegistryIP := os.Getenv("SERVICEREGISTRYADDRESS")
-	serviceRegistryPort, err := strconv.Atoi(os.Getenv("SERVICEREGISTRYPORT"))
+	var err error
+	appConfig, err = config.Load(*configPath, *profile, defaultConfig())
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
-	var rsrDTO arrowheadfunctions.System
-	rsrDTO.Address = os.Getenv("SYSTEMADDRESS")
-	rsrDTO.AuthenticationInfo = ""
-	rsrDTO.Port, err = strconv.Atoi(os.Getenv("SYSTEMPORT"))
-	if err != nil {
-		panic(err)
-	}
-	rsrDTO.SystemName = os.Getenv("SYSTEMNAME")
-	arrowheadfunctions.RegisterSystem(rsrDTO, serviceRegistryIP, serviceRegistryPort, arrowheadCert, arrowheadKey, arrowheadTruststore)
-	var service arrowheadfunctions.Service
-	service.Interfaces = []string{os.Getenv("SERVICEINTERFACE")}
-	service.Metadata.Method = os.Getenv("SERVICEMETHOD")
-	service.ProviderSystem = rsrDTO
-	service.Secure = os.Getenv("SERVICESECURE")
-	service.ServiceDefinition = os.Getenv("SERVICEDEFINITION")
-	service.ServiceUri = os.Getenv("SERVUCEURI")
-
-	arrowheadfunctions.PublishService(service, serviceRegistryIP, serviceRegistryPort, arrowheadCert, arrowheadKey, arrowheadTruststore)
-	router := CreateRouter()
-	StartRouter(router)
+	technichianID = appConfig.Identity.MSPID
 
-}
-
-// newGrpcConnection creates a gRPC connection to the Gateway server.
-func newGrpcConnection() *grpc.ClientConn {
-	certificate, err := loadCertificate(tlsCertPath)
+	registrar, err := newArrowheadRegistrar()
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
 
-	certPool := x509.NewCertPool()
-	certPool.AddCert(certificate)
-	transportCredentials := credentials.NewClientTLSFromCert(certPool, gatewayPeer)
-
-	connection, err := grpc.Dial(peerEndpoint, grpc.WithTransportCredentials(transportCredentials))
+	appMetrics = metrics.New()
+	gatewayConfig := appConfig.Gateway()
+	gatewayConfig.DialOptions = appMetrics.DialOptions()
+	fabricGateway, err = fabric.Connect(gatewayConfig)
 	if err != nil {
-		panic(fmt.Errorf("failed to create gRPC connection: %w", err))
+		log.Fatal(err)
 	}
 
-	return connection
-}
-
-// newIdentity creates a client identity for this Gateway connection using an X.509 certificate.
-func newIdentity() *identity.X509Identity {
-	certificate, err := loadCertificate(certPath)
+	authGuard, err = appConfig.Guard(fabricGateway)
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
-
-	id, err := identity.NewX509Identity(mspID, certificate)
-	if err != nil {
-		panic(err)
+	if authGuard == nil {
+		log.Printf("authentication is disabled, every request transacts as %s", appConfig.Identity.MSPID)
 	}
+	trackerOptions := appConfig.TrackerOptions()
+	trackerOptions.Observer = appMetrics
+	transactions = transaction.NewTracker(trackerOptions)
+	appMetrics.WatchGateway(fabricGateway)
+	appMetrics.WatchTracker(transactions)
+	readiness = appConfig.Readiness(fabricGateway)
 
-	return id
-}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-func loadCertificate(filename string) (*x509.Certificate, error) {
-	certificatePEM, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read certificate file: %w", err)
+	// the settlement service pays out closed periods through the token-sdk issuer every settlement.interval
+	if appConfig.Settlement.Interval > 0 {
+		go runSettlementService(ctx, appConfig.Settlement.Interval)
 	}
-	return identity.CertificateFromPEM(certificatePEM)
-}
 
-// newSign creates a function that generates a digital signature from a message digest using a private key.
-func newSign() identity.Sign {
-	files, err := os.ReadDir(keyPath)
-	if err != nil {
-		panic(fmt.Errorf("failed to read private key directory: %w", err))
+	// the services are registered with the Arrowhead Service Registry while the application runs
+	var registration sync.WaitGroup
+	if registrar != nil {
+		registration.Add(1)
+		go func() {
+			defer registration.Done()
+			registrar.Run(ctx)
+		}()
 	}
-	privateKeyPEM, err := os.ReadFile(path.Join(keyPath, files[0].Name()))
 
-	if err != nil {
-		panic(fmt.Errorf("failed to read private key file: %w", err))
-	}
+	router := CreateRouter()
+	StartRouter(ctx, router)
+	registration.Wait()
 
-	privateKey, err := identity.PrivateKeyFromPEM(privateKeyPEM)
-	if err != nil {
-		panic(err)
-	}
+}
 
-	sign, err := identity.NewPrivateKeySign(privateKey)
+// StartRouter serves the router until the context is done, then lets the requests in flight finish
+// before the commits of asynchronous transactions stop being followed and the shared gateway connection is closed
+func StartRouter(ctx context.Context, r *gin.Engine) {
+	err := server.Run(ctx, appConfig.Server.Address, r, server.DefaultShutdownTimeout, transactions.Close, func() {
+		if err := fabricGateway.Close(); err != nil {
+			log.Printf("failed to close the gateway: %v", err)
+		}
+	})
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
-
-	return sign
 }
 
-func StartRouter(r *gin.Engine) {
-	r.Run(":5000")
-	// srv := &http.Server{
-	// 	Addr:    ":8080", // Set port number
-	// 	Handler: r,
-	// }
-
-	// err := srv.ListenAndServeTLS(certPath, keyPath)
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
-	// r.RunTLS(":8080", tlsCertPath, tlsKeyPath)
-}
+// Server implements the operations of openapi.yaml. The routes generated from the spec bind the path
+// parameters and check the roles of the operation before they call it.
+type Server struct{}
+
+var _ api.ServerInterface = Server{}
 
+// CreateRouter registers the operations of the spec behind authentication. The spec itself is served at
+// /openapi.json, the liveness and readiness probes at /healthz and /readyz, the Prometheus metrics at /metrics
+// and how often transactions were retried on read conflicts at /metrics/retries, registered before the
+// authentication so they can be fetched without a token. Requests with Prefer: respond-async are answered
+// with 202 once their transaction is ordered, see GetTransaction.
 func CreateRouter() *gin.Engine {
 	r := gin.Default()
-
-	r.GET("/gc", ReadGCHandler)
-	r.GET("/gc/jobs", GetAllJobsHandler)
-	r.POST("/gc/create", CreateHandler)
-	r.POST("/job/take", TakeJobHandler)
-	r.POST("/job/done_correct", FinishJobCorrectErrorHandler)
-	r.POST("/job/done_wrong", FinishJobWrongErrorHandler)
+	r.Use(appMetrics.Middleware())
+	r.GET("/openapi.json", serveSpec)
+	r.GET("/healthz", health.Live)
+	r.GET("/readyz", readiness.Ready)
+	r.GET("/metrics", appMetrics.Handler())
+	r.GET("/metrics/retries", transactions.RespondRetryCounts)
+
+	authGuard.WithoutIdentity(offlineRoutes...)
+	r.Use(authGuard.Authenticate(), transactions.Middleware())
+	api.RegisterHandlersWithOptions(r, Server{}, api.GinServerOptions{
+		Middlewares:  []api.MiddlewareFunc{authGuard.RequireScopes(api.BearerAuthScopes)},
+		ErrorHandler: apierror.ParameterError,
+	})
 	return r
 }
 
-func Create(contract *client.Contract) {
-	fmt.Printf("\n--> Submit Transaction: create, function creates a key value pair on the ledger \n")
-
-	_, err := contract.SubmitTransaction("CreateGeneralContract")
+// serveSpec responds with the OpenAPI spec the routes are generated from
+func serveSpec(c *gin.Context) {
+	spec, err := api.GetSwagger()
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
+		apierror.Respond(c, err)
+		return
 	}
-	fmt.Printf("*** Transaction committed successfully\n")
+	c.JSON(http.StatusOK, spec)
 }
 
-func CreateHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
-	if err != nil {
-		panic(err)
-	}
+// GetTransaction reports whether a transaction that was submitted asynchronously has committed, in which
+// block and with which validation code. Only the user who submitted the transaction can see it.
+func (Server) GetTransaction(c *gin.Context, transactionID string) {
+	transactions.RespondStatus(c, transactionID)
+}
 
-	defer gw.Close()
+func Create(ctx context.Context, contract *client.Contract) error {
+	fmt.Printf("\n--> Submit Transaction: create, function creates a key value pair on the ledger \n")
 
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "gc"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	_, err := transactions.Submit(ctx, contract, "CreateGeneralContract")
+	if err != nil {
+		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
+	fmt.Printf("*** Transaction committed successfully\n")
+	return nil
+}
 
-	// chaincodeName2 := "bumpy"
+func (Server) CreateGeneralContract(c *gin.Context) {
+	contract := newGCContract(c)
 
-	channelName := "mychannel"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	err := Create(c.Request.Context(), contract)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
 	}
-
-	network := gw.GetNetwork(channelName)
-
-	contract := network.GetContract(chaincodeName)
-	Create(contract)
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "General contract created"})
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "General contract created"})
 }
 
-func createJob(contract *client.Contract, jobID string) {
-	fmt.Println("\n--> Submit Transaction: UpdateAsset asset70, asset70 does not exist and should return an error")
-
-	_, err := contract.SubmitTransaction("Create", technichianID, jobID, "5", "Tomoko", "300")
-
-	switch err := err.(type) {
-	case *client.EndorseError:
-		fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-	case *client.SubmitError:
-		fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-	case *client.CommitStatusError:
-		if errors.Is(err, context.DeadlineExceeded) {
-			fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-		} else {
-			fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		}
-	case *client.CommitError:
-		fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-	default:
-		panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-	}
-
-	// Any error that originates from a peer or orderer node external to the gateway will have its details
-	// embedded within the gRPC status error. The following code shows how to extract that.
-	statusErr := status.Convert(err)
+func createJob(ctx context.Context, contract *client.Contract, jobID string) error {
+	fmt.Println("\n--> Submit Transaction: Create, function creates a job on the ledger")
 
-	details := statusErr.Details()
-	if len(details) > 0 {
-		fmt.Println("Error Details:")
-
-		for _, detail := range details {
-			switch detail := detail.(type) {
-			case *gateway.ErrorDetail:
-				fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-			}
-		}
+	_, err := transactions.Submit(ctx, contract, "Create", technichianID, jobID, "5", "Tomoko", "300")
+	if err != nil {
+		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
+	return nil
 }
 
 func CreateJobHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	id1 := id.Credentials()
-	fmt.Println("id1: ", string(id1[:]))
-	fmt.Println("mspID: ", id.MspID())
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
-	if err != nil {
-		panic(err)
-	}
+	contract := newGCContract(c)
 
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "gc"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
-	}
-
-	// chaincodeName2 := "bumpy"
-
-	channelName := "mychannel"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	err := createJob(c.Request.Context(), contract, c.Param("jobID"))
+	if err != nil {
+		apierror.Respond(c, err)
+		return
 	}
-
-	network := gw.GetNetwork(channelName)
-
-	contract := network.GetContract(chaincodeName)
-	createJob(contract, c.Param("jobID"))
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "job created"})
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "job created"})
 }
 
 // Submit a transaction to query ledger state.
-func takeJob(contract *client.Contract, jobID string) {
-	fmt.Println("\n--> Submit Transaction: TakeJob, function updates a key value pair on the ledger \n")
+func takeJob(ctx context.Context, contract *client.Contract, jobID string, idempotencyKey string) (*transaction.Record, error) {
+	fmt.Printf("\n--> Submit Transaction: TakeJob, function updates a key value pair on the ledger\n")
 
 	fmt.Println("jobID: ", jobID)
 
 	//Remember to remove jobtype when integrated with jespers system
-	submitResult, err := contract.SubmitTransaction("TakeJob", jobID, technichianID)
+	submitResult, record, err := transactions.SubmitIdempotent(ctx, contract, "TakeJob", idempotencyKey, jobID, technichianID)
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
+		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	fmt.Println("Result:", submitResult)
+	fmt.Println("Result:", string(submitResult))
+	return record, nil
 }
 
-func TakeJobHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	id1 := id.Credentials()
-	fmt.Println("id1: ", string(id1[:]))
-	fmt.Println("mspID: ", id.MspID())
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "gc"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
-	}
-
-	// chaincodeName2 := "bumpy"
-
-	channelName := "mychannel"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
-	}
-
-	network := gw.GetNetwork(channelName)
+func (Server) TakeJob(c *gin.Context) {
+	contract := newGCContract(c)
 
-	contract := network.GetContract(chaincodeName)
-
-	var params TakeJobParams
+	var params api.TakeJobParams
 	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	takeJob(contract, params.JobID)
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "Job added to your general contract."})
-}
-
-func finishJobCorrectError(contract *client.Contract, jobID string) {
-	fmt.Println("\n--> Submit Transaction: Finish job correct error, function updates a key value pair on the ledger \n")
-
-	submitResult, err := contract.SubmitTransaction("JobDoneCorrectError", jobID)
+	idempotencyKey, err := transaction.IdempotencyKey(c)
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
+		apierror.Respond(c, err)
+		return
 	}
-
-	fmt.Println("Result:", submitResult)
-}
-
-func FinishJobCorrectErrorHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	id1 := id.Credentials()
-	fmt.Println("id1: ", string(id1[:]))
-	fmt.Println("mspID: ", id.MspID())
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
+	record, err := takeJob(c.Request.Context(), contract, params.JobID, idempotencyKey)
 	if err != nil {
-		panic(err)
+		apierror.Respond(c, err)
+		return
 	}
-
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "gc"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	if record != nil {
+		record.SetHeaders(c)
 	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "Job added to your general contract."})
+}
 
-	// chaincodeName2 := "bumpy"
+func finishJobCorrectError(ctx context.Context, contract *client.Contract, jobID string, idempotencyKey string) (*transaction.Record, error) {
+	fmt.Printf("\n--> Submit Transaction: Finish job correct error, function updates a key value pair on the ledger\n")
 
-	channelName := "mychannel"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	submitResult, record, err := transactions.SubmitIdempotent(ctx, contract, "JobDoneCorrectError", idempotencyKey, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	network := gw.GetNetwork(channelName)
+	fmt.Println("Result:", string(submitResult))
+	return record, nil
+}
 
-	contract := network.GetContract(chaincodeName)
+func (Server) FinishJobCorrectError(c *gin.Context) {
+	contract := newGCContract(c)
 
-	var params JobDoneParams
+	var params api.JobDoneParams
 	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	finishJobCorrectError(contract, params.JobID)
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "finished job with correct error"})
-}
-
-func finishJobWrongError(contract *client.Contract, jobID string) {
-	fmt.Println("\n--> Submit Transaction: FinishJob wrong error, function updates a key value pair on the ledger \n")
-
-	submitResult, err := contract.SubmitTransaction("JobDoneWrongError", jobID)
+	idempotencyKey, err := transaction.IdempotencyKey(c)
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
+		apierror.Respond(c, err)
+		return
 	}
-
-	fmt.Println("Result:", submitResult)
-}
-
-func FinishJobWrongErrorHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	id1 := id.Credentials()
-	fmt.Println("id1: ", string(id1[:]))
-	fmt.Println("mspID: ", id.MspID())
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
+	record, err := finishJobCorrectError(c.Request.Context(), contract, params.JobID, idempotencyKey)
 	if err != nil {
-		panic(err)
+		apierror.Respond(c, err)
+		return
 	}
-
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "gc"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	if record != nil {
+		record.SetHeaders(c)
 	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "finished job with correct error"})
+}
 
-	// chaincodeName2 := "bumpy"
+func finishJobWrongError(ctx context.Context, contract *client.Contract, jobID string, idempotencyKey string) (*transaction.Record, error) {
+	fmt.Printf("\n--> Submit Transaction: FinishJob wrong error, function updates a key value pair on the ledger\n")
 
-	channelName := "mychannel"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	submitResult, record, err := transactions.SubmitIdempotent(ctx, contract, "JobDoneWrongError", idempotencyKey, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	network := gw.GetNetwork(channelName)
+	fmt.Println("Result:", string(submitResult))
+	return record, nil
+}
+
+func (Server) FinishJobWrongError(c *gin.Context) {
+	contract := newGCContract(c)
 
-	contract := network.GetContract(chaincodeName)
-	var params JobDoneParams
+	var params api.JobDoneParams
 	if err := c.ShouldBindJSON(&params); err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	finishJobWrongError(contract, params.JobID)
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "finished job with wrong error"})
+	idempotencyKey, err := transaction.IdempotencyKey(c)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	record, err := finishJobWrongError(c.Request.Context(), contract, params.JobID, idempotencyKey)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	if record != nil {
+		record.SetHeaders(c)
+	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "finished job with wrong error"})
 }
 
 // Evaluate a transaction by key to query ledger state.
-func ReadGC(contract *client.Contract) *GeneralContract {
+func ReadGC(contract *client.Contract) (*api.GeneralContract, error) {
 	fmt.Printf("\n--> Evaluate Transaction: Read, function returns key value pair\n")
 
 	evaluateResult, err := contract.EvaluateTransaction("ReadGeneralContract", technichianID)
 	if err != nil {
-		panic(fmt.Errorf("failed to evaluate transaction: %w", err))
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
 	}
-	var gc GeneralContract
+	var gc api.GeneralContract
 	err = json.Unmarshal(evaluateResult, &gc)
 	if err != nil {
-		panic(fmt.Errorf("failed to unmarshal result: %w", err))
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
 
 	fmt.Println("Result: ", gc)
 
-	return &gc
+	return &gc, nil
 }
 
-func ReadGCHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	id1 := id.Credentials()
-	fmt.Println("id1: ", string(id1[:]))
-	fmt.Println("mspID: ", id.MspID())
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
+func (Server) ReadGeneralContract(c *gin.Context) {
+	contract := newGCContract(c)
+
+	readResult, err := ReadGC(contract)
 	if err != nil {
-		panic(err)
+		apierror.Respond(c, err)
+		return
 	}
+	c.IndentedJSON(http.StatusOK, readResult)
+}
 
-	defer gw.Close()
+func readJob(contract *client.Contract, jobID string) (*api.Job, error) {
+	fmt.Printf("\n--> Evaluate Transaction: ReadJob, function returns a job of the general contract\n")
 
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "gc"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	evaluateResult, err := contract.EvaluateTransaction("ReadJob", jobID, technichianID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
 	}
 
-	// chaincodeName2 := "bumpy"
-
-	channelName := "mychannel"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	fmt.Println("Result: ", string(evaluateResult[:]))
+	var job api.Job
+	err = json.Unmarshal(evaluateResult, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
-
-	network := gw.GetNetwork(channelName)
-
-	contract := network.GetContract(chaincodeName)
-	readResult := ReadGC(contract)
-	c.IndentedJSON(http.StatusOK, readResult)
+	return &job, nil
 }
 
-func readJob(contract *client.Contract, jobID string) {
-	fmt.Printf("\n--> Evaluate Transaction: Read, function returns key value pair\n")
+// GetJob returns a job of the general contract, which Arrowhead consumers use as the job-status service
+func (Server) GetJob(c *gin.Context, jobID string) {
+	contract := newGCContract(c)
 
-	evaluateResult, err := contract.EvaluateTransaction("ReadJob", jobID)
+	job, err := readJob(contract, jobID)
 	if err != nil {
-		panic(fmt.Errorf("failed to evaluate transaction: %w", err))
+		apierror.Respond(c, err)
+		return
 	}
-
-	fmt.Println("Result: ", string(evaluateResult[:]))
+	c.IndentedJSON(http.StatusOK, job)
 }
 
-func getAllJobs(contract *client.Contract) ([]byte, error) {
+func getAllJobs(contract *client.Contract) ([]api.Job, error) {
 	fmt.Printf("\n--> Evaluate Transaction: Read, function returns key value pair\n")
 
 	evaluateResult, err := contract.EvaluateTransaction("GetAllJobs")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
 	}
 
 	fmt.Println("Result: ", string(evaluateResult[:]))
 
-	return evaluateResult, nil
-}
-
-func GetAllJobsHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	id1 := id.Credentials()
-	fmt.Println("id1: ", string(id1[:]))
-	fmt.Println("mspID: ", id.MspID())
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
+	jobs := []api.Job{}
+	err = json.Unmarshal(evaluateResult, &jobs)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
-
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "gc"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
-	}
-
-	// chaincodeName2 := "bumpy"
-
-	channelName := "mychannel"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
-	}
-
-	network := gw.GetNetwork(channelName)
-
-	contract := network.GetContract(chaincodeName)
-	result, err := getAllJobs(contract)
-	if err != nil {
-		c.IndentedJSON(400, "Couln't get all jobs")
-	}
-	c.IndentedJSON(http.StatusOK, result)
+	return jobs, nil
 }
 
-// Submit transaction, passing in the wrong number of arguments ,expected to throw an error containing details of any error responses from the smart contract.
-func exampleErrorHandling(contract *client.Contract) {
-	fmt.Println("\n--> Submit Transaction: UpdateAsset asset70, asset70 does not exist and should return an error")
-
-	_, err := contract.SubmitTransaction("UpdateAsset", "asset70", "blue", "5", "Tomoko", "300")
-	if err == nil {
-		panic("******** FAILED to return an error")
-	}
-
-	fmt.Println("*** Successfully caught the error:")
-
-	switch err := err.(type) {
-	case *client.EndorseError:
-		fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-	case *client.SubmitError:
-		fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-	case *client.CommitStatusError:
-		if errors.Is(err, context.DeadlineExceeded) {
-			fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-		} else {
-			fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		}
-	case *client.CommitError:
-		fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-	default:
-		panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-	}
+func (Server) GetAllJobs(c *gin.Context) {
+	contract := newGCContract(c)
 
-	// Any error that originates from a peer or orderer node external to the gateway will have its details
-	// embedded within the gRPC status error. The following code shows how to extract that.
-	statusErr := status.Convert(err)
-
-	details := statusErr.Details()
-	if len(details) > 0 {
-		fmt.Println("Error Details:")
-
-		for _, detail := range details {
-			switch detail := detail.(type) {
-			case *gateway.ErrorDetail:
-				fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-			}
-		}
+	jobs, err := getAllJobs(contract)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
 	}
+	c.IndentedJSON(http.StatusOK, jobs)
 }
 
 // Format JSON data
@@ -0,0 +1,1535 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.13.4 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/nalle631/fabric-network/application/shared/bulk"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ErrorCode.
+const (
+	BadGateway     ErrorCode = "bad_gateway"
+	Conflict       ErrorCode = "conflict"
+	Forbidden      ErrorCode = "forbidden"
+	Internal       ErrorCode = "internal"
+	InvalidRequest ErrorCode = "invalid_request"
+	NotFound       ErrorCode = "not_found"
+	Timeout        ErrorCode = "timeout"
+	Unauthorized   ErrorCode = "unauthorized"
+)
+
+// AssignPropertyParams defines model for AssignPropertyParams.
+type AssignPropertyParams struct {
+	PropertyID string `json:"PropertyID"`
+}
+
+// CreateSLAParams defines model for CreateSLAParams.
+type CreateSLAParams struct {
+	MaxGrassLength    Decimal    `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    Decimal    `json:"MinGrassLength,omitempty"`
+	Parameters        Parameters `json:"Parameters,omitempty"`
+	PropertyID        string     `json:"PropertyID,omitempty"`
+	ServiceLevel      string     `json:"ServiceLevel,omitempty"`
+	ServiceType       string     `json:"ServiceType,omitempty"`
+	TargetGrassLength Decimal    `json:"TargetGrassLength,omitempty"`
+}
+
+// Customer defines model for Customer.
+type Customer struct {
+	ID         string     `json:"ID"`
+	Properties []Property `json:"Properties"`
+	SLAs       []SLA      `json:"SLAs"`
+}
+
+// CustomerParams defines model for CustomerParams.
+type CustomerParams struct {
+	CustomerID string `json:"CustomerID"`
+}
+
+// CustomerProperties The SLAs of a customer grouped by property, SLAs without a property are listed as unassigned
+type CustomerProperties struct {
+	Properties     []PropertySLAs `json:"Properties"`
+	UnassignedSLAs []SLA          `json:"UnassignedSLAs"`
+}
+
+// Decimal A decimal number
+type Decimal = decimal.Decimal
+
+// Error defines model for Error.
+type Error struct {
+	Code    ErrorCode     `json:"code"`
+	Details []ErrorDetail `json:"details,omitempty"`
+
+	// Error What went wrong, the message of the chaincode when it rejected the transaction
+	Error         string `json:"error"`
+	TransactionId string `json:"transactionId,omitempty"`
+}
+
+// ErrorCode defines model for Error.Code.
+type ErrorCode string
+
+// ErrorDetail The message of a peer or orderer that rejected the transaction
+type ErrorDetail struct {
+	Address string `json:"address,omitempty"`
+	Message string `json:"message"`
+	MspId   string `json:"mspId,omitempty"`
+}
+
+// GrassLengthInterval defines model for GrassLengthInterval.
+type GrassLengthInterval struct {
+	// MaxGrassLength A decimal number
+	MaxGrassLength Decimal `json:"MaxGrassLength"`
+
+	// MinGrassLength A decimal number
+	MinGrassLength Decimal `json:"MinGrassLength"`
+}
+
+// ImportReport The progress of an import, with the result of every row in the order of the rows
+type ImportReport = bulk.Report
+
+// ImportResult What became of a row. Rows are pending until they are submitted and stay pending when the import stops
+// before them, valid rows were priced by a dry run, invalid rows could not be read or priced and were not
+// submitted, failed rows were submitted and failed, and replayed rows had been created before.
+type ImportResult = bulk.Result
+
+// Invoice defines model for Invoice.
+type Invoice struct {
+	CustomerID string        `json:"CustomerID"`
+	ID         string        `json:"ID"`
+	IssuedAt   time.Time     `json:"IssuedAt"`
+	Lines      []InvoiceLine `json:"Lines"`
+
+	// Payment How an invoice was paid with the ERC-20 token
+	Payment *Payment `json:"Payment,omitempty"`
+	Period  string   `json:"Period"`
+	Status  string   `json:"Status"`
+
+	// Total An amount in a currency
+	Total Money `json:"Total"`
+}
+
+// InvoiceLine defines model for InvoiceLine.
+type InvoiceLine struct {
+	// Amount An amount in a currency
+	Amount       Money    `json:"Amount"`
+	BilledDays   int      `json:"BilledDays"`
+	PausedDays   int      `json:"PausedDays"`
+	PropertyID   string   `json:"PropertyID"`
+	SLAID        string   `json:"SLAID"`
+	Seasons      []string `json:"Seasons"`
+	ServiceLevel string   `json:"ServiceLevel"`
+	ServiceType  string   `json:"ServiceType"`
+}
+
+// InvoiceParams defines model for InvoiceParams.
+type InvoiceParams struct {
+	// Period The month to invoice as YYYY-MM
+	Period string `json:"Period"`
+}
+
+// Message defines model for Message.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// Money An amount in a currency
+type Money = decimal.Money
+
+// MonthlyPrice defines model for MonthlyPrice.
+type MonthlyPrice struct {
+	BilledDays int `json:"BilledDays"`
+	Month      int `json:"Month"`
+	PausedDays int `json:"PausedDays"`
+
+	// Price An amount in a currency
+	Price   Money    `json:"Price"`
+	Seasons []string `json:"Seasons"`
+}
+
+// ParameterSchema defines model for ParameterSchema.
+type ParameterSchema struct {
+	// Max A decimal number
+	Max Decimal `json:"Max"`
+
+	// Min A decimal number
+	Min      Decimal `json:"Min"`
+	Name     string  `json:"Name"`
+	Required bool    `json:"Required"`
+	Unit     string  `json:"Unit"`
+}
+
+// Parameters The parameters of a service by name, e.g. TargetGrassLength
+type Parameters map[string]Decimal
+
+// Payment How an invoice was paid with the ERC-20 token
+type Payment struct {
+	// Amount An amount in a currency
+	Amount        Money     `json:"Amount"`
+	From          string    `json:"From"`
+	PaidAt        time.Time `json:"PaidAt"`
+	To            string    `json:"To"`
+	TokenContract string    `json:"TokenContract"`
+	Tokens        int64     `json:"Tokens"`
+	TxID          string    `json:"TxID"`
+}
+
+// PaymentAccount defines model for PaymentAccount.
+type PaymentAccount struct {
+	PaymentAccount string `json:"PaymentAccount"`
+}
+
+// PriceComponent A named part of the monthly cost of an SLA, such as the base cost of its service level
+type PriceComponent struct {
+	// Amount A decimal number
+	Amount Decimal `json:"Amount"`
+	Name   string  `json:"Name"`
+}
+
+// PriceSchedule The price of an SLA for every month of a year
+type PriceSchedule struct {
+	// AnnualTotal An amount in a currency
+	AnnualTotal Money `json:"AnnualTotal"`
+
+	// BasePrice An amount in a currency
+	BasePrice Money          `json:"BasePrice"`
+	Months    []MonthlyPrice `json:"Months"`
+	Year      int            `json:"Year"`
+}
+
+// Property defines model for Property.
+type Property struct {
+	Address string `json:"Address"`
+	ID      string `json:"ID"`
+
+	// LotSize A decimal number
+	LotSize Decimal `json:"LotSize"`
+	Mower   string  `json:"Mower"`
+}
+
+// PropertyParams defines model for PropertyParams.
+type PropertyParams struct {
+	Address string  `json:"Address,omitempty"`
+	ID      string  `json:"ID,omitempty"`
+	LotSize Decimal `json:"LotSize,omitempty"`
+	Mower   string  `json:"Mower,omitempty"`
+}
+
+// PropertySLAs A property of a customer together with the SLAs attached to it
+type PropertySLAs struct {
+	Property Property `json:"Property"`
+	SLAs     []SLA    `json:"SLAs"`
+}
+
+// Quote The price of a single SLA configuration, configurations that cannot be priced have an error
+type Quote struct {
+	// AnnualTotal An amount in a currency
+	AnnualTotal Money            `json:"AnnualTotal"`
+	Breakdown   []PriceComponent `json:"Breakdown"`
+	Error       string           `json:"Error,omitempty"`
+
+	// MonthlyCost An amount in a currency
+	MonthlyCost Money `json:"MonthlyCost"`
+
+	// Parameters The parameters of a service by name, e.g. TargetGrassLength
+	Parameters   Parameters `json:"Parameters"`
+	Reference    string     `json:"Reference"`
+	ServiceLevel string     `json:"ServiceLevel"`
+	ServiceType  string     `json:"ServiceType"`
+}
+
+// QuoteConfiguration defines model for QuoteConfiguration.
+type QuoteConfiguration struct {
+	MaxGrassLength    Decimal    `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    Decimal    `json:"MinGrassLength,omitempty"`
+	Parameters        Parameters `json:"Parameters,omitempty"`
+	Reference         string     `json:"Reference,omitempty"`
+	Seasons           []Season   `json:"Seasons,omitempty"`
+	ServiceLevel      string     `json:"ServiceLevel,omitempty"`
+	ServiceType       string     `json:"ServiceType,omitempty"`
+	TargetGrassLength Decimal    `json:"TargetGrassLength,omitempty"`
+}
+
+// QuoteMatrix Quotes a mowing SLA for every service level against every grass length interval and target grass length
+type QuoteMatrix struct {
+	Intervals          []GrassLengthInterval `json:"Intervals,omitempty"`
+	Seasons            []Season              `json:"Seasons,omitempty"`
+	ServiceLevels      []string              `json:"ServiceLevels,omitempty"`
+	TargetGrassLengths []Decimal             `json:"TargetGrassLengths,omitempty"`
+}
+
+// QuotesParams The configurations to quote, listed one by one, as a matrix, or both
+type QuotesParams struct {
+	Configurations []QuoteConfiguration `json:"Configurations,omitempty"`
+
+	// Matrix Quotes a mowing SLA for every service level against every grass length interval and target grass length
+	Matrix *QuoteMatrix `json:"Matrix,omitempty"`
+}
+
+// ReconcileReport defines model for ReconcileReport.
+type ReconcileReport struct {
+	Added      []string `json:"Added"`
+	CustomerID string   `json:"CustomerID"`
+
+	// Foreign SLAs stored for the customer under another owner, which are not adopted
+	Foreign []string `json:"Foreign"`
+	Removed []string `json:"Removed"`
+	Updated []string `json:"Updated"`
+}
+
+// RemoveSLAParams defines model for RemoveSLAParams.
+type RemoveSLAParams struct {
+	CustomerID string `json:"CustomerID"`
+	SlaID      string `json:"slaID"`
+}
+
+// SLA defines model for SLA.
+type SLA struct {
+	// AppraisedValue An amount in a currency
+	AppraisedValue Money  `json:"AppraisedValue"`
+	CustomerID     string `json:"CustomerID"`
+	ID             string `json:"ID"`
+
+	// Parameters The parameters of a service by name, e.g. TargetGrassLength
+	Parameters   Parameters `json:"Parameters"`
+	PropertyID   string     `json:"PropertyID"`
+	Seasons      []Season   `json:"Seasons,omitempty"`
+	ServiceLevel string     `json:"ServiceLevel"`
+	ServiceType  string     `json:"ServiceType"`
+	StartDate    time.Time  `json:"StartDate"`
+}
+
+// Season A window of the year, given as MM-DD, in which an SLA is delivered with other parameters or paused
+type Season struct {
+	End        string     `json:"End"`
+	Name       string     `json:"Name"`
+	Parameters Parameters `json:"Parameters,omitempty"`
+	Paused     bool       `json:"Paused"`
+	Start      string     `json:"Start"`
+}
+
+// SeasonsParams defines model for SeasonsParams.
+type SeasonsParams struct {
+	Seasons []Season `json:"Seasons,omitempty"`
+}
+
+// ServiceSchema defines model for ServiceSchema.
+type ServiceSchema struct {
+	Parameters  []ParameterSchema `json:"Parameters"`
+	ServiceType string            `json:"ServiceType"`
+}
+
+// SlaParams An SLA to price. Mowing SLAs can be given with the grass length fields, every other service type
+// needs ServiceType and Parameters.
+type SlaParams struct {
+	MaxGrassLength    Decimal    `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    Decimal    `json:"MinGrassLength,omitempty"`
+	Parameters        Parameters `json:"Parameters,omitempty"`
+	Seasons           []Season   `json:"Seasons,omitempty"`
+	ServiceLevel      string     `json:"ServiceLevel,omitempty"`
+	ServiceType       string     `json:"ServiceType,omitempty"`
+	TargetGrassLength Decimal    `json:"TargetGrassLength,omitempty"`
+}
+
+// Transaction A transaction that was submitted asynchronously. Every such transaction was endorsed and ordered, it
+// is committed once its status is committed or invalid, and only committed transactions changed the
+// ledger. The status is unknown when its commit could not be waited for.
+type Transaction = transaction.Status
+
+// UpdateGrassLengthIntervalParams defines model for UpdateGrassLengthIntervalParams.
+type UpdateGrassLengthIntervalParams struct {
+	CustomerID string `json:"CustomerID"`
+
+	// MaxGrassLength A decimal number
+	MaxGrassLength Decimal `json:"MaxGrassLength"`
+
+	// MinGrassLength A decimal number
+	MinGrassLength Decimal `json:"MinGrassLength"`
+}
+
+// UpdateServiceLevelParams defines model for UpdateServiceLevelParams.
+type UpdateServiceLevelParams struct {
+	CustomerID   string `json:"CustomerID"`
+	ServiceLevel string `json:"ServiceLevel"`
+}
+
+// UpdateSlaParams The changes to an SLA, fields that are left out are not changed
+type UpdateSlaParams struct {
+	MaxGrassLength    Decimal    `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    Decimal    `json:"MinGrassLength,omitempty"`
+	Parameters        Parameters `json:"Parameters,omitempty"`
+	ServiceLevel      string     `json:"ServiceLevel,omitempty"`
+	TargetGrassLength Decimal    `json:"TargetGrassLength,omitempty"`
+}
+
+// UpdateTargetGrassLengthParams defines model for UpdateTargetGrassLengthParams.
+type UpdateTargetGrassLengthParams struct {
+	CustomerID string `json:"CustomerID"`
+
+	// TargetGrassLength A decimal number
+	TargetGrassLength Decimal `json:"TargetGrassLength"`
+}
+
+// CustomerId defines model for customer_id.
+type CustomerId = string
+
+// Id defines model for id.
+type Id = string
+
+// PropertyId defines model for property_id.
+type PropertyId = string
+
+// Accepted A transaction that was submitted asynchronously. Every such transaction was endorsed and ordered, it
+// is committed once its status is committed or invalid, and only committed transactions changed the
+// ledger. The status is unknown when its commit could not be waited for.
+type Accepted = Transaction
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse = Error
+
+// IDSuccess defines model for IDSuccess.
+type IDSuccess = string
+
+// InvoiceSuccess defines model for InvoiceSuccess.
+type InvoiceSuccess = Invoice
+
+// MessageSuccess defines model for MessageSuccess.
+type MessageSuccess = Message
+
+// SLASuccess defines model for SLASuccess.
+type SLASuccess = SLA
+
+// StartImportParams defines parameters for StartImport.
+type StartImportParams struct {
+	// Id The ID of the import, a new one when empty. An earlier import with the ID is resumed.
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
+
+	// DryRun Only price the rows
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+
+	// Parallel How many customers are imported at once
+	Parallel *int `form:"parallel,omitempty" json:"parallel,omitempty"`
+}
+
+// CreateCustomerJSONRequestBody defines body for CreateCustomer for application/json ContentType.
+type CreateCustomerJSONRequestBody = CustomerParams
+
+// EvaluateSLAJSONRequestBody defines body for EvaluateSLA for application/json ContentType.
+type EvaluateSLAJSONRequestBody = SlaParams
+
+// QuoteSLAsJSONRequestBody defines body for QuoteSLAs for application/json ContentType.
+type QuoteSLAsJSONRequestBody = QuotesParams
+
+// RemoveSLAJSONRequestBody defines body for RemoveSLA for application/json ContentType.
+type RemoveSLAJSONRequestBody = RemoveSLAParams
+
+// UpdateTargetGrassLengthJSONRequestBody defines body for UpdateTargetGrassLength for application/json ContentType.
+type UpdateTargetGrassLengthJSONRequestBody = UpdateTargetGrassLengthParams
+
+// UpdateGrassLengthIntervalJSONRequestBody defines body for UpdateGrassLengthInterval for application/json ContentType.
+type UpdateGrassLengthIntervalJSONRequestBody = UpdateGrassLengthIntervalParams
+
+// UpdateServiceLevelJSONRequestBody defines body for UpdateServiceLevel for application/json ContentType.
+type UpdateServiceLevelJSONRequestBody = UpdateServiceLevelParams
+
+// CreateInvoiceJSONRequestBody defines body for CreateInvoice for application/json ContentType.
+type CreateInvoiceJSONRequestBody = InvoiceParams
+
+// AddPropertyJSONRequestBody defines body for AddProperty for application/json ContentType.
+type AddPropertyJSONRequestBody = PropertyParams
+
+// UpdatePropertyJSONRequestBody defines body for UpdateProperty for application/json ContentType.
+type UpdatePropertyJSONRequestBody = PropertyParams
+
+// CreateSLAJSONRequestBody defines body for CreateSLA for application/json ContentType.
+type CreateSLAJSONRequestBody = CreateSLAParams
+
+// UpdateSLAJSONRequestBody defines body for UpdateSLA for application/json ContentType.
+type UpdateSLAJSONRequestBody = UpdateSlaParams
+
+// AssignSLAToPropertyJSONRequestBody defines body for AssignSLAToProperty for application/json ContentType.
+type AssignSLAToPropertyJSONRequestBody = AssignPropertyParams
+
+// SetSLASeasonsJSONRequestBody defines body for SetSLASeasons for application/json ContentType.
+type SetSLASeasonsJSONRequestBody = SeasonsParams
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Create the contract of a customer
+	// (POST /contract)
+	CreateCustomer(c *gin.Context)
+	// Get a customer with its properties and SLAs
+	// (GET /contract/{id})
+	ReadCustomer(c *gin.Context, id Id)
+	// Import customers and their SLAs from CSV or NDJSON in the background
+	// (POST /imports)
+	StartImport(c *gin.Context, params StartImportParams)
+	// Get the progress of an import with the result of every row
+	// (GET /imports/{id})
+	GetImport(c *gin.Context, id Id)
+	// Get the parameters of every service type
+	// (GET /services)
+	GetServiceSchemas(c *gin.Context)
+	// Get the price schedule of an SLA for the current year
+	// (POST /sla/evaluate)
+	EvaluateSLA(c *gin.Context)
+	// Price many SLA configurations at once
+	// (POST /sla/quotes)
+	QuoteSLAs(c *gin.Context)
+	// Remove an SLA of a customer
+	// (DELETE /sla/{id})
+	RemoveSLA(c *gin.Context, id Id)
+	// Get an SLA
+	// (GET /sla/{id})
+	ReadSLA(c *gin.Context, id Id)
+	// Change the target grass length of a mowing SLA
+	// (PUT /sla/{id}/grasslength)
+	UpdateTargetGrassLength(c *gin.Context, id Id)
+	// Change the allowed grass lengths of a mowing SLA
+	// (PUT /sla/{id}/intervall)
+	UpdateGrassLengthInterval(c *gin.Context, id Id)
+	// Get the service level of an SLA
+	// (GET /sla/{id}/servicelevel)
+	GetServiceLevel(c *gin.Context, id Id)
+	// Change the service level of an SLA
+	// (PUT /sla/{id}/servicelevel)
+	UpdateServiceLevel(c *gin.Context, id Id)
+	// Get the commit status of a transaction that was submitted asynchronously
+	// (GET /tx/{id})
+	GetTransaction(c *gin.Context, id Id)
+	// Get every invoice of a customer
+	// (GET /{customer_id}/invoices)
+	GetInvoices(c *gin.Context, customerId CustomerId)
+	// Invoice a customer for a month
+	// (POST /{customer_id}/invoices)
+	CreateInvoice(c *gin.Context, customerId CustomerId)
+	// Pay an invoice by transferring its total in tokens from the customer to the service owner
+	// (POST /{customer_id}/invoices/{period}/payment)
+	CollectPayment(c *gin.Context, customerId CustomerId, period string)
+	// Make the token account of the caller the account the customer pays from
+	// (PUT /{customer_id}/payment-account)
+	SetPaymentAccount(c *gin.Context, customerId CustomerId)
+	// Get the SLAs of a customer grouped by property
+	// (GET /{customer_id}/properties)
+	GetProperties(c *gin.Context, customerId CustomerId)
+	// Add a property to a customer
+	// (POST /{customer_id}/properties)
+	AddProperty(c *gin.Context, customerId CustomerId)
+	// Remove a property without SLAs from a customer
+	// (DELETE /{customer_id}/properties/{property_id})
+	RemoveProperty(c *gin.Context, customerId CustomerId, propertyId PropertyId)
+	// Change a property of a customer
+	// (PUT /{customer_id}/properties/{property_id})
+	UpdateProperty(c *gin.Context, customerId CustomerId, propertyId PropertyId)
+	// Repair the copies of the SLAs of a customer from the SLA chaincode
+	// (POST /{customer_id}/reconcile)
+	ReconcileCustomer(c *gin.Context, customerId CustomerId)
+	// Create an SLA for a customer
+	// (POST /{customer_id}/sla)
+	CreateSLA(c *gin.Context, customerId CustomerId)
+	// Change the service level and parameters of an SLA in a single transaction
+	// (PUT /{customer_id}/sla/{id})
+	UpdateSLA(c *gin.Context, customerId CustomerId, id Id)
+	// Attach an SLA to a property of the customer
+	// (PUT /{customer_id}/sla/{id}/property)
+	AssignSLAToProperty(c *gin.Context, customerId CustomerId, id Id)
+	// Replace the seasons of an SLA
+	// (PUT /{customer_id}/sla/{id}/seasons)
+	SetSLASeasons(c *gin.Context, customerId CustomerId, id Id)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandler       func(*gin.Context, error, int)
+}
+
+type MiddlewareFunc func(c *gin.Context)
+
+// CreateCustomer operation middleware
+func (siw *ServerInterfaceWrapper) CreateCustomer(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateCustomer(c)
+}
+
+// ReadCustomer operation middleware
+func (siw *ServerInterfaceWrapper) ReadCustomer(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReadCustomer(c, id)
+}
+
+// StartImport operation middleware
+func (siw *ServerInterfaceWrapper) StartImport(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{"service-owner"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StartImportParams
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "id", c.Request.URL.Query(), &params.Id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", c.Request.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter dryRun: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "parallel" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "parallel", c.Request.URL.Query(), &params.Parallel)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter parallel: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.StartImport(c, params)
+}
+
+// GetImport operation middleware
+func (siw *ServerInterfaceWrapper) GetImport(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetImport(c, id)
+}
+
+// GetServiceSchemas operation middleware
+func (siw *ServerInterfaceWrapper) GetServiceSchemas(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetServiceSchemas(c)
+}
+
+// EvaluateSLA operation middleware
+func (siw *ServerInterfaceWrapper) EvaluateSLA(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EvaluateSLA(c)
+}
+
+// QuoteSLAs operation middleware
+func (siw *ServerInterfaceWrapper) QuoteSLAs(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.QuoteSLAs(c)
+}
+
+// RemoveSLA operation middleware
+func (siw *ServerInterfaceWrapper) RemoveSLA(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveSLA(c, id)
+}
+
+// ReadSLA operation middleware
+func (siw *ServerInterfaceWrapper) ReadSLA(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReadSLA(c, id)
+}
+
+// UpdateTargetGrassLength operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTargetGrassLength(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateTargetGrassLength(c, id)
+}
+
+// UpdateGrassLengthInterval operation middleware
+func (siw *ServerInterfaceWrapper) UpdateGrassLengthInterval(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateGrassLengthInterval(c, id)
+}
+
+// GetServiceLevel operation middleware
+func (siw *ServerInterfaceWrapper) GetServiceLevel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetServiceLevel(c, id)
+}
+
+// UpdateServiceLevel operation middleware
+func (siw *ServerInterfaceWrapper) UpdateServiceLevel(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateServiceLevel(c, id)
+}
+
+// GetTransaction operation middleware
+func (siw *ServerInterfaceWrapper) GetTransaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTransaction(c, id)
+}
+
+// GetInvoices operation middleware
+func (siw *ServerInterfaceWrapper) GetInvoices(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetInvoices(c, customerId)
+}
+
+// CreateInvoice operation middleware
+func (siw *ServerInterfaceWrapper) CreateInvoice(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateInvoice(c, customerId)
+}
+
+// CollectPayment operation middleware
+func (siw *ServerInterfaceWrapper) CollectPayment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "period" -------------
+	var period string
+
+	err = runtime.BindStyledParameter("simple", false, "period", c.Param("period"), &period)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter period: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CollectPayment(c, customerId, period)
+}
+
+// SetPaymentAccount operation middleware
+func (siw *ServerInterfaceWrapper) SetPaymentAccount(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetPaymentAccount(c, customerId)
+}
+
+// GetProperties operation middleware
+func (siw *ServerInterfaceWrapper) GetProperties(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetProperties(c, customerId)
+}
+
+// AddProperty operation middleware
+func (siw *ServerInterfaceWrapper) AddProperty(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AddProperty(c, customerId)
+}
+
+// RemoveProperty operation middleware
+func (siw *ServerInterfaceWrapper) RemoveProperty(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "property_id" -------------
+	var propertyId PropertyId
+
+	err = runtime.BindStyledParameter("simple", false, "property_id", c.Param("property_id"), &propertyId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter property_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RemoveProperty(c, customerId, propertyId)
+}
+
+// UpdateProperty operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProperty(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "property_id" -------------
+	var propertyId PropertyId
+
+	err = runtime.BindStyledParameter("simple", false, "property_id", c.Param("property_id"), &propertyId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter property_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateProperty(c, customerId, propertyId)
+}
+
+// ReconcileCustomer operation middleware
+func (siw *ServerInterfaceWrapper) ReconcileCustomer(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ReconcileCustomer(c, customerId)
+}
+
+// CreateSLA operation middleware
+func (siw *ServerInterfaceWrapper) CreateSLA(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateSLA(c, customerId)
+}
+
+// UpdateSLA operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSLA(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateSLA(c, customerId, id)
+}
+
+// AssignSLAToProperty operation middleware
+func (siw *ServerInterfaceWrapper) AssignSLAToProperty(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.AssignSLAToProperty(c, customerId, id)
+}
+
+// SetSLASeasons operation middleware
+func (siw *ServerInterfaceWrapper) SetSLASeasons(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "customer_id" -------------
+	var customerId CustomerId
+
+	err = runtime.BindStyledParameter("simple", false, "customer_id", c.Param("customer_id"), &customerId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter customer_id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameter("simple", false, "id", c.Param("id"), &id)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{"customer", "service-owner"})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SetSLASeasons(c, customerId, id)
+}
+
+// GinServerOptions provides options for the Gin server.
+type GinServerOptions struct {
+	BaseURL      string
+	Middlewares  []MiddlewareFunc
+	ErrorHandler func(*gin.Context, error, int)
+}
+
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router gin.IRouter, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, GinServerOptions{})
+}
+
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options GinServerOptions) {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = func(c *gin.Context, err error, statusCode int) {
+			c.JSON(statusCode, gin.H{"msg": err.Error()})
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandler:       errorHandler,
+	}
+
+	router.POST(options.BaseURL+"/contract", wrapper.CreateCustomer)
+	router.GET(options.BaseURL+"/contract/:id", wrapper.ReadCustomer)
+	router.POST(options.BaseURL+"/imports", wrapper.StartImport)
+	router.GET(options.BaseURL+"/imports/:id", wrapper.GetImport)
+	router.GET(options.BaseURL+"/services", wrapper.GetServiceSchemas)
+	router.POST(options.BaseURL+"/sla/evaluate", wrapper.EvaluateSLA)
+	router.POST(options.BaseURL+"/sla/quotes", wrapper.QuoteSLAs)
+	router.DELETE(options.BaseURL+"/sla/:id", wrapper.RemoveSLA)
+	router.GET(options.BaseURL+"/sla/:id", wrapper.ReadSLA)
+	router.PUT(options.BaseURL+"/sla/:id/grasslength", wrapper.UpdateTargetGrassLength)
+	router.PUT(options.BaseURL+"/sla/:id/intervall", wrapper.UpdateGrassLengthInterval)
+	router.GET(options.BaseURL+"/sla/:id/servicelevel", wrapper.GetServiceLevel)
+	router.PUT(options.BaseURL+"/sla/:id/servicelevel", wrapper.UpdateServiceLevel)
+	router.GET(options.BaseURL+"/tx/:id", wrapper.GetTransaction)
+	router.GET(options.BaseURL+"/:customer_id/invoices", wrapper.GetInvoices)
+	router.POST(options.BaseURL+"/:customer_id/invoices", wrapper.CreateInvoice)
+	router.POST(options.BaseURL+"/:customer_id/invoices/:period/payment", wrapper.CollectPayment)
+	router.PUT(options.BaseURL+"/:customer_id/payment-account", wrapper.SetPaymentAccount)
+	router.GET(options.BaseURL+"/:customer_id/properties", wrapper.GetProperties)
+	router.POST(options.BaseURL+"/:customer_id/properties", wrapper.AddProperty)
+	router.DELETE(options.BaseURL+"/:customer_id/properties/:property_id", wrapper.RemoveProperty)
+	router.PUT(options.BaseURL+"/:customer_id/properties/:property_id", wrapper.UpdateProperty)
+	router.POST(options.BaseURL+"/:customer_id/reconcile", wrapper.ReconcileCustomer)
+	router.POST(options.BaseURL+"/:customer_id/sla", wrapper.CreateSLA)
+	router.PUT(options.BaseURL+"/:customer_id/sla/:id", wrapper.UpdateSLA)
+	router.PUT(options.BaseURL+"/:customer_id/sla/:id/property", wrapper.AssignSLAToProperty)
+	router.PUT(options.BaseURL+"/:customer_id/sla/:id/seasons", wrapper.SetSLASeasons)
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+w9a3PjNpJ/BcW7D3dVtOxxdnb3/E2xJzlv7GTOdpJKraZSENmSsKYALgBao53yf79q",
+	"PEiQBPWw5YyTnU+JhyTQ6Bf6rU9JJpal4MC1Ss4+JSWVdAkapPkrq5QWS5C/shz/zEFlkpWaCZ6cJXcL",
+	"IJcXRMyIXgDxryZpwvBpSfUiSRNOl5CctRZKEwn/rJiEPDnTsoI0UdkClhR30OsSX1daMj5PHh/TxO4c",
+	"WXLvlUopSpB6vcNh/Kvxw4QL7QPCI76sSsEVGOyOswxKDQaaTHANXOP/0rIsWEYRsON/KITuU7Dof0qY",
+	"JWfJfxw3dDu2T9XxnaRc0cwcyWzXP6NuXiErqgjwXEgFOaE8J0LmICFPzR+IBzwbKE2ouoeccKGJFmRF",
+	"mSYzIQnTimRiuWSarJheTPh7CTOQZ8SeMj+ias2zEbls3mOKSCiF1LijNntcCXvWUZImC6C5Yz3/z31S",
+	"/bwACZbn7KKOaOHRgn1Scqw/Hn9i+WOymT8s9MAzOBojCSxhmi/gI12WBX7UOl+S9gn9mCbvpBTyxpH7",
+	"YBQ2qw7R1lNrRlkBefKYJpcXt1WWgVJ7ARDh2yFhWS2oNnyUSaDabcofBMvgKTtvOrpbdgge5h+nyTUo",
+	"RecHB8Atuw37CreF3OLi9mp8aDBur8ZDINhHnsutjlGKzfl7p7Heo3Y3/+50GLOayD+/vIiLRqPj/h6+",
+	"+6FmfTH9B2QaT3xuGOH2ajy01zX9+K2kSl0Bn+uFwUVR/DBLzv6++dwXkLElLZLHD2ny8WgujnDrI3XP",
+	"yiNhkECLo1IwrkFaVYycwPhvttf71s252z7BN3tttYFcu69yC/KBZXAFD1AcYJ078/nTl7mjcg76NyLX",
+	"Y4xzvQXTY9moWNRkcC8xDZbdNxLcUS5pAKBS0rXTFbsvZES9u0ZHUi8vkhaQbosPG84+JLT++S4KInh3",
+	"41atDaLKTOEVQ2vTksylqErIyXRdW2ipfQ/tD1FpQusHhEogBVPG0lCk4tRoQsiTNK78nkJGg88IKX+s",
+	"dzs4UVv07OwTQ7eXjR6OxyS3jwivllNjuDcWzpvT0dueYRMIV3KWuK9HfoNQ9NiyFFJbfwIFOZkzvaim",
+	"o0wsjzktCvjzV2+OZ3QqWXbEQa+EvD/OFpTxTORwrBZUQn6ce6n21lSfLfF1/C/waonIYfyBFiz/1d3F",
+	"SZpUnFZ6IST7l6H8TMgpy3PgSZpwoX+diYrjv2eCzwqWaWPwa5DcHGhK81/nVMOKrhEZbAmi0smHLl7M",
+	"XawpK3antDnPhfmoR/HdVSZ4rHQNZDTKgGuykoLPU2MdL631UrtrHttktQBOmCYSkGUg79rSSeS4wePL",
+	"/OlKv8Pa9jippWqMl0OsRXVGcEhKSgBJhHRejSR6QTeess1aNM+lM9ueeqM5aKJ3x1KVB8Sc3ymGtOBG",
+	"vcQVHqwy2GaU7XTfxiysnT7swN/Zvrds7FyXRsfcgNc0fW4opZgjDQ07cGKVUmruCufdqqow7iM8gFwT",
+	"KVaEcfPI8IyXFSlWqscf56JyIROa58wSqX2TOIiRbHOQSc9i/1+xIkvKzb6KLOgDEKDZgihNdaWSmC6X",
+	"65uKB2tPhSiAcnz2DeNMLazPOhNySTXqaKrhCPVWTIgtAgdsmxux2l2beVIgOqOWjaZS7wParUVBoNll",
+	"xbkVkFxw/CajPAN0c/vquGsG+XPW69aYbEBLPUHd0Xsc1778plVxP3K899yLL3QF3dWHy5tztDAbV/RT",
+	"yOjSqTwpViOC8BvrpwSeMz4nFdesQE62RpGqpkumtYv5KE3X9ZvmLkCWt+cgSotSTfgUZsKGXJYpMTes",
+	"5dkV4C6SZdYmoyRHKap4ShgPXstEVdjo0RSIBIqBJv8ZgmCW4UJPeA1a6oIYwT5tsO1jG6mSUBZ07V9e",
+	"0JxMAbgPSRAL/agnwWPOK1rcCU2LbQx+LTisQ+PVerl5jCSgFyBbIdEwQIJ40gumEE1ev3jFVEfGMICl",
+	"FZkxqTQeKkkjAr/RJA8spt6T72Ad/fcrxiGut26vxgO79AXVsVKSJoYDUFIdrtLEE8pYWf6xpeR2MTbg",
+	"Wehbhw+kukud3YTYyNaLCrELTe3nVaVDbuelUhXkY727OkXU7aHNLbj4UUyZv6frpQtibQ5t2NfwC5BM",
+	"5Nv4p/Y97Pli59hHVmO+cItrHFQeO371gJ1qREfNjwBLPcKOl3iZ7KxVvmZFAfkFXQ/YDe9ppTY+3xS+",
+	"2yi+QJXgbdbovdS7zTfGjbYFhLqEscC1ztBeobNhC1st1DTHST0BNhBuMBRaM2vEvRAcTUfhg80YWPjl",
+	"l19+Obq+bnnPpyenfzo6eZtsU2lurxiU143v0IZv2KnYwyewnNcPCXBCDeLQDMa4i5TAs3X/5tyJvQMv",
+	"4dyv1BL023ffbUWR2ypYYotS92EJe8QXDUpcIzsU6/cyqty3CbX5+qny7nbcSbs8Qci77pkB1e+7iwTG",
+	"mK4Odt/WeY6eE7qf57nH29+b1G3M0anPGfOqfuRMbxc1s7h72QKWmsMEq29EyEY3cscDRtzfennrHCir",
+	"RtECxUR2SmA0H5F+2D0KaX3n931Yymt9iIZuSVne+Njvbs6PTk+IFvfAn6hHakb+RoplPBBP2V720J2I",
+	"LnOHQJ4LriXN9PAbqrUR4/rPf0rSiJTefdwlWl4rOLe2Oyb+nXRBcmvWB/4wTKpxlnncdu633vMtd1T7",
+	"/eiWqBbOPeFikWbktxwZsk7WL63uJJlQ2sVnbq/GKWZPF3it4jtTqqB+AR0iz8GFswSeeSsN6IS4cI+X",
+	"m8+PSi2vChgKRSHc9TlN+YQNOlmbAp+QNVB5EC/1a6pgvzvCaPjdXYTWzRcxEX/Bk0Surg5qf7EHbsCt",
+	"AUlb544j3WXS+sb3UOR22KW6EvqW/Qv2uX3ECuR25jHS6gFq9vHfbzrYkIE6fn5g+nl54wBZL5i5j+P3",
+	"OfndVtIuoqTqzGE76ajF3IZ06jsNFyBUa4oib9wBPZRUXO+TEH6pXOF6U+b3/yqhtyotohifF+bkBFNl",
+	"bF5JE+tI238qm2TJKHfRPhflM8FtyonP8BxAxUmg97lY8T1ytq1bKqK1BkJm+zCtUYvnQu1u1LSNwF2L",
+	"RdCydMVqm1zwA/nozV5bXPMAxDY2QoJt1+2GJ89Dxtpd2dwW1GnOx7SrOjfg7OmK5UPa96J7coJa4p94",
+	"qtRlnhwgtlBRV5I3YV/zXo2Ga6ol+9gXUPNQEUqWYoWh+7ZV0bKUCJ1TxpV2z+aSKkUKY+wT5lKCtuTT",
+	"+AKtF3rC6nOIu2uqWALy6TnvmDe7kR/M+8/asGHxfZzoZ1Q+7X66wCJ50uaPQ9KnGvOjfzF0dX7N267e",
+	"RnDjZQoOKdrylCwNE6dESDIVEaZqyfrup4/oiadToRG0rVu6V6PYu4FM8IwV0GSme/Yb5K0jbo24bskT",
+	"fCMksHmkUtoYKkoLCbnRDa2kVMVzkIRyYYwbseIgU7JaMPS/bD6O0FyUNnOzO6w3sBQP+x7wxzKner+P",
+	"hovOUofiZtkGqgZZH6K0w7c21K5uIYQq6J4Fcv6bGDRo4fW5pywlZQryn2hRwd55y/0yTU+1TbblIz6n",
+	"Cm+HoL8VRTTT1DGPmg/sbTtQsyD1BdXQCg9tiEN1A0Ftyu5jaUWSW61sSg1ZlM0sdiMO0YrxXKx8xGYN",
+	"VKZkzh6Ao1K/vj66uMBEv9cZ1uphiuRQsAdAnWM8JqtfwmAk/oUx49418I7nbWyfnB6d/jWGbB+6aV5d",
+	"WZKn27j4ZQuz7bmioWRDhTbMb06PTt5sZQwXgLLfpwZJ9U7DBFVDOuw3lr7HKITywYXMYnmANsF2c+46",
+	"uYXhtOWOWcmW7AUARRFeuxyxjBpKhRbWEx6R69paV+gmo49sRaqOLrTM8xmDIleps9ytKHnbHuGYcA6Q",
+	"KxLAayz5BuJ+3cuXBoxny/lnv8H+3Xs1wk7HyM0VtgJq36IWFJBh395CCi4qVaxH5J31mTH1sFt/JNMT",
+	"znxTo/V4MrApClM8QtoPpa+Hs/Vqghfr4HGwpcK6bD4HU5484QXkc5Ajgj5Xs3DF77lYcV+47Xdql9lh",
+	"k6a1+vsKYFqI7P57W/Qf9e/MC72eyhX1W+HKJsm5QxaszqBHTcF6vX3Sd4Nl7+uwK3QAHbEFZxWvWan3",
+	"UG4qvmyX0teRHEeahns6uMR1Va90zrFXEqClVSnnCB9tQKiZex9Ebinjf3Q1fMazPnck7Od/8Wym1t69",
+	"3D9uSn4aX11e1GgJmJxYHm+Vz1z/dH7+68278cWv5z98/83V5fndVhupfZKQ6wLq1jhvo2tLQUmw9Kgu",
+	"DTt4sWCLOWqPOBI6e6Jr+pkL/FvOyVOq/S0+wmvwiYjYEhnfBHfr0w1ADpuDd1Zd8DmYqJlPelsrz95U",
+	"pm0NZpqYdjYXiHEC88WUewlT7jCW1Wc3iSzz9cB4ophEj/Nsye+v2hcjvMwgqyTTa+PMOZsFqAQ5rvSi",
+	"+esbf8n97ec7P9vBON3maXNnLLQubcs84zPRl0oPojLJGSZJgynbItFJpkiAJZ6d/Be6cf/duG7+RRdM",
+	"9VM0XHWUmvAmeyyBTE0Vnfl6RL6m2b3vDgiCtLjEElPhjZWhiODtl1A7cChGEz7hjgx2h7/d/vA9sTRV",
+	"dWXNxDR3TpIR+RmvY2yPBK7ddYT2JXA6RcCcz1mCS2FZT5MSi94JNzVdNp+lMlGC77NqvqBuTocUhX1q",
+	"Q0X4T5UC6VfUpACqNBEczBl+8As4lWgva0I71mhvCsmIjOspDDVN7EgREh1NYox4ytWqiVadnpwikpQQ",
+	"3JchdeaKOCuNMK40UGfSs6ERJxPup49YM37GOC0CY54WSpBSmLSJFmY/OlWiqDQQ5Ft0HvC/ivx4c2Vz",
+	"8ee0KKY0uz/Cf7HHM2iLuD3YW2FuGgcb9gpVCrC8QXBb3qtbX3nLzMz1ME2heELHOhPet8xSyzGBkxS4",
+	"WZh2JHSmkZGxeduEPiTluVhilJCuaxmZ8MCjO7oBLVH4HO0UXSuyMJFIDTyksmUPzYqC+A5e5TY0tiVy",
+	"FdUaliXSZcLbtP7Tyf+MyDjwBduumL98pYEmNzi+sRv7OgcJVIMPfVIzzgf/usxhWQoNPFsffQdrfw4x",
+	"I1WJ1Dh9+xbjQVyjoJHx7fnlJWJe0sxEbJBRJrxxK+4BSsuKotKZWNZNvK5BqMvy9+AQSw3s6+aJokvz",
+	"2Dydinw94dZncQfy6zvexn1c450lZtA8GdLr8iI4YgNYgE1Efd7gRR/duFagM4IX2ojcQKXMNga8mWgy",
+	"U/54KCw1lQ0xar1tjlPrVWuRGwkl2INDZlIsyfntTyhL318YpUg1ObbvKe/+m+5P5StW7ECjdw+0qOw4",
+	"E9dGRihf6wVCyoKIQqDrzaKINeV7SVFWcXIBtxBRnS2sNvQK3FwMzvTzbWKNfBZrO0HJKpUQcqNWyMKp",
+	"KYTx8gIhweIKX7wjbc1N3eQ64aZH8L1QGg9RM4Xts/PEtcN96p5ZL2eiLG04wzfngaqWoAjTZ81h7Mvw",
+	"kSk94bUB647lVIJDliGZjc9AcHb8qO7p8zAxSVgjVsglJrSpmTY+4/np1yRwq5I0eQCp7P3+ZnSSPKaJ",
+	"KIHTkiVnyVejk9FXSWo8NmNdHGdB1W/pynfqewx9YzfX5ryZcub48muRrw8226czhuOxbUw5S7M1Q+z0",
+	"5GRo0fq9485ApMcUO1S2f1bPJzPl5TPqwiCbP2rPvQqtOWMHh3bc35NgaJyznY6M7ZR8QFtYVcsllesa",
+	"+S66YynVrtJL0kTTuQrXVMkH3L0mrZEWhH8OEfLeAM0D4oZj8Aas9+aVY5Yb2z1GmIOyxdDcpxoLn51Q",
+	"34IOqGKFF22jjkWNgr+BZE6/hcLYsdhvfzJ6j/p7xyg1gwpRVEuuSON2pOQ7vA+bjGgaZktSEjqA6YT3",
+	"XJSUtL1sc4C2M2w01tIo9rweRSNhTqUx6jKqbFYmyOJMuIXUXms1L/kLNMzxjPylZU7MiesJR3uIuyUL",
+	"xsHYRWJV708nPDyaizobQVLWXnUoGiGCULlyzWaGQnadTi84U07fm/i3Mnu60THGjsZ8ZB3hY4qg0bVO",
+	"iRKE+g/9Utbu96MV/D1p7iFjXaNib4unSX7ag/elc9MkR99bTQmHlSlMMiAa4EZkzAlQWTCQHrTwCmxO",
+	"PPKDIP9ZgVx3ZlA2MrykH72z/Ob0r5GYZRfWH5Am9qYOBk3Edsr9wIJmt1rSZ7RQ0G8Rf0xj0VozcaK5",
+	"rFsGE1qAtsozBgJivSigiAPx1xSPz5YYyv7qNE2WjNs/3vSzAo8fdr0+Px7x3OvKJkD8aRKEFSbJ2SSZ",
+	"ytnRm0mSTlrxHPMISzzMk55km8dvzbO2iJsHf7EPGO8++GqSbBslqeGjPs7UQxvsQCe1lE5f5bTBSTtA",
+	"cHPaFA+Wvk3/kn6VbJ06utmEOD3ckMZwKMvQpEYnadRky6T2CqUe17JhSOj+M0JD7VUPBA0N6M3Ie7Eb",
+	"deM1atEYiqm1mJm0NnPEo+k5G8H96o7bvl279lAbgZaEVj/cQ6mbISKhmU0kGBKqvsb+Fgb19We2pnbh",
+	"UedstS6R5PPwAppUemia0cZhRoMc4PZTg9bwt6BbNToqeSY9dqySCLaMVHxGKRVaSuq5JOojvdVD266t",
+	"17Y4yKPYlEDXGC7oMbjgwbBPGYQXXsihDLohdvYlD7JxuydygHZ1g1GnFdKXGx6enGZD5cDq9GPamLqN",
+	"ibpWzEHaun8YdlDaFfLBLMqwNr4upvQV8giR6+doJR1UGu/aUBM+0Leh6mL7ETnfpUXLBl18/QIzfo6N",
+	"HfV1uymCdz7cS3BtqwPhBRh39+aCXbTQ2HY/BN03Wbcl4SBMbETK2u+9/iIV2O6DTOsv/BwKGOr5w5Xr",
+	"hEadhZJANL0Hbg0PY2eIfN1njLqA/umX/uG5qVvV/yWq5hjKIsarwKF4miooMlE6HDd7Fr33xXowUf11",
+	"RLsM9vr4CoXu2Gjmos6jl1UEkwP5+9ckSZtLDL7IlY9WmySqzR33b2YraE3X5hbW8Vd9sYVxYl2Wr451",
+	"huvZvjBPn3loUYgV5G27bk/2cXsVvrxpi7vn24tewmM3QbGyoIwP/a5KvBtrs99nT/YqrgLdBatxMGL3",
+	"6QZhPgw1XkqKI1WYX8S3L747swIKrP+JoqF4nMkU1IVTq4UI6my6RUrYV6QACFZE2YplCAJ5traCLEQl",
+	"g1IZV0EVC+Pdtaekv6pY3n6/eHXweILFmi/nMpp5r9aTgBeC7zxPfAp+Ne3x2FcRbtLil/6dfekU7PR8",
+	"gu0zZXbXMF99+leh6a2b72Aa9JywwBNvMKPsNxSVeFQ8n2iH1/jtaamHUvOdnwh7PWp+c1bG0bshtlWm",
+	"NnAZJfywGB9/Ks3018fjshmuOMAloigg034I47PYJB2ea+uTHTU3xn760A9NHv7Vw13G4H7492Ga93Qd",
+	"TsjEAm/U9DOQ0lQRakW00LQwCTwzArIJsgUzyPr15Tvym+OuIxoMhIwZnregO6MeP+sdssNocQ/nUHLB",
+	"vkX8wV+hLdlhlWt676IGyAcecC+XGRZfWGPNP2nxSEnXlnV2ZYxWO8iQRdH6ualXyg+RXxQbTDi1ytCa",
+	"jPrrcSB3+9mzgMYBHUNDY3C6YV0fxl2Nk8tCkTlwJL7NUWFFti8Mj/0ybt9TGOd5MG/w9VkynbmaBzNl",
+	"Ln6/zuo4z8NfzNMibse2OGyTIjn+FPw0cifVFEsUHYZf0q2vB1A9Mevwh8n19PVAU1C0nfgbg1afj5q/",
+	"G2XxRwlv0YFhubsrDenH5A27PfUkvSfX5f9mFkh36F/E/PjZx4IklNSw0e/Egbkx8LpwV8ls69KApVJ7",
+	"LqZAIZjBMFTp32YKVdDhkponTk7yUPZnJylThx42A9hqmmB20oARhKXkt1dj24nYb7Drtv/J7iJ1I3C8",
+	"kS/ovjP1+nhs1w3XN7vq39t+lUZX99fAv1hdnmJh5dmmuouolNS5gk05pKvxS9/EL59pekL54j7lI3+U",
+	"5BKqrc7PvVjuMr/j5Ia3d37Td1c2q+2tQX4bm9+gvr0a34nfygZ8Qc6zp/liAA64jOZnDzx7GY8xNAXD",
+	"iNTuxmBQrVDP1RsKUqIAu7d+vzzWHtH5hbkaU7OgmVdwBkXRvHkQ1ty4sV0e5EO8UzA7nR7RskzSpJKF",
+	"mxNzdnxciIwWC6H02duTkzeGB9zGQ4Nj6qZk1WRKGku3n3O5HRooEw4FaJYyKrq/ShPubH1ns871NIRm",
+	"Gi/VzZKBQMbAM6hP66Sru1/WNZCu9tst5skRA9F87r4gze8I218iNifPm4VcxXIkSRXO5WiS6T6EgbNg",
+	"0Eh2VfxMusR8s3Iru95f/2scE+F6ZNrIDLqV3VLuteTxw+P/DwB/d2PdPY0AAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
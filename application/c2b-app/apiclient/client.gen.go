@@ -0,0 +1,4449 @@
+// Package apiclient provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen version v1.13.4 DO NOT EDIT.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/deepmap/oapi-codegen/pkg/runtime"
+	"github.com/nalle631/fabric-network/application/shared/bulk"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ErrorCode.
+const (
+	BadGateway     ErrorCode = "bad_gateway"
+	Conflict       ErrorCode = "conflict"
+	Forbidden      ErrorCode = "forbidden"
+	Internal       ErrorCode = "internal"
+	InvalidRequest ErrorCode = "invalid_request"
+	NotFound       ErrorCode = "not_found"
+	Timeout        ErrorCode = "timeout"
+	Unauthorized   ErrorCode = "unauthorized"
+)
+
+// AssignPropertyParams defines model for AssignPropertyParams.
+type AssignPropertyParams struct {
+	PropertyID string `json:"PropertyID"`
+}
+
+// CreateSLAParams defines model for CreateSLAParams.
+type CreateSLAParams struct {
+	MaxGrassLength    Decimal    `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    Decimal    `json:"MinGrassLength,omitempty"`
+	Parameters        Parameters `json:"Parameters,omitempty"`
+	PropertyID        string     `json:"PropertyID,omitempty"`
+	ServiceLevel      string     `json:"ServiceLevel,omitempty"`
+	ServiceType       string     `json:"ServiceType,omitempty"`
+	TargetGrassLength Decimal    `json:"TargetGrassLength,omitempty"`
+}
+
+// Customer defines model for Customer.
+type Customer struct {
+	ID         string     `json:"ID"`
+	Properties []Property `json:"Properties"`
+	SLAs       []SLA      `json:"SLAs"`
+}
+
+// CustomerParams defines model for CustomerParams.
+type CustomerParams struct {
+	CustomerID string `json:"CustomerID"`
+}
+
+// CustomerProperties The SLAs of a customer grouped by property, SLAs without a property are listed as unassigned
+type CustomerProperties struct {
+	Properties     []PropertySLAs `json:"Properties"`
+	UnassignedSLAs []SLA          `json:"UnassignedSLAs"`
+}
+
+// Decimal A decimal number
+type Decimal = decimal.Decimal
+
+// Error defines model for Error.
+type Error struct {
+	Code    ErrorCode     `json:"code"`
+	Details []ErrorDetail `json:"details,omitempty"`
+
+	// Error What went wrong, the message of the chaincode when it rejected the transaction
+	Error         string `json:"error"`
+	TransactionId string `json:"transactionId,omitempty"`
+}
+
+// ErrorCode defines model for Error.Code.
+type ErrorCode string
+
+// ErrorDetail The message of a peer or orderer that rejected the transaction
+type ErrorDetail struct {
+	Address string `json:"address,omitempty"`
+	Message string `json:"message"`
+	MspId   string `json:"mspId,omitempty"`
+}
+
+// GrassLengthInterval defines model for GrassLengthInterval.
+type GrassLengthInterval struct {
+	// MaxGrassLength A decimal number
+	MaxGrassLength Decimal `json:"MaxGrassLength"`
+
+	// MinGrassLength A decimal number
+	MinGrassLength Decimal `json:"MinGrassLength"`
+}
+
+// ImportReport The progress of an import, with the result of every row in the order of the rows
+type ImportReport = bulk.Report
+
+// ImportResult What became of a row. Rows are pending until they are submitted and stay pending when the import stops
+// before them, valid rows were priced by a dry run, invalid rows could not be read or priced and were not
+// submitted, failed rows were submitted and failed, and replayed rows had been created before.
+type ImportResult = bulk.Result
+
+// Invoice defines model for Invoice.
+type Invoice struct {
+	CustomerID string        `json:"CustomerID"`
+	ID         string        `json:"ID"`
+	IssuedAt   time.Time     `json:"IssuedAt"`
+	Lines      []InvoiceLine `json:"Lines"`
+
+	// Payment How an invoice was paid with the ERC-20 token
+	Payment *Payment `json:"Payment,omitempty"`
+	Period  string   `json:"Period"`
+	Status  string   `json:"Status"`
+
+	// Total An amount in a currency
+	Total Money `json:"Total"`
+}
+
+// InvoiceLine defines model for InvoiceLine.
+type InvoiceLine struct {
+	// Amount An amount in a currency
+	Amount       Money    `json:"Amount"`
+	BilledDays   int      `json:"BilledDays"`
+	PausedDays   int      `json:"PausedDays"`
+	PropertyID   string   `json:"PropertyID"`
+	SLAID        string   `json:"SLAID"`
+	Seasons      []string `json:"Seasons"`
+	ServiceLevel string   `json:"ServiceLevel"`
+	ServiceType  string   `json:"ServiceType"`
+}
+
+// InvoiceParams defines model for InvoiceParams.
+type InvoiceParams struct {
+	// Period The month to invoice as YYYY-MM
+	Period string `json:"Period"`
+}
+
+// Message defines model for Message.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// Money An amount in a currency
+type Money = decimal.Money
+
+// MonthlyPrice defines model for MonthlyPrice.
+type MonthlyPrice struct {
+	BilledDays int `json:"BilledDays"`
+	Month      int `json:"Month"`
+	PausedDays int `json:"PausedDays"`
+
+	// Price An amount in a currency
+	Price   Money    `json:"Price"`
+	Seasons []string `json:"Seasons"`
+}
+
+// ParameterSchema defines model for ParameterSchema.
+type ParameterSchema struct {
+	// Max A decimal number
+	Max Decimal `json:"Max"`
+
+	// Min A decimal number
+	Min      Decimal `json:"Min"`
+	Name     string  `json:"Name"`
+	Required bool    `json:"Required"`
+	Unit     string  `json:"Unit"`
+}
+
+// Parameters The parameters of a service by name, e.g. TargetGrassLength
+type Parameters map[string]Decimal
+
+// Payment How an invoice was paid with the ERC-20 token
+type Payment struct {
+	// Amount An amount in a currency
+	Amount        Money     `json:"Amount"`
+	From          string    `json:"From"`
+	PaidAt        time.Time `json:"PaidAt"`
+	To            string    `json:"To"`
+	TokenContract string    `json:"TokenContract"`
+	Tokens        int64     `json:"Tokens"`
+	TxID          string    `json:"TxID"`
+}
+
+// PaymentAccount defines model for PaymentAccount.
+type PaymentAccount struct {
+	PaymentAccount string `json:"PaymentAccount"`
+}
+
+// PriceComponent A named part of the monthly cost of an SLA, such as the base cost of its service level
+type PriceComponent struct {
+	// Amount A decimal number
+	Amount Decimal `json:"Amount"`
+	Name   string  `json:"Name"`
+}
+
+// PriceSchedule The price of an SLA for every month of a year
+type PriceSchedule struct {
+	// AnnualTotal An amount in a currency
+	AnnualTotal Money `json:"AnnualTotal"`
+
+	// BasePrice An amount in a currency
+	BasePrice Money          `json:"BasePrice"`
+	Months    []MonthlyPrice `json:"Months"`
+	Year      int            `json:"Year"`
+}
+
+// Property defines model for Property.
+type Property struct {
+	Address string `json:"Address"`
+	ID      string `json:"ID"`
+
+	// LotSize A decimal number
+	LotSize Decimal `json:"LotSize"`
+	Mower   string  `json:"Mower"`
+}
+
+// PropertyParams defines model for PropertyParams.
+type PropertyParams struct {
+	Address string  `json:"Address,omitempty"`
+	ID      string  `json:"ID,omitempty"`
+	LotSize Decimal `json:"LotSize,omitempty"`
+	Mower   string  `json:"Mower,omitempty"`
+}
+
+// PropertySLAs A property of a customer together with the SLAs attached to it
+type PropertySLAs struct {
+	Property Property `json:"Property"`
+	SLAs     []SLA    `json:"SLAs"`
+}
+
+// Quote The price of a single SLA configuration, configurations that cannot be priced have an error
+type Quote struct {
+	// AnnualTotal An amount in a currency
+	AnnualTotal Money            `json:"AnnualTotal"`
+	Breakdown   []PriceComponent `json:"Breakdown"`
+	Error       string           `json:"Error,omitempty"`
+
+	// MonthlyCost An amount in a currency
+	MonthlyCost Money `json:"MonthlyCost"`
+
+	// Parameters The parameters of a service by name, e.g. TargetGrassLength
+	Parameters   Parameters `json:"Parameters"`
+	Reference    string     `json:"Reference"`
+	ServiceLevel string     `json:"ServiceLevel"`
+	ServiceType  string     `json:"ServiceType"`
+}
+
+// QuoteConfiguration defines model for QuoteConfiguration.
+type QuoteConfiguration struct {
+	MaxGrassLength    Decimal    `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    Decimal    `json:"MinGrassLength,omitempty"`
+	Parameters        Parameters `json:"Parameters,omitempty"`
+	Reference         string     `json:"Reference,omitempty"`
+	Seasons           []Season   `json:"Seasons,omitempty"`
+	ServiceLevel      string     `json:"ServiceLevel,omitempty"`
+	ServiceType       string     `json:"ServiceType,omitempty"`
+	TargetGrassLength Decimal    `json:"TargetGrassLength,omitempty"`
+}
+
+// QuoteMatrix Quotes a mowing SLA for every service level against every grass length interval and target grass length
+type QuoteMatrix struct {
+	Intervals          []GrassLengthInterval `json:"Intervals,omitempty"`
+	Seasons            []Season              `json:"Seasons,omitempty"`
+	ServiceLevels      []string              `json:"ServiceLevels,omitempty"`
+	TargetGrassLengths []Decimal             `json:"TargetGrassLengths,omitempty"`
+}
+
+// QuotesParams The configurations to quote, listed one by one, as a matrix, or both
+type QuotesParams struct {
+	Configurations []QuoteConfiguration `json:"Configurations,omitempty"`
+
+	// Matrix Quotes a mowing SLA for every service level against every grass length interval and target grass length
+	Matrix *QuoteMatrix `json:"Matrix,omitempty"`
+}
+
+// ReconcileReport defines model for ReconcileReport.
+type ReconcileReport struct {
+	Added      []string `json:"Added"`
+	CustomerID string   `json:"CustomerID"`
+
+	// Foreign SLAs stored for the customer under another owner, which are not adopted
+	Foreign []string `json:"Foreign"`
+	Removed []string `json:"Removed"`
+	Updated []string `json:"Updated"`
+}
+
+// RemoveSLAParams defines model for RemoveSLAParams.
+type RemoveSLAParams struct {
+	CustomerID string `json:"CustomerID"`
+	SlaID      string `json:"slaID"`
+}
+
+// SLA defines model for SLA.
+type SLA struct {
+	// AppraisedValue An amount in a currency
+	AppraisedValue Money  `json:"AppraisedValue"`
+	CustomerID     string `json:"CustomerID"`
+	ID             string `json:"ID"`
+
+	// Parameters The parameters of a service by name, e.g. TargetGrassLength
+	Parameters   Parameters `json:"Parameters"`
+	PropertyID   string     `json:"PropertyID"`
+	Seasons      []Season   `json:"Seasons,omitempty"`
+	ServiceLevel string     `json:"ServiceLevel"`
+	ServiceType  string     `json:"ServiceType"`
+	StartDate    time.Time  `json:"StartDate"`
+}
+
+// Season A window of the year, given as MM-DD, in which an SLA is delivered with other parameters or paused
+type Season struct {
+	End        string     `json:"End"`
+	Name       string     `json:"Name"`
+	Parameters Parameters `json:"Parameters,omitempty"`
+	Paused     bool       `json:"Paused"`
+	Start      string     `json:"Start"`
+}
+
+// SeasonsParams defines model for SeasonsParams.
+type SeasonsParams struct {
+	Seasons []Season `json:"Seasons,omitempty"`
+}
+
+// ServiceSchema defines model for ServiceSchema.
+type ServiceSchema struct {
+	Parameters  []ParameterSchema `json:"Parameters"`
+	ServiceType string            `json:"ServiceType"`
+}
+
+// SlaParams An SLA to price. Mowing SLAs can be given with the grass length fields, every other service type
+// needs ServiceType and Parameters.
+type SlaParams struct {
+	MaxGrassLength    Decimal    `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    Decimal    `json:"MinGrassLength,omitempty"`
+	Parameters        Parameters `json:"Parameters,omitempty"`
+	Seasons           []Season   `json:"Seasons,omitempty"`
+	ServiceLevel      string     `json:"ServiceLevel,omitempty"`
+	ServiceType       string     `json:"ServiceType,omitempty"`
+	TargetGrassLength Decimal    `json:"TargetGrassLength,omitempty"`
+}
+
+// Transaction A transaction that was submitted asynchronously. Every such transaction was endorsed and ordered, it
+// is committed once its status is committed or invalid, and only committed transactions changed the
+// ledger. The status is unknown when its commit could not be waited for.
+type Transaction = transaction.Status
+
+// UpdateGrassLengthIntervalParams defines model for UpdateGrassLengthIntervalParams.
+type UpdateGrassLengthIntervalParams struct {
+	CustomerID string `json:"CustomerID"`
+
+	// MaxGrassLength A decimal number
+	MaxGrassLength Decimal `json:"MaxGrassLength"`
+
+	// MinGrassLength A decimal number
+	MinGrassLength Decimal `json:"MinGrassLength"`
+}
+
+// UpdateServiceLevelParams defines model for UpdateServiceLevelParams.
+type UpdateServiceLevelParams struct {
+	CustomerID   string `json:"CustomerID"`
+	ServiceLevel string `json:"ServiceLevel"`
+}
+
+// UpdateSlaParams The changes to an SLA, fields that are left out are not changed
+type UpdateSlaParams struct {
+	MaxGrassLength    Decimal    `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    Decimal    `json:"MinGrassLength,omitempty"`
+	Parameters        Parameters `json:"Parameters,omitempty"`
+	ServiceLevel      string     `json:"ServiceLevel,omitempty"`
+	TargetGrassLength Decimal    `json:"TargetGrassLength,omitempty"`
+}
+
+// UpdateTargetGrassLengthParams defines model for UpdateTargetGrassLengthParams.
+type UpdateTargetGrassLengthParams struct {
+	CustomerID string `json:"CustomerID"`
+
+	// TargetGrassLength A decimal number
+	TargetGrassLength Decimal `json:"TargetGrassLength"`
+}
+
+// CustomerId defines model for customer_id.
+type CustomerId = string
+
+// Id defines model for id.
+type Id = string
+
+// PropertyId defines model for property_id.
+type PropertyId = string
+
+// Accepted A transaction that was submitted asynchronously. Every such transaction was endorsed and ordered, it
+// is committed once its status is committed or invalid, and only committed transactions changed the
+// ledger. The status is unknown when its commit could not be waited for.
+type Accepted = Transaction
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse = Error
+
+// IDSuccess defines model for IDSuccess.
+type IDSuccess = string
+
+// InvoiceSuccess defines model for InvoiceSuccess.
+type InvoiceSuccess = Invoice
+
+// MessageSuccess defines model for MessageSuccess.
+type MessageSuccess = Message
+
+// SLASuccess defines model for SLASuccess.
+type SLASuccess = SLA
+
+// StartImportParams defines parameters for StartImport.
+type StartImportParams struct {
+	// Id The ID of the import, a new one when empty. An earlier import with the ID is resumed.
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
+
+	// DryRun Only price the rows
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+
+	// Parallel How many customers are imported at once
+	Parallel *int `form:"parallel,omitempty" json:"parallel,omitempty"`
+}
+
+// CreateCustomerJSONRequestBody defines body for CreateCustomer for application/json ContentType.
+type CreateCustomerJSONRequestBody = CustomerParams
+
+// EvaluateSLAJSONRequestBody defines body for EvaluateSLA for application/json ContentType.
+type EvaluateSLAJSONRequestBody = SlaParams
+
+// QuoteSLAsJSONRequestBody defines body for QuoteSLAs for application/json ContentType.
+type QuoteSLAsJSONRequestBody = QuotesParams
+
+// RemoveSLAJSONRequestBody defines body for RemoveSLA for application/json ContentType.
+type RemoveSLAJSONRequestBody = RemoveSLAParams
+
+// UpdateTargetGrassLengthJSONRequestBody defines body for UpdateTargetGrassLength for application/json ContentType.
+type UpdateTargetGrassLengthJSONRequestBody = UpdateTargetGrassLengthParams
+
+// UpdateGrassLengthIntervalJSONRequestBody defines body for UpdateGrassLengthInterval for application/json ContentType.
+type UpdateGrassLengthIntervalJSONRequestBody = UpdateGrassLengthIntervalParams
+
+// UpdateServiceLevelJSONRequestBody defines body for UpdateServiceLevel for application/json ContentType.
+type UpdateServiceLevelJSONRequestBody = UpdateServiceLevelParams
+
+// CreateInvoiceJSONRequestBody defines body for CreateInvoice for application/json ContentType.
+type CreateInvoiceJSONRequestBody = InvoiceParams
+
+// AddPropertyJSONRequestBody defines body for AddProperty for application/json ContentType.
+type AddPropertyJSONRequestBody = PropertyParams
+
+// UpdatePropertyJSONRequestBody defines body for UpdateProperty for application/json ContentType.
+type UpdatePropertyJSONRequestBody = PropertyParams
+
+// CreateSLAJSONRequestBody defines body for CreateSLA for application/json ContentType.
+type CreateSLAJSONRequestBody = CreateSLAParams
+
+// UpdateSLAJSONRequestBody defines body for UpdateSLA for application/json ContentType.
+type UpdateSLAJSONRequestBody = UpdateSlaParams
+
+// AssignSLAToPropertyJSONRequestBody defines body for AssignSLAToProperty for application/json ContentType.
+type AssignSLAToPropertyJSONRequestBody = AssignPropertyParams
+
+// SetSLASeasonsJSONRequestBody defines body for SetSLASeasons for application/json ContentType.
+type SetSLASeasonsJSONRequestBody = SeasonsParams
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// CreateCustomerWithBody request with any body
+	CreateCustomerWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateCustomer(ctx context.Context, body CreateCustomerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReadCustomer request
+	ReadCustomer(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartImportWithBody request with any body
+	StartImportWithBody(ctx context.Context, params *StartImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetImport request
+	GetImport(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetServiceSchemas request
+	GetServiceSchemas(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EvaluateSLAWithBody request with any body
+	EvaluateSLAWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	EvaluateSLA(ctx context.Context, body EvaluateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QuoteSLAsWithBody request with any body
+	QuoteSLAsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	QuoteSLAs(ctx context.Context, body QuoteSLAsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveSLAWithBody request with any body
+	RemoveSLAWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RemoveSLA(ctx context.Context, id Id, body RemoveSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReadSLA request
+	ReadSLA(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateTargetGrassLengthWithBody request with any body
+	UpdateTargetGrassLengthWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateTargetGrassLength(ctx context.Context, id Id, body UpdateTargetGrassLengthJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateGrassLengthIntervalWithBody request with any body
+	UpdateGrassLengthIntervalWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateGrassLengthInterval(ctx context.Context, id Id, body UpdateGrassLengthIntervalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetServiceLevel request
+	GetServiceLevel(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateServiceLevelWithBody request with any body
+	UpdateServiceLevelWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateServiceLevel(ctx context.Context, id Id, body UpdateServiceLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransaction request
+	GetTransaction(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetInvoices request
+	GetInvoices(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateInvoiceWithBody request with any body
+	CreateInvoiceWithBody(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateInvoice(ctx context.Context, customerId CustomerId, body CreateInvoiceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CollectPayment request
+	CollectPayment(ctx context.Context, customerId CustomerId, period string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetPaymentAccount request
+	SetPaymentAccount(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetProperties request
+	GetProperties(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddPropertyWithBody request with any body
+	AddPropertyWithBody(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddProperty(ctx context.Context, customerId CustomerId, body AddPropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveProperty request
+	RemoveProperty(ctx context.Context, customerId CustomerId, propertyId PropertyId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdatePropertyWithBody request with any body
+	UpdatePropertyWithBody(ctx context.Context, customerId CustomerId, propertyId PropertyId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateProperty(ctx context.Context, customerId CustomerId, propertyId PropertyId, body UpdatePropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReconcileCustomer request
+	ReconcileCustomer(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateSLAWithBody request with any body
+	CreateSLAWithBody(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSLA(ctx context.Context, customerId CustomerId, body CreateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateSLAWithBody request with any body
+	UpdateSLAWithBody(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateSLA(ctx context.Context, customerId CustomerId, id Id, body UpdateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AssignSLAToPropertyWithBody request with any body
+	AssignSLAToPropertyWithBody(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AssignSLAToProperty(ctx context.Context, customerId CustomerId, id Id, body AssignSLAToPropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetSLASeasonsWithBody request with any body
+	SetSLASeasonsWithBody(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetSLASeasons(ctx context.Context, customerId CustomerId, id Id, body SetSLASeasonsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) CreateCustomerWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCustomerRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateCustomer(ctx context.Context, body CreateCustomerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCustomerRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReadCustomer(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadCustomerRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StartImportWithBody(ctx context.Context, params *StartImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartImportRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetImport(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetImportRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetServiceSchemas(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetServiceSchemasRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EvaluateSLAWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEvaluateSLARequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EvaluateSLA(ctx context.Context, body EvaluateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEvaluateSLARequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QuoteSLAsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQuoteSLAsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QuoteSLAs(ctx context.Context, body QuoteSLAsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQuoteSLAsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveSLAWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveSLARequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveSLA(ctx context.Context, id Id, body RemoveSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveSLARequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReadSLA(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadSLARequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTargetGrassLengthWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTargetGrassLengthRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTargetGrassLength(ctx context.Context, id Id, body UpdateTargetGrassLengthJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTargetGrassLengthRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateGrassLengthIntervalWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateGrassLengthIntervalRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateGrassLengthInterval(ctx context.Context, id Id, body UpdateGrassLengthIntervalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateGrassLengthIntervalRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetServiceLevel(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetServiceLevelRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateServiceLevelWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateServiceLevelRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateServiceLevel(ctx context.Context, id Id, body UpdateServiceLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateServiceLevelRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransaction(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetInvoices(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetInvoicesRequest(c.Server, customerId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateInvoiceWithBody(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateInvoiceRequestWithBody(c.Server, customerId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateInvoice(ctx context.Context, customerId CustomerId, body CreateInvoiceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateInvoiceRequest(c.Server, customerId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CollectPayment(ctx context.Context, customerId CustomerId, period string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCollectPaymentRequest(c.Server, customerId, period)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPaymentAccount(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPaymentAccountRequest(c.Server, customerId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetProperties(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPropertiesRequest(c.Server, customerId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddPropertyWithBody(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPropertyRequestWithBody(c.Server, customerId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddProperty(ctx context.Context, customerId CustomerId, body AddPropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddPropertyRequest(c.Server, customerId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveProperty(ctx context.Context, customerId CustomerId, propertyId PropertyId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemovePropertyRequest(c.Server, customerId, propertyId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdatePropertyWithBody(ctx context.Context, customerId CustomerId, propertyId PropertyId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdatePropertyRequestWithBody(c.Server, customerId, propertyId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateProperty(ctx context.Context, customerId CustomerId, propertyId PropertyId, body UpdatePropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdatePropertyRequest(c.Server, customerId, propertyId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReconcileCustomer(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReconcileCustomerRequest(c.Server, customerId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSLAWithBody(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSLARequestWithBody(c.Server, customerId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSLA(ctx context.Context, customerId CustomerId, body CreateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSLARequest(c.Server, customerId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateSLAWithBody(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSLARequestWithBody(c.Server, customerId, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateSLA(ctx context.Context, customerId CustomerId, id Id, body UpdateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSLARequest(c.Server, customerId, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AssignSLAToPropertyWithBody(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignSLAToPropertyRequestWithBody(c.Server, customerId, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AssignSLAToProperty(ctx context.Context, customerId CustomerId, id Id, body AssignSLAToPropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignSLAToPropertyRequest(c.Server, customerId, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetSLASeasonsWithBody(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetSLASeasonsRequestWithBody(c.Server, customerId, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetSLASeasons(ctx context.Context, customerId CustomerId, id Id, body SetSLASeasonsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetSLASeasonsRequest(c.Server, customerId, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewCreateCustomerRequest calls the generic CreateCustomer builder with application/json body
+func NewCreateCustomerRequest(server string, body CreateCustomerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateCustomerRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateCustomerRequestWithBody generates requests for CreateCustomer with any type of body
+func NewCreateCustomerRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contract")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReadCustomerRequest generates requests for ReadCustomer
+func NewReadCustomerRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/contract/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStartImportRequestWithBody generates requests for StartImport with any type of body
+func NewStartImportRequestWithBody(server string, params *StartImportParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/imports")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Id != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "id", runtime.ParamLocationQuery, *params.Id); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.DryRun != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dryRun", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Parallel != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "parallel", runtime.ParamLocationQuery, *params.Parallel); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetImportRequest generates requests for GetImport
+func NewGetImportRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/imports/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetServiceSchemasRequest generates requests for GetServiceSchemas
+func NewGetServiceSchemasRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/services")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewEvaluateSLARequest calls the generic EvaluateSLA builder with application/json body
+func NewEvaluateSLARequest(server string, body EvaluateSLAJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewEvaluateSLARequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewEvaluateSLARequestWithBody generates requests for EvaluateSLA with any type of body
+func NewEvaluateSLARequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sla/evaluate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewQuoteSLAsRequest calls the generic QuoteSLAs builder with application/json body
+func NewQuoteSLAsRequest(server string, body QuoteSLAsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewQuoteSLAsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewQuoteSLAsRequestWithBody generates requests for QuoteSLAs with any type of body
+func NewQuoteSLAsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sla/quotes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemoveSLARequest calls the generic RemoveSLA builder with application/json body
+func NewRemoveSLARequest(server string, id Id, body RemoveSLAJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRemoveSLARequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewRemoveSLARequestWithBody generates requests for RemoveSLA with any type of body
+func NewRemoveSLARequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sla/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReadSLARequest generates requests for ReadSLA
+func NewReadSLARequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sla/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateTargetGrassLengthRequest calls the generic UpdateTargetGrassLength builder with application/json body
+func NewUpdateTargetGrassLengthRequest(server string, id Id, body UpdateTargetGrassLengthJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateTargetGrassLengthRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateTargetGrassLengthRequestWithBody generates requests for UpdateTargetGrassLength with any type of body
+func NewUpdateTargetGrassLengthRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sla/%s/grasslength", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateGrassLengthIntervalRequest calls the generic UpdateGrassLengthInterval builder with application/json body
+func NewUpdateGrassLengthIntervalRequest(server string, id Id, body UpdateGrassLengthIntervalJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateGrassLengthIntervalRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateGrassLengthIntervalRequestWithBody generates requests for UpdateGrassLengthInterval with any type of body
+func NewUpdateGrassLengthIntervalRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sla/%s/intervall", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetServiceLevelRequest generates requests for GetServiceLevel
+func NewGetServiceLevelRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sla/%s/servicelevel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateServiceLevelRequest calls the generic UpdateServiceLevel builder with application/json body
+func NewUpdateServiceLevelRequest(server string, id Id, body UpdateServiceLevelJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateServiceLevelRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUpdateServiceLevelRequestWithBody generates requests for UpdateServiceLevel with any type of body
+func NewUpdateServiceLevelRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sla/%s/servicelevel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTransactionRequest generates requests for GetTransaction
+func NewGetTransactionRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tx/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetInvoicesRequest generates requests for GetInvoices
+func NewGetInvoicesRequest(server string, customerId CustomerId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/invoices", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateInvoiceRequest calls the generic CreateInvoice builder with application/json body
+func NewCreateInvoiceRequest(server string, customerId CustomerId, body CreateInvoiceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateInvoiceRequestWithBody(server, customerId, "application/json", bodyReader)
+}
+
+// NewCreateInvoiceRequestWithBody generates requests for CreateInvoice with any type of body
+func NewCreateInvoiceRequestWithBody(server string, customerId CustomerId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/invoices", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCollectPaymentRequest generates requests for CollectPayment
+func NewCollectPaymentRequest(server string, customerId CustomerId, period string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "period", runtime.ParamLocationPath, period)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/invoices/%s/payment", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetPaymentAccountRequest generates requests for SetPaymentAccount
+func NewSetPaymentAccountRequest(server string, customerId CustomerId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/payment-account", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPropertiesRequest generates requests for GetProperties
+func NewGetPropertiesRequest(server string, customerId CustomerId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/properties", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddPropertyRequest calls the generic AddProperty builder with application/json body
+func NewAddPropertyRequest(server string, customerId CustomerId, body AddPropertyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddPropertyRequestWithBody(server, customerId, "application/json", bodyReader)
+}
+
+// NewAddPropertyRequestWithBody generates requests for AddProperty with any type of body
+func NewAddPropertyRequestWithBody(server string, customerId CustomerId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/properties", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRemovePropertyRequest generates requests for RemoveProperty
+func NewRemovePropertyRequest(server string, customerId CustomerId, propertyId PropertyId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "property_id", runtime.ParamLocationPath, propertyId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/properties/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdatePropertyRequest calls the generic UpdateProperty builder with application/json body
+func NewUpdatePropertyRequest(server string, customerId CustomerId, propertyId PropertyId, body UpdatePropertyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdatePropertyRequestWithBody(server, customerId, propertyId, "application/json", bodyReader)
+}
+
+// NewUpdatePropertyRequestWithBody generates requests for UpdateProperty with any type of body
+func NewUpdatePropertyRequestWithBody(server string, customerId CustomerId, propertyId PropertyId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "property_id", runtime.ParamLocationPath, propertyId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/properties/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReconcileCustomerRequest generates requests for ReconcileCustomer
+func NewReconcileCustomerRequest(server string, customerId CustomerId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/reconcile", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateSLARequest calls the generic CreateSLA builder with application/json body
+func NewCreateSLARequest(server string, customerId CustomerId, body CreateSLAJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSLARequestWithBody(server, customerId, "application/json", bodyReader)
+}
+
+// NewCreateSLARequestWithBody generates requests for CreateSLA with any type of body
+func NewCreateSLARequestWithBody(server string, customerId CustomerId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/sla", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateSLARequest calls the generic UpdateSLA builder with application/json body
+func NewUpdateSLARequest(server string, customerId CustomerId, id Id, body UpdateSLAJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateSLARequestWithBody(server, customerId, id, "application/json", bodyReader)
+}
+
+// NewUpdateSLARequestWithBody generates requests for UpdateSLA with any type of body
+func NewUpdateSLARequestWithBody(server string, customerId CustomerId, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/sla/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAssignSLAToPropertyRequest calls the generic AssignSLAToProperty builder with application/json body
+func NewAssignSLAToPropertyRequest(server string, customerId CustomerId, id Id, body AssignSLAToPropertyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAssignSLAToPropertyRequestWithBody(server, customerId, id, "application/json", bodyReader)
+}
+
+// NewAssignSLAToPropertyRequestWithBody generates requests for AssignSLAToProperty with any type of body
+func NewAssignSLAToPropertyRequestWithBody(server string, customerId CustomerId, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/sla/%s/property", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSetSLASeasonsRequest calls the generic SetSLASeasons builder with application/json body
+func NewSetSLASeasonsRequest(server string, customerId CustomerId, id Id, body SetSLASeasonsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetSLASeasonsRequestWithBody(server, customerId, id, "application/json", bodyReader)
+}
+
+// NewSetSLASeasonsRequestWithBody generates requests for SetSLASeasons with any type of body
+func NewSetSLASeasonsRequestWithBody(server string, customerId CustomerId, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "customer_id", runtime.ParamLocationPath, customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/%s/sla/%s/seasons", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// CreateCustomerWithBodyWithResponse request with any body
+	CreateCustomerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCustomerResponse, error)
+
+	CreateCustomerWithResponse(ctx context.Context, body CreateCustomerJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCustomerResponse, error)
+
+	// ReadCustomerWithResponse request
+	ReadCustomerWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ReadCustomerResponse, error)
+
+	// StartImportWithBodyWithResponse request with any body
+	StartImportWithBodyWithResponse(ctx context.Context, params *StartImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartImportResponse, error)
+
+	// GetImportWithResponse request
+	GetImportWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetImportResponse, error)
+
+	// GetServiceSchemasWithResponse request
+	GetServiceSchemasWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetServiceSchemasResponse, error)
+
+	// EvaluateSLAWithBodyWithResponse request with any body
+	EvaluateSLAWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EvaluateSLAResponse, error)
+
+	EvaluateSLAWithResponse(ctx context.Context, body EvaluateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*EvaluateSLAResponse, error)
+
+	// QuoteSLAsWithBodyWithResponse request with any body
+	QuoteSLAsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*QuoteSLAsResponse, error)
+
+	QuoteSLAsWithResponse(ctx context.Context, body QuoteSLAsJSONRequestBody, reqEditors ...RequestEditorFn) (*QuoteSLAsResponse, error)
+
+	// RemoveSLAWithBodyWithResponse request with any body
+	RemoveSLAWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RemoveSLAResponse, error)
+
+	RemoveSLAWithResponse(ctx context.Context, id Id, body RemoveSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*RemoveSLAResponse, error)
+
+	// ReadSLAWithResponse request
+	ReadSLAWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ReadSLAResponse, error)
+
+	// UpdateTargetGrassLengthWithBodyWithResponse request with any body
+	UpdateTargetGrassLengthWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTargetGrassLengthResponse, error)
+
+	UpdateTargetGrassLengthWithResponse(ctx context.Context, id Id, body UpdateTargetGrassLengthJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTargetGrassLengthResponse, error)
+
+	// UpdateGrassLengthIntervalWithBodyWithResponse request with any body
+	UpdateGrassLengthIntervalWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateGrassLengthIntervalResponse, error)
+
+	UpdateGrassLengthIntervalWithResponse(ctx context.Context, id Id, body UpdateGrassLengthIntervalJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateGrassLengthIntervalResponse, error)
+
+	// GetServiceLevelWithResponse request
+	GetServiceLevelWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetServiceLevelResponse, error)
+
+	// UpdateServiceLevelWithBodyWithResponse request with any body
+	UpdateServiceLevelWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateServiceLevelResponse, error)
+
+	UpdateServiceLevelWithResponse(ctx context.Context, id Id, body UpdateServiceLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateServiceLevelResponse, error)
+
+	// GetTransactionWithResponse request
+	GetTransactionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error)
+
+	// GetInvoicesWithResponse request
+	GetInvoicesWithResponse(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*GetInvoicesResponse, error)
+
+	// CreateInvoiceWithBodyWithResponse request with any body
+	CreateInvoiceWithBodyWithResponse(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateInvoiceResponse, error)
+
+	CreateInvoiceWithResponse(ctx context.Context, customerId CustomerId, body CreateInvoiceJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateInvoiceResponse, error)
+
+	// CollectPaymentWithResponse request
+	CollectPaymentWithResponse(ctx context.Context, customerId CustomerId, period string, reqEditors ...RequestEditorFn) (*CollectPaymentResponse, error)
+
+	// SetPaymentAccountWithResponse request
+	SetPaymentAccountWithResponse(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*SetPaymentAccountResponse, error)
+
+	// GetPropertiesWithResponse request
+	GetPropertiesWithResponse(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*GetPropertiesResponse, error)
+
+	// AddPropertyWithBodyWithResponse request with any body
+	AddPropertyWithBodyWithResponse(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPropertyResponse, error)
+
+	AddPropertyWithResponse(ctx context.Context, customerId CustomerId, body AddPropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPropertyResponse, error)
+
+	// RemovePropertyWithResponse request
+	RemovePropertyWithResponse(ctx context.Context, customerId CustomerId, propertyId PropertyId, reqEditors ...RequestEditorFn) (*RemovePropertyResponse, error)
+
+	// UpdatePropertyWithBodyWithResponse request with any body
+	UpdatePropertyWithBodyWithResponse(ctx context.Context, customerId CustomerId, propertyId PropertyId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdatePropertyResponse, error)
+
+	UpdatePropertyWithResponse(ctx context.Context, customerId CustomerId, propertyId PropertyId, body UpdatePropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdatePropertyResponse, error)
+
+	// ReconcileCustomerWithResponse request
+	ReconcileCustomerWithResponse(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*ReconcileCustomerResponse, error)
+
+	// CreateSLAWithBodyWithResponse request with any body
+	CreateSLAWithBodyWithResponse(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSLAResponse, error)
+
+	CreateSLAWithResponse(ctx context.Context, customerId CustomerId, body CreateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSLAResponse, error)
+
+	// UpdateSLAWithBodyWithResponse request with any body
+	UpdateSLAWithBodyWithResponse(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSLAResponse, error)
+
+	UpdateSLAWithResponse(ctx context.Context, customerId CustomerId, id Id, body UpdateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSLAResponse, error)
+
+	// AssignSLAToPropertyWithBodyWithResponse request with any body
+	AssignSLAToPropertyWithBodyWithResponse(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AssignSLAToPropertyResponse, error)
+
+	AssignSLAToPropertyWithResponse(ctx context.Context, customerId CustomerId, id Id, body AssignSLAToPropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*AssignSLAToPropertyResponse, error)
+
+	// SetSLASeasonsWithBodyWithResponse request with any body
+	SetSLASeasonsWithBodyWithResponse(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetSLASeasonsResponse, error)
+
+	SetSLASeasonsWithResponse(ctx context.Context, customerId CustomerId, id Id, body SetSLASeasonsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetSLASeasonsResponse, error)
+}
+
+type CreateCustomerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateCustomerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateCustomerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReadCustomerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Customer
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ReadCustomerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReadCustomerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartImportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *ImportReport
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r StartImportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartImportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetImportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportReport
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetImportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetImportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetServiceSchemasResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ServiceSchema
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetServiceSchemasResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetServiceSchemasResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EvaluateSLAResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PriceSchedule
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r EvaluateSLAResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EvaluateSLAResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QuoteSLAsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Quote
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r QuoteSLAsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QuoteSLAsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveSLAResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveSLAResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveSLAResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReadSLAResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SLASuccess
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ReadSLAResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReadSLAResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateTargetGrassLengthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateTargetGrassLengthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateTargetGrassLengthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateGrassLengthIntervalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateGrassLengthIntervalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateGrassLengthIntervalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetServiceLevelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetServiceLevelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetServiceLevelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateServiceLevelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateServiceLevelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateServiceLevelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Transaction
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetInvoicesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Invoice
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetInvoicesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetInvoicesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateInvoiceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InvoiceSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateInvoiceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateInvoiceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CollectPaymentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *InvoiceSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CollectPaymentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CollectPaymentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetPaymentAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PaymentAccount
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SetPaymentAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetPaymentAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPropertiesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CustomerProperties
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPropertiesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPropertiesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddPropertyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IDSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AddPropertyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddPropertyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemovePropertyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r RemovePropertyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemovePropertyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdatePropertyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdatePropertyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdatePropertyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReconcileCustomerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReconcileReport
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ReconcileCustomerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReconcileCustomerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSLAResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IDSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSLAResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSLAResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateSLAResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SLASuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateSLAResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateSLAResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AssignSLAToPropertyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r AssignSLAToPropertyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AssignSLAToPropertyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetSLASeasonsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageSuccess
+	JSON202      *Accepted
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r SetSLASeasonsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetSLASeasonsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// CreateCustomerWithBodyWithResponse request with arbitrary body returning *CreateCustomerResponse
+func (c *ClientWithResponses) CreateCustomerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCustomerResponse, error) {
+	rsp, err := c.CreateCustomerWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCustomerResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateCustomerWithResponse(ctx context.Context, body CreateCustomerJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCustomerResponse, error) {
+	rsp, err := c.CreateCustomer(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCustomerResponse(rsp)
+}
+
+// ReadCustomerWithResponse request returning *ReadCustomerResponse
+func (c *ClientWithResponses) ReadCustomerWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ReadCustomerResponse, error) {
+	rsp, err := c.ReadCustomer(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReadCustomerResponse(rsp)
+}
+
+// StartImportWithBodyWithResponse request with arbitrary body returning *StartImportResponse
+func (c *ClientWithResponses) StartImportWithBodyWithResponse(ctx context.Context, params *StartImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*StartImportResponse, error) {
+	rsp, err := c.StartImportWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartImportResponse(rsp)
+}
+
+// GetImportWithResponse request returning *GetImportResponse
+func (c *ClientWithResponses) GetImportWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetImportResponse, error) {
+	rsp, err := c.GetImport(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetImportResponse(rsp)
+}
+
+// GetServiceSchemasWithResponse request returning *GetServiceSchemasResponse
+func (c *ClientWithResponses) GetServiceSchemasWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetServiceSchemasResponse, error) {
+	rsp, err := c.GetServiceSchemas(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetServiceSchemasResponse(rsp)
+}
+
+// EvaluateSLAWithBodyWithResponse request with arbitrary body returning *EvaluateSLAResponse
+func (c *ClientWithResponses) EvaluateSLAWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EvaluateSLAResponse, error) {
+	rsp, err := c.EvaluateSLAWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEvaluateSLAResponse(rsp)
+}
+
+func (c *ClientWithResponses) EvaluateSLAWithResponse(ctx context.Context, body EvaluateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*EvaluateSLAResponse, error) {
+	rsp, err := c.EvaluateSLA(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEvaluateSLAResponse(rsp)
+}
+
+// QuoteSLAsWithBodyWithResponse request with arbitrary body returning *QuoteSLAsResponse
+func (c *ClientWithResponses) QuoteSLAsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*QuoteSLAsResponse, error) {
+	rsp, err := c.QuoteSLAsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQuoteSLAsResponse(rsp)
+}
+
+func (c *ClientWithResponses) QuoteSLAsWithResponse(ctx context.Context, body QuoteSLAsJSONRequestBody, reqEditors ...RequestEditorFn) (*QuoteSLAsResponse, error) {
+	rsp, err := c.QuoteSLAs(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQuoteSLAsResponse(rsp)
+}
+
+// RemoveSLAWithBodyWithResponse request with arbitrary body returning *RemoveSLAResponse
+func (c *ClientWithResponses) RemoveSLAWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RemoveSLAResponse, error) {
+	rsp, err := c.RemoveSLAWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveSLAResponse(rsp)
+}
+
+func (c *ClientWithResponses) RemoveSLAWithResponse(ctx context.Context, id Id, body RemoveSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*RemoveSLAResponse, error) {
+	rsp, err := c.RemoveSLA(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveSLAResponse(rsp)
+}
+
+// ReadSLAWithResponse request returning *ReadSLAResponse
+func (c *ClientWithResponses) ReadSLAWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*ReadSLAResponse, error) {
+	rsp, err := c.ReadSLA(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReadSLAResponse(rsp)
+}
+
+// UpdateTargetGrassLengthWithBodyWithResponse request with arbitrary body returning *UpdateTargetGrassLengthResponse
+func (c *ClientWithResponses) UpdateTargetGrassLengthWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTargetGrassLengthResponse, error) {
+	rsp, err := c.UpdateTargetGrassLengthWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTargetGrassLengthResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateTargetGrassLengthWithResponse(ctx context.Context, id Id, body UpdateTargetGrassLengthJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTargetGrassLengthResponse, error) {
+	rsp, err := c.UpdateTargetGrassLength(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTargetGrassLengthResponse(rsp)
+}
+
+// UpdateGrassLengthIntervalWithBodyWithResponse request with arbitrary body returning *UpdateGrassLengthIntervalResponse
+func (c *ClientWithResponses) UpdateGrassLengthIntervalWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateGrassLengthIntervalResponse, error) {
+	rsp, err := c.UpdateGrassLengthIntervalWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateGrassLengthIntervalResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateGrassLengthIntervalWithResponse(ctx context.Context, id Id, body UpdateGrassLengthIntervalJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateGrassLengthIntervalResponse, error) {
+	rsp, err := c.UpdateGrassLengthInterval(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateGrassLengthIntervalResponse(rsp)
+}
+
+// GetServiceLevelWithResponse request returning *GetServiceLevelResponse
+func (c *ClientWithResponses) GetServiceLevelWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetServiceLevelResponse, error) {
+	rsp, err := c.GetServiceLevel(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetServiceLevelResponse(rsp)
+}
+
+// UpdateServiceLevelWithBodyWithResponse request with arbitrary body returning *UpdateServiceLevelResponse
+func (c *ClientWithResponses) UpdateServiceLevelWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateServiceLevelResponse, error) {
+	rsp, err := c.UpdateServiceLevelWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateServiceLevelResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateServiceLevelWithResponse(ctx context.Context, id Id, body UpdateServiceLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateServiceLevelResponse, error) {
+	rsp, err := c.UpdateServiceLevel(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateServiceLevelResponse(rsp)
+}
+
+// GetTransactionWithResponse request returning *GetTransactionResponse
+func (c *ClientWithResponses) GetTransactionWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*GetTransactionResponse, error) {
+	rsp, err := c.GetTransaction(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionResponse(rsp)
+}
+
+// GetInvoicesWithResponse request returning *GetInvoicesResponse
+func (c *ClientWithResponses) GetInvoicesWithResponse(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*GetInvoicesResponse, error) {
+	rsp, err := c.GetInvoices(ctx, customerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetInvoicesResponse(rsp)
+}
+
+// CreateInvoiceWithBodyWithResponse request with arbitrary body returning *CreateInvoiceResponse
+func (c *ClientWithResponses) CreateInvoiceWithBodyWithResponse(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateInvoiceResponse, error) {
+	rsp, err := c.CreateInvoiceWithBody(ctx, customerId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateInvoiceResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateInvoiceWithResponse(ctx context.Context, customerId CustomerId, body CreateInvoiceJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateInvoiceResponse, error) {
+	rsp, err := c.CreateInvoice(ctx, customerId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateInvoiceResponse(rsp)
+}
+
+// CollectPaymentWithResponse request returning *CollectPaymentResponse
+func (c *ClientWithResponses) CollectPaymentWithResponse(ctx context.Context, customerId CustomerId, period string, reqEditors ...RequestEditorFn) (*CollectPaymentResponse, error) {
+	rsp, err := c.CollectPayment(ctx, customerId, period, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCollectPaymentResponse(rsp)
+}
+
+// SetPaymentAccountWithResponse request returning *SetPaymentAccountResponse
+func (c *ClientWithResponses) SetPaymentAccountWithResponse(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*SetPaymentAccountResponse, error) {
+	rsp, err := c.SetPaymentAccount(ctx, customerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPaymentAccountResponse(rsp)
+}
+
+// GetPropertiesWithResponse request returning *GetPropertiesResponse
+func (c *ClientWithResponses) GetPropertiesWithResponse(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*GetPropertiesResponse, error) {
+	rsp, err := c.GetProperties(ctx, customerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPropertiesResponse(rsp)
+}
+
+// AddPropertyWithBodyWithResponse request with arbitrary body returning *AddPropertyResponse
+func (c *ClientWithResponses) AddPropertyWithBodyWithResponse(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddPropertyResponse, error) {
+	rsp, err := c.AddPropertyWithBody(ctx, customerId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPropertyResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddPropertyWithResponse(ctx context.Context, customerId CustomerId, body AddPropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*AddPropertyResponse, error) {
+	rsp, err := c.AddProperty(ctx, customerId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddPropertyResponse(rsp)
+}
+
+// RemovePropertyWithResponse request returning *RemovePropertyResponse
+func (c *ClientWithResponses) RemovePropertyWithResponse(ctx context.Context, customerId CustomerId, propertyId PropertyId, reqEditors ...RequestEditorFn) (*RemovePropertyResponse, error) {
+	rsp, err := c.RemoveProperty(ctx, customerId, propertyId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemovePropertyResponse(rsp)
+}
+
+// UpdatePropertyWithBodyWithResponse request with arbitrary body returning *UpdatePropertyResponse
+func (c *ClientWithResponses) UpdatePropertyWithBodyWithResponse(ctx context.Context, customerId CustomerId, propertyId PropertyId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdatePropertyResponse, error) {
+	rsp, err := c.UpdatePropertyWithBody(ctx, customerId, propertyId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdatePropertyResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdatePropertyWithResponse(ctx context.Context, customerId CustomerId, propertyId PropertyId, body UpdatePropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdatePropertyResponse, error) {
+	rsp, err := c.UpdateProperty(ctx, customerId, propertyId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdatePropertyResponse(rsp)
+}
+
+// ReconcileCustomerWithResponse request returning *ReconcileCustomerResponse
+func (c *ClientWithResponses) ReconcileCustomerWithResponse(ctx context.Context, customerId CustomerId, reqEditors ...RequestEditorFn) (*ReconcileCustomerResponse, error) {
+	rsp, err := c.ReconcileCustomer(ctx, customerId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReconcileCustomerResponse(rsp)
+}
+
+// CreateSLAWithBodyWithResponse request with arbitrary body returning *CreateSLAResponse
+func (c *ClientWithResponses) CreateSLAWithBodyWithResponse(ctx context.Context, customerId CustomerId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSLAResponse, error) {
+	rsp, err := c.CreateSLAWithBody(ctx, customerId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSLAResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSLAWithResponse(ctx context.Context, customerId CustomerId, body CreateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSLAResponse, error) {
+	rsp, err := c.CreateSLA(ctx, customerId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSLAResponse(rsp)
+}
+
+// UpdateSLAWithBodyWithResponse request with arbitrary body returning *UpdateSLAResponse
+func (c *ClientWithResponses) UpdateSLAWithBodyWithResponse(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSLAResponse, error) {
+	rsp, err := c.UpdateSLAWithBody(ctx, customerId, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSLAResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateSLAWithResponse(ctx context.Context, customerId CustomerId, id Id, body UpdateSLAJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSLAResponse, error) {
+	rsp, err := c.UpdateSLA(ctx, customerId, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSLAResponse(rsp)
+}
+
+// AssignSLAToPropertyWithBodyWithResponse request with arbitrary body returning *AssignSLAToPropertyResponse
+func (c *ClientWithResponses) AssignSLAToPropertyWithBodyWithResponse(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AssignSLAToPropertyResponse, error) {
+	rsp, err := c.AssignSLAToPropertyWithBody(ctx, customerId, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignSLAToPropertyResponse(rsp)
+}
+
+func (c *ClientWithResponses) AssignSLAToPropertyWithResponse(ctx context.Context, customerId CustomerId, id Id, body AssignSLAToPropertyJSONRequestBody, reqEditors ...RequestEditorFn) (*AssignSLAToPropertyResponse, error) {
+	rsp, err := c.AssignSLAToProperty(ctx, customerId, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignSLAToPropertyResponse(rsp)
+}
+
+// SetSLASeasonsWithBodyWithResponse request with arbitrary body returning *SetSLASeasonsResponse
+func (c *ClientWithResponses) SetSLASeasonsWithBodyWithResponse(ctx context.Context, customerId CustomerId, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetSLASeasonsResponse, error) {
+	rsp, err := c.SetSLASeasonsWithBody(ctx, customerId, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetSLASeasonsResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetSLASeasonsWithResponse(ctx context.Context, customerId CustomerId, id Id, body SetSLASeasonsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetSLASeasonsResponse, error) {
+	rsp, err := c.SetSLASeasons(ctx, customerId, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetSLASeasonsResponse(rsp)
+}
+
+// ParseCreateCustomerResponse parses an HTTP response from a CreateCustomerWithResponse call
+func ParseCreateCustomerResponse(rsp *http.Response) (*CreateCustomerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateCustomerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReadCustomerResponse parses an HTTP response from a ReadCustomerWithResponse call
+func ParseReadCustomerResponse(rsp *http.Response) (*ReadCustomerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadCustomerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Customer
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStartImportResponse parses an HTTP response from a StartImportWithResponse call
+func ParseStartImportResponse(rsp *http.Response) (*StartImportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StartImportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest ImportReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetImportResponse parses an HTTP response from a GetImportWithResponse call
+func ParseGetImportResponse(rsp *http.Response) (*GetImportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetImportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetServiceSchemasResponse parses an HTTP response from a GetServiceSchemasWithResponse call
+func ParseGetServiceSchemasResponse(rsp *http.Response) (*GetServiceSchemasResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetServiceSchemasResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ServiceSchema
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEvaluateSLAResponse parses an HTTP response from a EvaluateSLAWithResponse call
+func ParseEvaluateSLAResponse(rsp *http.Response) (*EvaluateSLAResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EvaluateSLAResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PriceSchedule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQuoteSLAsResponse parses an HTTP response from a QuoteSLAsWithResponse call
+func ParseQuoteSLAsResponse(rsp *http.Response) (*QuoteSLAsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QuoteSLAsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Quote
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveSLAResponse parses an HTTP response from a RemoveSLAWithResponse call
+func ParseRemoveSLAResponse(rsp *http.Response) (*RemoveSLAResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveSLAResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReadSLAResponse parses an HTTP response from a ReadSLAWithResponse call
+func ParseReadSLAResponse(rsp *http.Response) (*ReadSLAResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadSLAResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SLASuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateTargetGrassLengthResponse parses an HTTP response from a UpdateTargetGrassLengthWithResponse call
+func ParseUpdateTargetGrassLengthResponse(rsp *http.Response) (*UpdateTargetGrassLengthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateTargetGrassLengthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateGrassLengthIntervalResponse parses an HTTP response from a UpdateGrassLengthIntervalWithResponse call
+func ParseUpdateGrassLengthIntervalResponse(rsp *http.Response) (*UpdateGrassLengthIntervalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateGrassLengthIntervalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetServiceLevelResponse parses an HTTP response from a GetServiceLevelWithResponse call
+func ParseGetServiceLevelResponse(rsp *http.Response) (*GetServiceLevelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetServiceLevelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateServiceLevelResponse parses an HTTP response from a UpdateServiceLevelWithResponse call
+func ParseUpdateServiceLevelResponse(rsp *http.Response) (*UpdateServiceLevelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateServiceLevelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionResponse parses an HTTP response from a GetTransactionWithResponse call
+func ParseGetTransactionResponse(rsp *http.Response) (*GetTransactionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetInvoicesResponse parses an HTTP response from a GetInvoicesWithResponse call
+func ParseGetInvoicesResponse(rsp *http.Response) (*GetInvoicesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetInvoicesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Invoice
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateInvoiceResponse parses an HTTP response from a CreateInvoiceWithResponse call
+func ParseCreateInvoiceResponse(rsp *http.Response) (*CreateInvoiceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateInvoiceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InvoiceSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCollectPaymentResponse parses an HTTP response from a CollectPaymentWithResponse call
+func ParseCollectPaymentResponse(rsp *http.Response) (*CollectPaymentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CollectPaymentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest InvoiceSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetPaymentAccountResponse parses an HTTP response from a SetPaymentAccountWithResponse call
+func ParseSetPaymentAccountResponse(rsp *http.Response) (*SetPaymentAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetPaymentAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PaymentAccount
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPropertiesResponse parses an HTTP response from a GetPropertiesWithResponse call
+func ParseGetPropertiesResponse(rsp *http.Response) (*GetPropertiesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPropertiesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CustomerProperties
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddPropertyResponse parses an HTTP response from a AddPropertyWithResponse call
+func ParseAddPropertyResponse(rsp *http.Response) (*AddPropertyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddPropertyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IDSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemovePropertyResponse parses an HTTP response from a RemovePropertyWithResponse call
+func ParseRemovePropertyResponse(rsp *http.Response) (*RemovePropertyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemovePropertyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdatePropertyResponse parses an HTTP response from a UpdatePropertyWithResponse call
+func ParseUpdatePropertyResponse(rsp *http.Response) (*UpdatePropertyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdatePropertyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReconcileCustomerResponse parses an HTTP response from a ReconcileCustomerWithResponse call
+func ParseReconcileCustomerResponse(rsp *http.Response) (*ReconcileCustomerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReconcileCustomerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReconcileReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateSLAResponse parses an HTTP response from a CreateSLAWithResponse call
+func ParseCreateSLAResponse(rsp *http.Response) (*CreateSLAResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateSLAResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IDSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateSLAResponse parses an HTTP response from a UpdateSLAWithResponse call
+func ParseUpdateSLAResponse(rsp *http.Response) (*UpdateSLAResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateSLAResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SLASuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAssignSLAToPropertyResponse parses an HTTP response from a AssignSLAToPropertyWithResponse call
+func ParseAssignSLAToPropertyResponse(rsp *http.Response) (*AssignSLAToPropertyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AssignSLAToPropertyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetSLASeasonsResponse parses an HTTP response from a SetSLASeasonsWithResponse call
+func ParseSetSLASeasonsResponse(rsp *http.Response) (*SetSLASeasonsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetSLASeasonsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageSuccess
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Accepted
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nalle631/fabric-network/application/c2b-app/api"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+)
+
+func (Server) SetSLASeasons(c *gin.Context, customerID string, slaID string) {
+	contract := newCustomerContract(c)
+
+	var seasonsParams api.SeasonsParams
+	if err := c.BindJSON(&seasonsParams); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+	if seasonsParams.Seasons == nil {
+		seasonsParams.Seasons = []api.Season{}
+	}
+	seasonsJSON, err := json.Marshal(seasonsParams.Seasons)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+
+	fmt.Printf("\n--> Submit Transaction: SetSLASeasons, function replaces the seasons of an SLA\n")
+	_, err = transactions.Submit(c.Request.Context(), contract, "SetSLASeasons", customerID, slaID, string(seasonsJSON))
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "Seasons updated successfully"})
+}
+
+// CreateInvoice invoices the customer for the period given as YYYY-MM.
+// The identity of the application must be enrolled with the service owner role.
+func (Server) CreateInvoice(c *gin.Context, customerID string) {
+	contract := newCustomerContract(c)
+
+	var invoiceParams api.InvoiceParams
+	if err := c.BindJSON(&invoiceParams); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+
+	fmt.Printf("\n--> Submit Transaction: CreateInvoice, function bills the customer for a month\n")
+	result, err := transactions.Submit(c.Request.Context(), contract, "CreateInvoice", customerID, invoiceParams.Period)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+
+	var invoice api.Invoice
+	err = json.Unmarshal(result, &invoice)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to unmarshal result: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, invoice)
+}
+
+func (Server) GetInvoices(c *gin.Context, customerID string) {
+	contract := newCustomerContract(c)
+
+	fmt.Printf("\n--> Evaluate Transaction: GetInvoices, function returns every invoice of the customer\n")
+	result, err := contract.EvaluateTransaction("GetInvoices", customerID)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to evaluate transaction: %w", err))
+		return
+	}
+
+	invoices := []api.Invoice{}
+	err = json.Unmarshal(result, &invoices)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to unmarshal result: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, invoices)
+}
+
+// CollectPayment pays the invoice of the customer for the period by transferring its total in tokens
+// from the customer to the service owner. The identity of the application must be enrolled with the service owner role.
+func (Server) CollectPayment(c *gin.Context, customerID string, period string) {
+	contract := newCustomerContract(c)
+
+	fmt.Printf("\n--> Submit Transaction: CollectPayment, function pays an invoice with the token\n")
+	result, err := transactions.Submit(c.Request.Context(), contract, "CollectPayment", customerID, period)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+
+	var invoice api.Invoice
+	err = json.Unmarshal(result, &invoice)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to unmarshal result: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, invoice)
+}
+
+// SetPaymentAccount makes the identity of the application the token account the customer pays from
+func (Server) SetPaymentAccount(c *gin.Context, customerID string) {
+	contract := newCustomerContract(c)
+
+	fmt.Printf("\n--> Submit Transaction: SetPaymentAccount, function sets the token account of the customer\n")
+	result, err := transactions.Submit(c.Request.Context(), contract, "SetPaymentAccount", customerID)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, api.PaymentAccount{PaymentAccount: string(result)})
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+const (
+	// breachEventName is the chaincode event the SLA chaincode emits when a mower reports a breached SLA
+	breachEventName = "SLABreached"
+	// breachRelayRetryDelay is the time to wait before listening again after the relay failed
+	breachRelayRetryDelay = 10 * time.Second
+)
+
+// fabricCAAttributes is the certificate extension the Fabric CA puts the attributes of an enrolled identity in
+var fabricCAAttributes = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+// the role attribute job offers are checked against by the general contract chaincode
+const (
+	roleAttribute    = "role"
+	serviceOwnerRole = "serviceowner"
+)
+
+// Breach is a breached SLA as emitted by the SLA chaincode
+type Breach struct {
+	ID           string    `json:"ID"`
+	SLAID        string    `json:"SLAID"`
+	CustomerID   string    `json:"CustomerID"`
+	Reason       string    `json:"Reason"`
+	ServiceType  string    `json:"ServiceType"`
+	ServiceLevel string    `json:"ServiceLevel"`
+	Mower        string    `json:"Mower"`
+	Address      string    `json:"Address"`
+	Status       string    `json:"Status"`
+	ReportedAt   time.Time `json:"ReportedAt"`
+	Deadline     time.Time `json:"Deadline"`
+}
+
+// runBreachRelay listens for breached SLAs on the customer channel and publishes a job offer for each of them
+// on the technician channel, where service providers can take them. Writes to another channel are not possible
+// from chaincode, so the application relays them. The relay restarts after errors until ctx is done. The last relayed
+// breach is kept in the checkpoint file of the profile, so no breach is lost or relayed twice after a restart.
+func runBreachRelay(ctx context.Context) {
+	for {
+		err := relayBreaches(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		fmt.Println("Breach relay stopped, restarting: ", err)
+		time.Sleep(breachRelayRetryDelay)
+	}
+}
+
+func relayBreaches(ctx context.Context) error {
+	slaDeployment := appConfig.Chaincode(mowerChaincode)
+	jobDeployment := appConfig.Chaincode(jobChaincode)
+
+	checkpointer, err := client.NewFileCheckpointer(appConfig.BreachRelay.Checkpoint)
+	if err != nil {
+		return err
+	}
+	defer checkpointer.Close()
+
+	jobContract := fabricGateway.Contract(jobDeployment.Channel, jobDeployment.Name)
+
+	events, err := fabricGateway.Network(slaDeployment.Channel).ChaincodeEvents(ctx, slaDeployment.Name, client.WithCheckpoint(checkpointer))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Relaying SLA breaches from channel", slaDeployment.Channel, "to channel", jobDeployment.Channel)
+	for event := range events {
+		if event.EventName == breachEventName {
+			var breach Breach
+			err = json.Unmarshal(event.Payload, &breach)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal breach: %w", err)
+			}
+
+			// the checkpoint is only moved past the event once the offer exists, so a failed offer is retried
+			err = createJobOffer(ctx, jobContract, breach)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = checkpointer.CheckpointChaincodeEvent(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// createJobOffer publishes a job offer for the breach unless one has already been published.
+// The offer has the same ID as the breach, which makes relaying a breach more than once harmless. Offers are
+// created in the general contract, so they are retried when they conflict with jobs taken at the same time.
+func createJobOffer(ctx context.Context, contract *client.Contract, breach Breach) error {
+	exists, err := contract.EvaluateTransaction("JobOfferExists", breach.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check job offer %s: %w", breach.ID, err)
+	}
+	if string(exists) == "true" {
+		return nil
+	}
+
+	fmt.Printf("Creating job offer %s for %s breach of SLA %s\n", breach.ID, breach.Reason, breach.SLAID)
+	_, err = transactions.Submit(ctx, contract, "CreateJobOffer", breach.ID, breach.ServiceType, breach.ServiceLevel, breach.Mower, breach.Address, breach.Deadline.Format(time.RFC3339), breach.SLAID, breach.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to create job offer %s: %w", breach.ID, err)
+	}
+
+	return nil
+}
+
+// checkBreachRelayIdentity returns an error unless the certificate of the application identity was enrolled with
+// the service owner role, without which every job offer is refused and the relay would retry forever
+func checkBreachRelayIdentity(certPath string) error {
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the certificate of the breach relay: %w", err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return fmt.Errorf("%s is not a PEM certificate", certPath)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse the certificate of the breach relay: %w", err)
+	}
+
+	for _, extension := range certificate.Extensions {
+		if !extension.Id.Equal(fabricCAAttributes) {
+			continue
+		}
+		var attributes struct {
+			Attrs map[string]string `json:"attrs"`
+		}
+		err = json.Unmarshal(extension.Value, &attributes)
+		if err != nil {
+			return fmt.Errorf("invalid attributes in %s: %w", certPath, err)
+		}
+		if attributes.Attrs[roleAttribute] == serviceOwnerRole {
+			return nil
+		}
+	}
+	return fmt.Errorf("the breach relay needs an identity enrolled with %s=%s, which %s is not", roleAttribute, serviceOwnerRole, certPath)
+}
@@ -9,1081 +9,550 @@ package main
 import (
 	"bytes"
 	"context"
-	"crypto/x509"
 	"encoding/json"
-	"errors"
+	"flag"
 	"fmt"
+	"log"
 	"net/http"
 	"os"
-	"path"
-	"strconv"
-	"time"
+	"os/signal"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/hyperledger/fabric-gateway/pkg/client"
-	"github.com/hyperledger/fabric-gateway/pkg/identity"
-	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/status"
+	"github.com/nalle631/fabric-network/application/c2b-app/api"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/bulk"
+	"github.com/nalle631/fabric-network/application/shared/config"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/health"
+	"github.com/nalle631/fabric-network/application/shared/metrics"
+	"github.com/nalle631/fabric-network/application/shared/server"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
 )
 
-const (
-	mspID        = "Org1MSP"
-	cryptoPath   = "../../test-network/organizations/peerOrganizations/org1.example.com"
-	certPath     = cryptoPath + "/users/User1@org1.example.com/msp/signcerts/User1@org1.example.com-cert.pem"
-	keyPath      = cryptoPath + "/users/User1@org1.example.com/msp/keystore/"
-	tlsCertPath  = cryptoPath + "/peers/peer0.org1.example.com/tls/ca.crt"
-	peerEndpoint = "localhost:7051"
-	gatewayPeer  = "peer0.org1.example.com"
-)
-
-type CustomerParams struct {
-	CustomerID string `json:"CustomerID"`
-}
-
-type CreateSLAParams struct {
-	ServiceLevel      string  `json:"ServiceLevel"`
-	TargetGrassLength float32 `json:"TargetGrassLength"`
-	MaxGrassLength    float32 `json:"MaxGrassLength"`
-	MinGrassLength    float32 `json:"MinGrassLength"`
-}
-
-type UpdateServiceLevelParams struct {
-	CustomerID   string `json:"CustomerID"`
-	ServiceLevel string `json:"ServiceLevel"`
-}
-
-type UpdateTargetGrassLengthParams struct {
-	CustomerID        string  `json:"CustomerID"`
-	TargetGrassLength float32 `json:"TargetGrassLength"`
-}
-
-type UpdateGrassLengthIntervalParams struct {
-	CustomerID     string  `json:"CustomerID"`
-	MaxGrassLength float32 `json:"MaxGrassLength"`
-	MinGrassLength float32 `json:"MinGrassLength"`
-}
-
-type RemoveSLAParams struct {
-	CustomerID string `json:"CustomerID"`
-	SlaID      string `json:"slaID"`
-}
-
-type Customer struct {
-	ID   string `json:"ID"`
-	SLAs []SLA  `json:"SLAs"`
-}
-
-type SlaParams struct {
-	ServiceLevel      string  `json:"ServiceLevel"`
-	TargetGrassLength float32 `json:"TargetGrassLength"`
-	MaxGrassLength    float32 `json:"MaxGrassLength"`
-	MinGrassLength    float32 `json:"MinGrassLength"`
-}
-
-type UpdateSlaParams struct {
-	ServiceLevel      string  `json:"ServiceLevel"`
-	TargetGrassLength float32 `json:"TargetGrassLength"`
-	MaxGrassLength    float32 `json:"MaxGrassLength"`
-	MinGrassLength    float32 `json:"MinGrassLength"`
-}
-
-type SLA struct {
-	AppraisedValue int `json:"AppraisedValue,omitempty"`
-	SlaParams
-	ID string `json:"ID"`
-}
+// fabricGateway is the gateway connection shared by every handler and the breach relay
+var fabricGateway *fabric.Gateway
 
 func main() {
-	router := CreateRouter()
-	StartRouter(router)
+	configPath := flag.String("config", "", "configuration file, "+config.DefaultPath+" if it exists")
+	profile := flag.String("profile", "", "profile of the configuration file to run as")
+	flag.Parse()
 
-}
-
-// newGrpcConnection creates a gRPC connection to the Gateway server.
-func newGrpcConnection() *grpc.ClientConn {
-	certificate, err := loadCertificate(tlsCertPath)
+	var err error
+	appConfig, err = config.Load(*configPath, *profile, defaultConfig())
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
 
-	certPool := x509.NewCertPool()
-	certPool.AddCert(certificate)
-	transportCredentials := credentials.NewClientTLSFromCert(certPool, gatewayPeer)
-
-	connection, err := grpc.Dial(peerEndpoint, grpc.WithTransportCredentials(transportCredentials))
+	appMetrics = metrics.New()
+	gatewayConfig := appConfig.Gateway()
+	gatewayConfig.DialOptions = appMetrics.DialOptions()
+	fabricGateway, err = fabric.Connect(gatewayConfig)
 	if err != nil {
-		panic(fmt.Errorf("failed to create gRPC connection: %w", err))
+		log.Fatal(err)
 	}
 
-	return connection
-}
-
-// newIdentity creates a client identity for this Gateway connection using an X.509 certificate.
-func newIdentity() *identity.X509Identity {
-	certificate, err := loadCertificate(certPath)
+	authGuard, err = appConfig.Guard(fabricGateway)
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
-
-	id, err := identity.NewX509Identity(mspID, certificate)
-	if err != nil {
-		panic(err)
+	if authGuard == nil {
+		log.Printf("authentication is disabled, every request transacts as %s", appConfig.Identity.MSPID)
 	}
+	trackerOptions := appConfig.TrackerOptions()
+	trackerOptions.Observer = appMetrics
+	transactions = transaction.NewTracker(trackerOptions)
+	appMetrics.WatchGateway(fabricGateway)
+	appMetrics.WatchTracker(transactions)
+	readiness = appConfig.Readiness(fabricGateway)
+	importJobs = bulk.NewJobs()
 
-	return id
-}
-
-func loadCertificate(filename string) (*x509.Certificate, error) {
-	certificatePEM, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read certificate file: %w", err)
-	}
-	return identity.CertificateFromPEM(certificatePEM)
-}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-// newSign creates a function that generates a digital signature from a message digest using a private key.
-func newSign() identity.Sign {
-	files, err := os.ReadDir(keyPath)
-	if err != nil {
-		panic(fmt.Errorf("failed to read private key directory: %w", err))
+	if appConfig.BreachRelay.Enabled {
+		err = checkBreachRelayIdentity(appConfig.Identity.CertPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		go runBreachRelay(ctx)
 	}
-	privateKeyPEM, err := os.ReadFile(path.Join(keyPath, files[0].Name()))
 
-	if err != nil {
-		panic(fmt.Errorf("failed to read private key file: %w", err))
-	}
+	router := CreateRouter()
+	StartRouter(ctx, router)
 
-	privateKey, err := identity.PrivateKeyFromPEM(privateKeyPEM)
-	if err != nil {
-		panic(err)
-	}
+}
 
-	sign, err := identity.NewPrivateKeySign(privateKey)
+// StartRouter serves the router until the context is done, then lets the requests in flight finish before the
+// running imports stop, the commits of asynchronous transactions stop being followed and the shared gateway
+// connection is closed
+func StartRouter(ctx context.Context, r *gin.Engine) {
+	err := server.Run(ctx, appConfig.Server.Address, r, server.DefaultShutdownTimeout, importJobs.Close, transactions.Close, func() {
+		if err := fabricGateway.Close(); err != nil {
+			log.Printf("failed to close the gateway: %v", err)
+		}
+	})
 	if err != nil {
-		panic(err)
+		log.Fatal(err)
 	}
-
-	return sign
 }
 
-func StartRouter(r *gin.Engine) {
-	r.Run(":5001")
-	// srv := &http.Server{
-	// 	Addr:    ":8080", // Set port number
-	// 	Handler: r,
-	// }
+// Server implements the operations of openapi.yaml. The routes generated from the spec bind the path
+// parameters and check the roles of the operation before they call it.
+type Server struct{}
 
-	// err := srv.ListenAndServeTLS(certPath, keyPath)
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
-	// r.RunTLS(":8080", tlsCertPath, tlsKeyPath)
-}
+var _ api.ServerInterface = Server{}
 
+// CreateRouter registers the operations of the spec behind authentication. The spec itself is served at
+// /openapi.json, the liveness and readiness probes at /healthz and /readyz, the Prometheus metrics at /metrics
+// and how often transactions were retried on read conflicts at /metrics/retries, registered before the
+// authentication so they can be fetched without a token. Requests with Prefer: respond-async are answered
+// with 202 once their transaction is ordered, see GetTransaction.
 func CreateRouter() *gin.Engine {
 	r := gin.Default()
-
-	r.GET("/contract/:id", ReadCustomerHandler)
-	r.GET("/sla/:id", ReadSLAHandler)
-	r.GET("/sla/:id/servicelevel", GetServiceLevelHandler)
-	r.POST("/contract", CreateCustomerHandler)
-	r.POST(":customer_id/sla", CreateSLAHandler)
-	r.PUT(":customer_id/sla/:id", updateSLAHandler)
-	r.PUT("/sla/:id/grasslength", updateTargetGrassLengthHandler)
-	r.PUT("/sla/:id/intervall", updateGrassLengthIntervalHandler)
-	r.PUT("sla/:id/servicelevel", updateServiceLevelHandler)
-	r.POST("/sla/evaluate", evaluateSLAHandler)
-	r.DELETE("/sla/:id", removeSLAHandler)
+	r.Use(appMetrics.Middleware())
+	r.GET("/openapi.json", serveSpec)
+	r.GET("/healthz", health.Live)
+	r.GET("/readyz", readiness.Ready)
+	r.GET("/metrics", appMetrics.Handler())
+	r.GET("/metrics/retries", transactions.RespondRetryCounts)
+
+	r.Use(authGuard.Authenticate(), transactions.Middleware())
+	api.RegisterHandlersWithOptions(r, Server{}, api.GinServerOptions{
+		Middlewares:  []api.MiddlewareFunc{authGuard.RequireScopes(api.BearerAuthScopes)},
+		ErrorHandler: apierror.ParameterError,
+	})
 	return r
 }
 
-func createCustomer(contract *client.Contract, customerID string) {
-	fmt.Printf("\n--> Submit Transaction: createCustomer, function creates a key value pair on the ledger \n")
-
-	_, err := contract.SubmitTransaction("CreateCustomer", customerID)
+// serveSpec responds with the OpenAPI spec the routes are generated from
+func serveSpec(c *gin.Context) {
+	spec, err := api.GetSwagger()
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
+		apierror.Respond(c, err)
+		return
 	}
-
-	fmt.Printf("*** Transaction committed successfully\n")
+	c.JSON(http.StatusOK, spec)
 }
 
-func CreateCustomerHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
-	if err != nil {
-		panic(err)
-	}
+// GetTransaction reports whether a transaction that was submitted asynchronously has committed, in which
+// block and with which validation code. Only the user who submitted the transaction can see it.
+func (Server) GetTransaction(c *gin.Context, transactionID string) {
+	transactions.RespondStatus(c, transactionID)
+}
 
-	defer gw.Close()
+func createCustomer(ctx context.Context, contract *client.Contract, customerID string) error {
+	fmt.Printf("\n--> Submit Transaction: createCustomer, function creates a key value pair on the ledger \n")
 
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "customer"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	_, err := transactions.Submit(ctx, contract, "CreateCustomer", customerID)
+	if err != nil {
+		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
-	}
+	fmt.Printf("*** Transaction committed successfully\n")
+	return nil
+}
 
-	network := gw.GetNetwork(channelName)
+func (Server) CreateCustomer(c *gin.Context) {
+	contract := newCustomerContract(c)
 
-	contract := network.GetContract(chaincodeName)
-	var customerParams CustomerParams
+	var customerParams api.CustomerParams
 	if err := c.BindJSON(&customerParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	createCustomer(contract, customerParams.CustomerID)
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "Customer created successfully"})
+	err := createCustomer(c.Request.Context(), contract, customerParams.CustomerID)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "Customer created successfully"})
 }
 
-func createSLA(contract *client.Contract, customerID string, serviceLevel string, targetgrasslength float32, maxgrasslength float32, mingrasslength float32) (*SLA, error) {
+// createSLA creates the SLA for the customer. With an idempotency key the SLA ID is derived from the key, so a
+// retry proposes the same SLA and the chaincode returns the SLA of the first request with the key in the record.
+func createSLA(ctx context.Context, contract *client.Contract, customerID string, slaParams api.CreateSLAParams, idempotencyKey string) (*api.SLA, *transaction.Record, error) {
 	fmt.Println("\n--> Submit Transaction: createSLA")
 	newUUID := uuid.New()
+	if idempotencyKey != "" {
+		newUUID = uuid.NewSHA1(uuid.NameSpaceURL, []byte("sla:"+customerID+":"+idempotencyKey))
+	}
 	newUUIDString := newUUID.String()
 	fmt.Println("UUID generated: ", newUUID)
 	fmt.Println("UUID string: ", newUUIDString)
-	targetgrasslength_string := fmt.Sprintf("%f", targetgrasslength)
-	maxgrasslength_string := fmt.Sprintf("%f", maxgrasslength)
-	mingrasslength_string := fmt.Sprintf("%f", mingrasslength)
 
-	createResult, err := contract.SubmitTransaction("CreateSLA", customerID, newUUIDString, serviceLevel, targetgrasslength_string, maxgrasslength_string, mingrasslength_string)
-
-	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
+	var createResult []byte
+	var record *transaction.Record
+	var err error
+	if slaParams.Parameters != nil || slaParams.PropertyID != "" {
+		// SLAs given with a parameter set can be of any service type, mowing is the default
+		serviceType := slaParams.ServiceType
+		if serviceType == "" {
+			serviceType = "mowing"
 		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
+		parameters := slaParams.Parameters
+		if parameters == nil {
+			parameters = map[string]decimal.Decimal{
+				"TargetGrassLength": slaParams.TargetGrassLength,
+				"MaxGrassLength":    slaParams.MaxGrassLength,
+				"MinGrassLength":    slaParams.MinGrassLength,
 			}
 		}
-		return nil, err
+		parametersJSON, marshalErr := json.Marshal(parameters)
+		if marshalErr != nil {
+			return nil, nil, marshalErr
+		}
+		createResult, record, err = transactions.SubmitIdempotent(ctx, contract, "CreateServiceSLA", idempotencyKey, customerID, newUUIDString, slaParams.PropertyID, serviceType, slaParams.ServiceLevel, string(parametersJSON))
+	} else {
+		targetgrasslength_string := slaParams.TargetGrassLength.String()
+		maxgrasslength_string := slaParams.MaxGrassLength.String()
+		mingrasslength_string := slaParams.MinGrassLength.String()
+		createResult, record, err = transactions.SubmitIdempotent(ctx, contract, "CreateSLA", idempotencyKey, customerID, newUUIDString, slaParams.ServiceLevel, targetgrasslength_string, maxgrasslength_string, mingrasslength_string)
 	}
 
-	var sla SLA
-	json.Unmarshal(createResult, &sla)
-	fmt.Println("Result: ", string(createResult[:]))
-	return &sla, nil
-}
-
-func CreateSLAHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
 	if err != nil {
-		panic(err)
+		return nil, nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "customer"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
-	}
-
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	fmt.Println("Result: ", string(createResult[:]))
+	var sla api.SLA
+	err = json.Unmarshal(createResult, &sla)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
+	return &sla, record, nil
+}
 
-	network := gw.GetNetwork(channelName)
+func (Server) CreateSLA(c *gin.Context, customerID string) {
+	contract := newCustomerContract(c)
 
-	contract := network.GetContract(chaincodeName)
-	var slaParams CreateSLAParams
-	customerID := c.Param("customer_id")
+	var slaParams api.CreateSLAParams
 	if err := c.BindJSON(&slaParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	sla, err := createSLA(contract, customerID, slaParams.ServiceLevel, slaParams.TargetGrassLength, slaParams.MaxGrassLength, slaParams.MinGrassLength)
-
+	idempotencyKey, err := transaction.IdempotencyKey(c)
 	if err != nil {
-		c.JSON(501, gin.H{"error": err.Error()})
+		apierror.Respond(c, err)
 		return
 	}
-	c.IndentedJSON(http.StatusOK, sla.ID)
-}
+	sla, record, err := createSLA(c.Request.Context(), contract, customerID, slaParams, idempotencyKey)
 
-func updateSLAHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
 	if err != nil {
-		panic(err)
-	}
-
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "customer"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+		apierror.Respond(c, err)
+		return
 	}
-
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	if record != nil {
+		record.SetHeaders(c)
 	}
+	c.IndentedJSON(http.StatusOK, sla.ID)
+}
 
-	network := gw.GetNetwork(channelName)
+func (Server) UpdateSLA(c *gin.Context, customerID string, slaID string) {
+	contract := newCustomerContract(c)
 
-	contract := network.GetContract(chaincodeName)
-	var slaParams UpdateSlaParams
-	customerID := c.Param("customer_id")
-	slaID := c.Param("id")
+	var slaParams api.UpdateSlaParams
 	if err := c.BindJSON(&slaParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
 
-	updateTargetGrassLength(contract, customerID, slaID, slaParams.TargetGrassLength)
-	updateGrassLengthInterval(contract, customerID, slaID, slaParams.MaxGrassLength, slaParams.MinGrassLength)
-	err = updateServiceLevel(contract, customerID, slaID, slaParams.ServiceLevel)
+	sla, err := updateSLA(c.Request.Context(), contract, customerID, slaID, slaParams)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, err)
 		return
 	}
-	c.IndentedJSON(http.StatusOK, gin.H{"status": "ok"})
+	c.IndentedJSON(http.StatusOK, sla)
 
 }
 
-func updateServiceLevel(contract *client.Contract, customerID string, slaID string, serviceLevel string) error {
-	fmt.Println("\n--> Submit Transaction: updateServiceLevel")
+// updateSLA applies every change to the SLA in a single transaction, so either all of them or none are made
+func updateSLA(ctx context.Context, contract *client.Contract, customerID string, slaID string, slaParams api.UpdateSlaParams) (*api.SLA, error) {
+	fmt.Printf("\n--> Submit Transaction: UpdateSLA, function updates the service level and parameters of an SLA\n")
 
-	_, err := contract.SubmitTransaction("UpdateServiceLevel", customerID, slaID, serviceLevel)
+	parameters := map[string]decimal.Decimal{}
+	for name, value := range slaParams.Parameters {
+		parameters[name] = value
+	}
+	// the grass lengths of mowing SLAs can still be given as separate fields
+	if !slaParams.TargetGrassLength.IsZero() {
+		parameters["TargetGrassLength"] = slaParams.TargetGrassLength
+	}
+	if !slaParams.MaxGrassLength.IsZero() {
+		parameters["MaxGrassLength"] = slaParams.MaxGrassLength
+	}
+	if !slaParams.MinGrassLength.IsZero() {
+		parameters["MinGrassLength"] = slaParams.MinGrassLength
+	}
 
+	parametersJSON, err := json.Marshal(parameters)
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			return fmt.Errorf("unexpected error type %T: %w", err, err)
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
-		return err
+		return nil, err
 	}
-	return nil
-}
 
-func updateServiceLevelHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
+	submitResult, err := transactions.Submit(ctx, contract, "UpdateSLA", customerID, slaID, slaParams.ServiceLevel, string(parametersJSON))
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "customer"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	var sla api.SLA
+	err = json.Unmarshal(submitResult, &sla)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
+	return &sla, nil
+}
 
-	// chaincodeName2 := "bumpy"
+func updateServiceLevel(ctx context.Context, contract *client.Contract, customerID string, slaID string, serviceLevel string) error {
+	fmt.Println("\n--> Submit Transaction: updateServiceLevel")
 
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	_, err := transactions.Submit(ctx, contract, "UpdateServiceLevel", customerID, slaID, serviceLevel)
+	if err != nil {
+		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
+	return nil
+}
 
-	network := gw.GetNetwork(channelName)
+func (Server) UpdateServiceLevel(c *gin.Context, slaID string) {
+	contract := newCustomerContract(c)
 
-	contract := network.GetContract(chaincodeName)
-	slaID := c.Param("id")
-	var updateServiceLevelParams UpdateServiceLevelParams
+	var updateServiceLevelParams api.UpdateServiceLevelParams
 	if err := c.BindJSON(&updateServiceLevelParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	err = updateServiceLevel(contract, updateServiceLevelParams.CustomerID, slaID, updateServiceLevelParams.ServiceLevel)
+	err := updateServiceLevel(c.Request.Context(), contract, updateServiceLevelParams.CustomerID, slaID, updateServiceLevelParams.ServiceLevel)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, err)
 		return
 	}
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "Service level updated successfully"})
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "Service level updated successfully"})
 }
 
 // Submit a transaction to query ledger state.
-func updateTargetGrassLength(contract *client.Contract, customerID string, slaID string, targetgrasslength float32) {
-	fmt.Println("\n--> Submit Transaction: updateTargetGrassLength \n")
-	fmt.Println(targetgrasslength)
-	targetgrasslength_string := fmt.Sprintf("%f", targetgrasslength)
-	fmt.Println(targetgrasslength_string)
-
-	submitResult, err := contract.SubmitTransaction("UpdateTargetGrassLength", customerID, slaID, targetgrasslength_string)
+func updateTargetGrassLength(ctx context.Context, contract *client.Contract, customerID string, slaID string, targetgrasslength decimal.Decimal) error {
+	fmt.Println("\n--> Submit Transaction: updateTargetGrassLength")
+	targetgrasslength_string := targetgrasslength.String()
 
+	submitResult, err := transactions.Submit(ctx, contract, "UpdateTargetGrassLength", customerID, slaID, targetgrasslength_string)
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
+		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	fmt.Println("Result:", submitResult)
+	fmt.Println("Result:", string(submitResult))
+	return nil
 }
 
-func updateTargetGrassLengthHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "customer"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
-	}
-
-	// chaincodeName2 := "bumpy"
+func (Server) UpdateTargetGrassLength(c *gin.Context, slaID string) {
+	contract := newCustomerContract(c)
 
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
-	}
-
-	network := gw.GetNetwork(channelName)
-
-	contract := network.GetContract(chaincodeName)
-	slaID := c.Param("id")
-	var updateTargetGrassLengthParams UpdateTargetGrassLengthParams
+	var updateTargetGrassLengthParams api.UpdateTargetGrassLengthParams
 	if err := c.BindJSON(&updateTargetGrassLengthParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	updateTargetGrassLength(contract, updateTargetGrassLengthParams.CustomerID, slaID, updateTargetGrassLengthParams.TargetGrassLength)
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "TargetGrassLength updated successfully"})
-}
-
-func updateGrassLengthInterval(contract *client.Contract, customerID string, slaID string, maxgrasslength float32, mingrasslength float32) {
-	fmt.Println("\n--> Submit Transaction: updateGrassLengthInterval \n")
-
-	maxgrasslength_string := fmt.Sprintf("%f", maxgrasslength)
-	mingrasslength_string := fmt.Sprintf("%f", mingrasslength)
-	submitResult, err := contract.SubmitTransaction("UpdateGrassLengthInterval", customerID, slaID, maxgrasslength_string, mingrasslength_string)
+	err := updateTargetGrassLength(c.Request.Context(), contract, updateTargetGrassLengthParams.CustomerID, slaID, updateTargetGrassLengthParams.TargetGrassLength)
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
+		apierror.Respond(c, err)
+		return
 	}
-
-	fmt.Println("Result:", submitResult)
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "TargetGrassLength updated successfully"})
 }
 
-func updateGrassLengthIntervalHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
-	if err != nil {
-		panic(err)
-	}
-
-	defer gw.Close()
+func updateGrassLengthInterval(ctx context.Context, contract *client.Contract, customerID string, slaID string, maxgrasslength decimal.Decimal, mingrasslength decimal.Decimal) error {
+	fmt.Println("\n--> Submit Transaction: updateGrassLengthInterval")
 
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "customer"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	maxgrasslength_string := maxgrasslength.String()
+	mingrasslength_string := mingrasslength.String()
+	submitResult, err := transactions.Submit(ctx, contract, "UpdateGrassLengthInterval", customerID, slaID, maxgrasslength_string, mingrasslength_string)
+	if err != nil {
+		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
-	}
+	fmt.Println("Result:", string(submitResult))
+	return nil
+}
 
-	network := gw.GetNetwork(channelName)
+func (Server) UpdateGrassLengthInterval(c *gin.Context, slaID string) {
+	contract := newCustomerContract(c)
 
-	contract := network.GetContract(chaincodeName)
-	slaID := c.Param("id")
-	var updateGrassLengthIntervalParams UpdateGrassLengthIntervalParams
+	var updateGrassLengthIntervalParams api.UpdateGrassLengthIntervalParams
 	if err := c.BindJSON(&updateGrassLengthIntervalParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	updateGrassLengthInterval(contract, updateGrassLengthIntervalParams.CustomerID, slaID, updateGrassLengthIntervalParams.MaxGrassLength, updateGrassLengthIntervalParams.MinGrassLength)
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "GrassLengthInterval updated successfully"})
-}
-
-func removeSLA(contract *client.Contract, customerID string, slaID string) {
-	fmt.Println("\n--> Submit Transaction: updateGrassLengthInterval \n")
-
-	submitResult, err := contract.SubmitTransaction("RemoveSLA", customerID, slaID)
+	err := updateGrassLengthInterval(c.Request.Context(), contract, updateGrassLengthIntervalParams.CustomerID, slaID, updateGrassLengthIntervalParams.MaxGrassLength, updateGrassLengthIntervalParams.MinGrassLength)
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
+		apierror.Respond(c, err)
+		return
 	}
-
-	fmt.Println("Result:", submitResult)
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "GrassLengthInterval updated successfully"})
 }
 
-func removeSLAHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
-	if err != nil {
-		panic(err)
-	}
+func removeSLA(ctx context.Context, contract *client.Contract, customerID string, slaID string) error {
+	fmt.Println("\n--> Submit Transaction: removeSLA")
 
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "customer"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	submitResult, err := transactions.Submit(ctx, contract, "RemoveSLA", customerID, slaID)
+	if err != nil {
+		return fmt.Errorf("failed to submit transaction: %w", err)
 	}
 
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
-	}
+	fmt.Println("Result:", string(submitResult))
+	return nil
+}
 
-	network := gw.GetNetwork(channelName)
+// RemoveSLA removes the SLA given in the body, which names its customer
+func (Server) RemoveSLA(c *gin.Context, _ string) {
+	contract := newCustomerContract(c)
 
-	contract := network.GetContract(chaincodeName)
-	var removeSLAParams RemoveSLAParams
+	var removeSLAParams api.RemoveSLAParams
 	if err := c.BindJSON(&removeSLAParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
-	removeSLA(contract, removeSLAParams.CustomerID, removeSLAParams.SlaID)
-	c.IndentedJSON(http.StatusOK, gin.H{"message": "SLA removed successfully"})
+	err := removeSLA(c.Request.Context(), contract, removeSLAParams.CustomerID, removeSLAParams.SlaID)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "SLA removed successfully"})
 }
 
-func evaluateSLA(contract *client.Contract, sla SlaParams) (int, error) {
-	fmt.Printf("\n--> Evaluate Transaction: EvaluateSLA, function returns evaluation of an SLA\n")
-	maxgrasslength_string := fmt.Sprintf("%f", sla.MaxGrassLength)
-	mingrasslength_string := fmt.Sprintf("%f", sla.MinGrassLength)
-	targetgrasslength_string := fmt.Sprintf("%f", sla.TargetGrassLength)
-	evaluateResult, err := contract.EvaluateTransaction("EvaluateSLA", sla.ServiceLevel, targetgrasslength_string, maxgrasslength_string, mingrasslength_string)
-	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
+func evaluateSLA(contract *client.Contract, sla api.SlaParams) (*api.PriceSchedule, error) {
+	fmt.Printf("\n--> Evaluate Transaction: EvaluateSLA, function returns the price schedule of an SLA\n")
+	var evaluateResult []byte
+	var err error
+	if sla.Parameters != nil || sla.Seasons != nil {
+		serviceType := sla.ServiceType
+		if serviceType == "" {
+			serviceType = "mowing"
 		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
+		parameters := sla.Parameters
+		if parameters == nil {
+			parameters = map[string]decimal.Decimal{
+				"TargetGrassLength": sla.TargetGrassLength,
+				"MaxGrassLength":    sla.MaxGrassLength,
+				"MinGrassLength":    sla.MinGrassLength,
 			}
 		}
-		return 0, err
+		seasons := sla.Seasons
+		if seasons == nil {
+			seasons = []api.Season{}
+		}
+		parametersJSON, marshalErr := json.Marshal(parameters)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		seasonsJSON, marshalErr := json.Marshal(seasons)
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		evaluateResult, err = contract.EvaluateTransaction("EvaluateServiceSLA", serviceType, sla.ServiceLevel, string(parametersJSON), string(seasonsJSON))
+	} else {
+		maxgrasslength_string := sla.MaxGrassLength.String()
+		mingrasslength_string := sla.MinGrassLength.String()
+		targetgrasslength_string := sla.TargetGrassLength.String()
+		evaluateResult, err = contract.EvaluateTransaction("EvaluateSLA", sla.ServiceLevel, targetgrasslength_string, maxgrasslength_string, mingrasslength_string)
 	}
-	evaluateResult_string := string(evaluateResult[:])
-
-	return strconv.Atoi(evaluateResult_string)
-}
-
-func evaluateSLAHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
 	if err != nil {
-		panic(err)
-	}
-
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "mower"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
 	}
 
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	var schedule api.PriceSchedule
+	err = json.Unmarshal(evaluateResult, &schedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
+	return &schedule, nil
+}
 
-	network := gw.GetNetwork(channelName)
-	contract := network.GetContract(chaincodeName)
+func (Server) EvaluateSLA(c *gin.Context) {
+	contract := newMowerContract(c)
 	// buf := new(strings.Builder)
 	// _, err = io.Copy(buf, c.Request.Body)
 	// if err != nil {
 	// 	fmt.Println("Error copying")
 	// }
 	// fmt.Println("Non indented recieved: ", buf.String())
-	var slaParams SlaParams
+	var slaParams api.SlaParams
 	if err := c.BindJSON(&slaParams); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, apierror.BadRequest(err))
 		return
 	}
 	fmt.Println("Json recieved: ", slaParams)
 	evaluatedValue, err := evaluateSLA(contract, slaParams)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, err)
 		return
 	}
 	c.IndentedJSON(http.StatusOK, evaluatedValue)
 
 }
 
-func readSLA(contract *client.Contract, slaID string) (*SLA, error) {
-	fmt.Printf("\n--> Evaluate Transaction: ReadSLA, function returns key value pair\n")
+func getServiceSchemas(contract *client.Contract) ([]api.ServiceSchema, error) {
+	fmt.Printf("\n--> Evaluate Transaction: GetServiceSchemas, function returns the parameters of every service type\n")
 
-	evaluateResult, err := contract.EvaluateTransaction("ReadSLA", slaID)
+	evaluateResult, err := contract.EvaluateTransaction("GetServiceSchemas")
 	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
-		return nil, err
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
 	}
 
-	var sla SLA
-	json.Unmarshal(evaluateResult, &sla)
-	fmt.Println("Result: ", string(evaluateResult[:]))
-	return &sla, nil
+	var schemas []api.ServiceSchema
+	err = json.Unmarshal(evaluateResult, &schemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return schemas, nil
 }
 
-func ReadSLAHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
+func (Server) GetServiceSchemas(c *gin.Context) {
+	contract := newMowerContract(c)
+	schemas, err := getServiceSchemas(contract)
 	if err != nil {
-		panic(err)
+		apierror.Respond(c, err)
+		return
 	}
+	c.IndentedJSON(http.StatusOK, schemas)
+}
 
-	defer gw.Close()
+func readSLA(contract *client.Contract, slaID string) (*api.SLA, error) {
+	fmt.Printf("\n--> Evaluate Transaction: ReadSLA, function returns key value pair\n")
 
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "mower"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
+	evaluateResult, err := contract.EvaluateTransaction("ReadSLA", slaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
 	}
 
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+	fmt.Println("Result: ", string(evaluateResult[:]))
+	var sla api.SLA
+	err = json.Unmarshal(evaluateResult, &sla)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
+	return &sla, nil
+}
 
-	network := gw.GetNetwork(channelName)
+func (Server) ReadSLA(c *gin.Context, slaID string) {
+	contract := newMowerContract(c)
 
-	slaID := c.Param("id")
-	contract := network.GetContract(chaincodeName)
 	sla, err := readSLA(contract, slaID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, err)
 		return
 	}
 	c.IndentedJSON(http.StatusOK, sla)
 
 }
 
-func GetServiceLevelHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
-	if err != nil {
-		panic(err)
-	}
+func (Server) GetServiceLevel(c *gin.Context, slaID string) {
+	contract := newMowerContract(c)
 
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "mower"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
-	}
-
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
-	}
-
-	network := gw.GetNetwork(channelName)
-
-	slaID := c.Param("id")
-	contract := network.GetContract(chaincodeName)
 	sla, err := readSLA(contract, slaID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, err)
 		return
 	}
 
@@ -1091,156 +560,34 @@ func GetServiceLevelHandler(c *gin.Context) {
 }
 
 // Evaluate a transaction by key to query ledger state.
-func readCustomer(contract *client.Contract, customerID string) (*Customer, error) {
+func readCustomer(contract *client.Contract, customerID string) (*api.Customer, error) {
 	fmt.Printf("\n--> Evaluate Transaction: Read, function returns key value pair\n")
 
 	evaluateResult, err := contract.EvaluateTransaction("ReadCustomer", customerID)
 	if err != nil {
-		return nil, (fmt.Errorf("failed to evaluate transaction: %w", err))
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
 	}
 
 	fmt.Println("Result: ", string(evaluateResult[:]))
-	var customer Customer
-	json.Unmarshal(evaluateResult, &customer)
-	return &customer, nil
-}
-
-func ReadCustomerHandler(c *gin.Context) {
-	clientConnection := newGrpcConnection()
-	defer clientConnection.Close()
-
-	id := newIdentity()
-	sign := newSign()
-
-	// Create a Gateway connection for a specific client identity
-	gw, err := client.Connect(
-		id,
-		client.WithSign(sign),
-		client.WithClientConnection(clientConnection),
-		// Default timeouts for different gRPC calls
-		client.WithEvaluateTimeout(5*time.Second),
-		client.WithEndorseTimeout(15*time.Second),
-		client.WithSubmitTimeout(5*time.Second),
-		client.WithCommitStatusTimeout(1*time.Minute),
-	)
+	var customer api.Customer
+	err = json.Unmarshal(evaluateResult, &customer)
 	if err != nil {
-		panic(err)
-	}
-
-	defer gw.Close()
-
-	// Override default values for chaincode and channel name as they may differ in testing contexts.
-	chaincodeName := "customer"
-	if ccname := os.Getenv("CHAINCODE_NAME"); ccname != "" {
-		chaincodeName = ccname
-	}
-
-	// chaincodeName2 := "bumpy"
-
-	channelName := "customer"
-	if cname := os.Getenv("CHANNEL_NAME"); cname != "" {
-		channelName = cname
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
 	}
+	return &customer, nil
+}
 
-	network := gw.GetNetwork(channelName)
+func (Server) ReadCustomer(c *gin.Context, customerID string) {
+	contract := newCustomerContract(c)
 
-	contract := network.GetContract(chaincodeName)
-	customerID := c.Param("id")
 	customer, err := readCustomer(contract, customerID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.Respond(c, err)
 		return
 	}
 	c.IndentedJSON(http.StatusOK, customer)
 }
 
-func getCustomerSLAs(contract *client.Contract, customerID string) {
-	fmt.Printf("\n--> Evaluate Transaction: getCustomerSLAs\n")
-
-	submitResult, err := contract.SubmitTransaction("GetAllSLA", customerID)
-	if err != nil {
-		switch err := err.(type) {
-		case *client.EndorseError:
-			fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.SubmitError:
-			fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		case *client.CommitStatusError:
-			if errors.Is(err, context.DeadlineExceeded) {
-				fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-			} else {
-				fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-			}
-		case *client.CommitError:
-			fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-		default:
-			panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-		}
-
-		// Any error that originates from a peer or orderer node external to the gateway will have its details
-		// embedded within the gRPC status error. The following code shows how to extract that.
-		statusErr := status.Convert(err)
-
-		details := statusErr.Details()
-		if len(details) > 0 {
-			fmt.Println("Error Details:")
-
-			for _, detail := range details {
-				switch detail := detail.(type) {
-				case *gateway.ErrorDetail:
-					fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-				}
-			}
-		}
-	}
-
-	fmt.Println("Result: ", string(submitResult[:]))
-}
-
-// Submit transaction, passing in the wrong number of arguments ,expected to throw an error containing details of any error responses from the smart contract.
-func exampleErrorHandling(contract *client.Contract) {
-	fmt.Println("\n--> Submit Transaction: UpdateAsset asset70, asset70 does not exist and should return an error")
-
-	_, err := contract.SubmitTransaction("UpdateAsset", "asset70", "blue", "5", "Tomoko", "300")
-	if err == nil {
-		panic("******** FAILED to return an error")
-	}
-
-	fmt.Println("*** Successfully caught the error:")
-
-	switch err := err.(type) {
-	case *client.EndorseError:
-		fmt.Printf("Endorse error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-	case *client.SubmitError:
-		fmt.Printf("Submit error for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-	case *client.CommitStatusError:
-		if errors.Is(err, context.DeadlineExceeded) {
-			fmt.Printf("Timeout waiting for transaction %s commit status: %s", err.TransactionID, err)
-		} else {
-			fmt.Printf("Error obtaining commit status for transaction %s with gRPC status %v: %s\n", err.TransactionID, status.Code(err), err)
-		}
-	case *client.CommitError:
-		fmt.Printf("Transaction %s failed to commit with status %d: %s\n", err.TransactionID, int32(err.Code), err)
-	default:
-		panic(fmt.Errorf("unexpected error type %T: %w", err, err))
-	}
-
-	// Any error that originates from a peer or orderer node external to the gateway will have its details
-	// embedded within the gRPC status error. The following code shows how to extract that.
-	statusErr := status.Convert(err)
-
-	details := statusErr.Details()
-	if len(details) > 0 {
-		fmt.Println("Error Details:")
-
-		for _, detail := range details {
-			switch detail := detail.(type) {
-			case *gateway.ErrorDetail:
-				fmt.Printf("- address: %s, mspId: %s, message: %s\n", detail.Address, detail.MspId, detail.Message)
-			}
-		}
-	}
-}
-
 // Format JSON data
 func formatJSON(data []byte) string {
 	var prettyJSON bytes.Buffer
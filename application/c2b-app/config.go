@@ -0,0 +1,61 @@
+package main
+
+import (
+	"github.com/nalle631/fabric-network/application/shared/auth"
+	"github.com/nalle631/fabric-network/application/shared/config"
+	"github.com/nalle631/fabric-network/application/shared/health"
+	"github.com/nalle631/fabric-network/application/shared/metrics"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+)
+
+const (
+	// the identity of Org1 in the test network, used when no configuration file overrides it
+	cryptoPath = "../../test-network/organizations/peerOrganizations/org1.example.com"
+
+	// keys of the chaincodes in the configuration
+	customerChaincode = "customer"
+	mowerChaincode    = "mower"
+	jobChaincode      = "job"
+)
+
+// appConfig is the configuration of the profile the application runs as
+var appConfig *config.Config
+
+// authGuard authenticates the requests, nil when authentication is disabled
+var authGuard *auth.Guard
+
+// transactions submits the transactions of the handlers and follows those submitted asynchronously
+var transactions *transaction.Tracker
+
+// appMetrics are the Prometheus metrics of the application, served at /metrics
+var appMetrics *metrics.Metrics
+
+// readiness checks the gateway, chaincodes and certificates the application needs, served at /readyz
+var readiness *health.Checker
+
+// defaultConfig is the configuration the application runs with when no configuration file or environment
+// variable overrides it: User1 of Org1 in the test network, with the customer and mower chaincodes on the
+// customer channel and the general contract chaincode that breaches are relayed to on mychannel
+func defaultConfig() config.Config {
+	return config.Config{
+		Server: config.Server{Address: ":5001"},
+		Identity: config.Identity{
+			MSPID:    "Org1MSP",
+			CertPath: cryptoPath + "/users/User1@org1.example.com/msp/signcerts/User1@org1.example.com-cert.pem",
+			KeyPath:  cryptoPath + "/users/User1@org1.example.com/msp/keystore/",
+		},
+		Peer: config.Peer{
+			Endpoint: "localhost:7051",
+			TLS: config.TLS{
+				Enabled:      true,
+				CACertPath:   cryptoPath + "/peers/peer0.org1.example.com/tls/ca.crt",
+				HostOverride: "peer0.org1.example.com",
+			},
+		},
+		Chaincodes: map[string]config.Chaincode{
+			customerChaincode: {Channel: "customer", Name: "customer"},
+			mowerChaincode:    {Channel: "customer", Name: "mower"},
+			jobChaincode:      {Channel: "mychannel", Name: "gc"},
+		},
+	}
+}
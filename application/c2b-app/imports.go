@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/nalle631/fabric-network/application/c2b-app/api"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/bulk"
+)
+
+// maxImportSize is the largest body of an import, room for tens of thousands of rows
+const maxImportSize = 16 << 20
+
+// maxImportParallel is how many customers an import can create at once
+const maxImportParallel = 32
+
+// importJobs runs the bulk imports in the background and keeps their reports
+var importJobs *bulk.Jobs
+
+// StartImport reads the rows of the body in the format of its content type and imports them in the background,
+// signed by the user of the request
+func (Server) StartImport(c *gin.Context, params api.StartImportParams) {
+	format, ok := bulk.ContentTypes[c.ContentType()]
+	if !ok {
+		apierror.Respond(c, apierror.New(http.StatusUnsupportedMediaType, apierror.CodeInvalidRequest, "imports are text/csv or application/x-ndjson"))
+		return
+	}
+
+	id := uuid.NewString()
+	if params.Id != nil && *params.Id != "" {
+		id = *params.Id
+	}
+	if err := bulk.CheckID(id); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+	options := bulk.Options{}
+	if params.DryRun != nil {
+		options.DryRun = *params.DryRun
+	}
+	if params.Parallel != nil {
+		if *params.Parallel < 1 || *params.Parallel > maxImportParallel {
+			apierror.Respond(c, apierror.BadRequest(fmt.Errorf("parallel must be between 1 and %d", maxImportParallel)))
+			return
+		}
+		options.Parallel = *params.Parallel
+	}
+
+	rows, err := bulk.Parse(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize), format)
+	if err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+	if len(rows) == 0 {
+		apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "no rows to import"))
+		return
+	}
+
+	ledger := bulk.Chaincodes{Customer: newCustomerContract(c), Mower: newMowerContract(c), Transactions: transactions}
+	imp := bulk.New(id, rows, ledger, options)
+	if err := importJobs.Start(imp); err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.Header("Location", "/imports/"+id)
+	c.IndentedJSON(http.StatusAccepted, imp.Report())
+}
+
+// GetImport reports the progress of an import
+func (Server) GetImport(c *gin.Context, id string) {
+	report, err := importJobs.Report(id)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, report)
+}
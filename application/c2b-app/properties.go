@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/nalle631/fabric-network/application/c2b-app/api"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/auth"
+)
+
+// newCustomerContract returns the customer contract as the identity of the user of the request
+func newCustomerContract(c *gin.Context) *client.Contract {
+	chaincode := appConfig.Chaincode(customerChaincode)
+	return auth.GatewayFrom(c, fabricGateway).Contract(chaincode.Channel, chaincode.Name)
+}
+
+// groupSLAsByProperty groups the SLAs of the customer by the property they are attached to
+func groupSLAsByProperty(customer *api.Customer) api.CustomerProperties {
+	grouped := api.CustomerProperties{
+		Properties:     []api.PropertySLAs{},
+		UnassignedSLAs: []api.SLA{},
+	}
+
+	index := make(map[string]int)
+	for i, property := range customer.Properties {
+		index[property.ID] = i
+		grouped.Properties = append(grouped.Properties, api.PropertySLAs{Property: property, SLAs: []api.SLA{}})
+	}
+
+	for _, sla := range customer.SLAs {
+		i, ok := index[sla.PropertyID]
+		if !ok {
+			grouped.UnassignedSLAs = append(grouped.UnassignedSLAs, sla)
+			continue
+		}
+		grouped.Properties[i].SLAs = append(grouped.Properties[i].SLAs, sla)
+	}
+
+	return grouped
+}
+
+func (Server) GetProperties(c *gin.Context, customerID string) {
+	contract := newCustomerContract(c)
+
+	customer, err := readCustomer(contract, customerID)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, groupSLAsByProperty(customer))
+}
+
+func (Server) AddProperty(c *gin.Context, customerID string) {
+	contract := newCustomerContract(c)
+
+	var propertyParams api.PropertyParams
+	if err := c.BindJSON(&propertyParams); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+	if propertyParams.ID == "" {
+		propertyParams.ID = uuid.New().String()
+	}
+
+	fmt.Printf("\n--> Submit Transaction: AddProperty, function adds a property to the customer\n")
+	_, err := transactions.Submit(c.Request.Context(), contract, "AddProperty", customerID, propertyParams.ID, propertyParams.Address, propertyParams.LotSize.String(), propertyParams.Mower)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, propertyParams.ID)
+}
+
+func (Server) UpdateProperty(c *gin.Context, customerID string, propertyID string) {
+	contract := newCustomerContract(c)
+
+	var propertyParams api.PropertyParams
+	if err := c.BindJSON(&propertyParams); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+
+	fmt.Printf("\n--> Submit Transaction: UpdateProperty, function updates a property of the customer\n")
+	_, err := transactions.Submit(c.Request.Context(), contract, "UpdateProperty", customerID, propertyID, propertyParams.Address, propertyParams.LotSize.String(), propertyParams.Mower)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "Property updated successfully"})
+}
+
+func (Server) RemoveProperty(c *gin.Context, customerID string, propertyID string) {
+	contract := newCustomerContract(c)
+
+	fmt.Printf("\n--> Submit Transaction: RemoveProperty, function removes a property from the customer\n")
+	_, err := transactions.Submit(c.Request.Context(), contract, "RemoveProperty", customerID, propertyID)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "Property removed successfully"})
+}
+
+func (Server) AssignSLAToProperty(c *gin.Context, customerID string, slaID string) {
+	contract := newCustomerContract(c)
+
+	var assignParams api.AssignPropertyParams
+	if err := c.BindJSON(&assignParams); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+
+	fmt.Printf("\n--> Submit Transaction: AssignSLAToProperty, function attaches an SLA to a property\n")
+	_, err := transactions.Submit(c.Request.Context(), contract, "AssignSLAToProperty", customerID, slaID, assignParams.PropertyID)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, api.Message{Message: "SLA assigned to property successfully"})
+}
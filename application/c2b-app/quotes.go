@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/nalle631/fabric-network/application/c2b-app/api"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/auth"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// quoteRequest follows the QuoteRequest of the mower chaincode
+type quoteRequest struct {
+	Reference    string                     `json:"Reference,omitempty"`
+	ServiceType  string                     `json:"ServiceType,omitempty"`
+	ServiceLevel string                     `json:"ServiceLevel"`
+	Parameters   map[string]decimal.Decimal `json:"Parameters"`
+	Seasons      []api.Season               `json:"Seasons,omitempty"`
+}
+
+// newMowerContract returns the mower contract as the identity of the user of the request
+func newMowerContract(c *gin.Context) *client.Contract {
+	chaincode := appConfig.Chaincode(mowerChaincode)
+	return auth.GatewayFrom(c, fabricGateway).Contract(chaincode.Channel, chaincode.Name)
+}
+
+// quoteRequests expands the quote parameters to one request per configuration. Configurations without
+// parameters are mowing SLAs given by their grass lengths, and matrix configurations are referenced
+// by their service level, target grass length and interval.
+func quoteRequests(params api.QuotesParams) []quoteRequest {
+	requests := []quoteRequest{}
+	for _, configuration := range params.Configurations {
+		parameters := configuration.Parameters
+		if parameters == nil {
+			parameters = mowingQuoteParameters(configuration.TargetGrassLength, configuration.MaxGrassLength, configuration.MinGrassLength)
+		}
+		requests = append(requests, quoteRequest{
+			Reference:    configuration.Reference,
+			ServiceType:  configuration.ServiceType,
+			ServiceLevel: configuration.ServiceLevel,
+			Parameters:   parameters,
+			Seasons:      configuration.Seasons,
+		})
+	}
+
+	if params.Matrix != nil {
+		matrix := params.Matrix
+		for _, serviceLevel := range matrix.ServiceLevels {
+			for _, target := range matrix.TargetGrassLengths {
+				for _, interval := range matrix.Intervals {
+					requests = append(requests, quoteRequest{
+						Reference:    fmt.Sprintf("%s/%s/%s-%s", serviceLevel, target, interval.MinGrassLength, interval.MaxGrassLength),
+						ServiceLevel: serviceLevel,
+						Parameters:   mowingQuoteParameters(target, interval.MaxGrassLength, interval.MinGrassLength),
+						Seasons:      matrix.Seasons,
+					})
+				}
+			}
+		}
+	}
+
+	return requests
+}
+
+func mowingQuoteParameters(targetGrassLength decimal.Decimal, maxGrassLength decimal.Decimal, minGrassLength decimal.Decimal) map[string]decimal.Decimal {
+	return map[string]decimal.Decimal{
+		"TargetGrassLength": targetGrassLength,
+		"MaxGrassLength":    maxGrassLength,
+		"MinGrassLength":    minGrassLength,
+	}
+}
+
+func evaluateQuotes(contract *client.Contract, requests []quoteRequest) ([]api.Quote, error) {
+	requestsJSON, err := json.Marshal(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("\n--> Evaluate Transaction: EvaluateSLABatch, function returns a quote for every SLA configuration\n")
+	result, err := contract.EvaluateTransaction("EvaluateSLABatch", string(requestsJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate transaction: %w", err)
+	}
+
+	quotes := []api.Quote{}
+	err = json.Unmarshal(result, &quotes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return quotes, nil
+}
+
+// QuoteSLAs quotes many SLA configurations in a single evaluation, with a breakdown of the monthly cost of each
+func (Server) QuoteSLAs(c *gin.Context) {
+	var params api.QuotesParams
+	if err := c.BindJSON(&params); err != nil {
+		apierror.Respond(c, apierror.BadRequest(err))
+		return
+	}
+
+	requests := quoteRequests(params)
+	if len(requests) == 0 {
+		apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "no configurations to quote"))
+		return
+	}
+
+	contract := newMowerContract(c)
+
+	quotes, err := evaluateQuotes(contract, requests)
+	if err != nil {
+		apierror.Respond(c, err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, quotes)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nalle631/fabric-network/application/c2b-app/api"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+)
+
+// ReconcileCustomer repairs the customer's copies of its SLAs from the SLA chaincode and reports what was repaired
+func (Server) ReconcileCustomer(c *gin.Context, customerID string) {
+	contract := newCustomerContract(c)
+
+	fmt.Printf("\n--> Submit Transaction: ReconcileCustomer, function repairs the customer's copies of its SLAs\n")
+	result, err := transactions.Submit(c.Request.Context(), contract, "ReconcileCustomer", customerID)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", err))
+		return
+	}
+
+	var report api.ReconcileReport
+	err = json.Unmarshal(result, &report)
+	if err != nil {
+		apierror.Respond(c, fmt.Errorf("failed to unmarshal result: %w", err))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, report)
+}
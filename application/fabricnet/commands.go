@@ -0,0 +1,410 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// command is a subcommand of fabricnet. setup adds its flags to the flag set and returns the function that runs
+// it with its nargs arguments once the flags are parsed.
+type command struct {
+	group   string
+	name    string
+	args    string
+	summary string
+	nargs   int
+	setup   func(flags *flag.FlagSet) func(cli *cli, args []string) error
+}
+
+var commands = []command{
+	{"gc", "create", "", "create the general contract of the technician organisation", 0, gcCreate},
+	{"gc", "show", "", "show the general contract of the technician organisation", 0, gcShow},
+	{"job", "take", "<jobID>", "take the job for the technician organisation", 1, jobTake},
+	{"job", "done", "<jobID>", "report the job done, with the error the customer reported or --wrong-error", 1, jobDone},
+	{"job", "list", "", "list every job", 0, jobList},
+	{"job", "show", "<jobID>", "show the job of the technician organisation", 1, jobShow},
+	{"customer", "create", "<customerID>", "create the customer", 1, customerCreate},
+	{"customer", "show", "<customerID>", "show the customer", 1, customerShow},
+	{"customer", "import", "<file>", "import customers and SLAs from CSV or NDJSON, - reads standard input", 1, customerImport},
+	{"sla", "create", "<customerID>", "create an SLA for the customer", 1, slaCreate},
+	{"sla", "update", "<customerID> <slaID>", "change the service level and parameters of an SLA in one transaction", 2, slaUpdate},
+	{"sla", "evaluate", "", "evaluate the price of an SLA without creating it", 0, slaEvaluate},
+	{"sla", "show", "<slaID>", "show the SLA", 1, slaShow},
+	{"sla", "remove", "<customerID> <slaID>", "remove the SLA of the customer", 2, slaRemove},
+}
+
+func gcCreate(*flag.FlagSet) func(*cli, []string) error {
+	return func(cli *cli, _ []string) error {
+		contract, err := cli.contract(gcChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.submit(contract, "CreateGeneralContract")
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "general contract created")
+	}
+}
+
+func gcShow(*flag.FlagSet) func(*cli, []string) error {
+	return func(cli *cli, _ []string) error {
+		contract, err := cli.contract(gcChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.evaluate(contract, "ReadGeneralContract", cli.technicianID())
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "")
+	}
+}
+
+func jobTake(flags *flag.FlagSet) func(*cli, []string) error {
+	key := keyFlag(flags)
+	return func(cli *cli, args []string) error {
+		contract, err := cli.contract(gcChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.submitIdempotent(contract, "TakeJob", *key, args[0], cli.technicianID())
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "job "+args[0]+" taken")
+	}
+}
+
+func jobDone(flags *flag.FlagSet) func(*cli, []string) error {
+	key := keyFlag(flags)
+	wrongError := flags.Bool("wrong-error", false, "the error the technician found was not the one the customer reported")
+	return func(cli *cli, args []string) error {
+		contract, err := cli.contract(gcChaincode)
+		if err != nil {
+			return err
+		}
+		name := "JobDoneCorrectError"
+		if *wrongError {
+			name = "JobDoneWrongError"
+		}
+		result, err := cli.submitIdempotent(contract, name, *key, args[0])
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "job "+args[0]+" done")
+	}
+}
+
+func jobList(*flag.FlagSet) func(*cli, []string) error {
+	return func(cli *cli, _ []string) error {
+		contract, err := cli.contract(gcChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.evaluate(contract, "GetAllJobs")
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "no jobs")
+	}
+}
+
+func jobShow(*flag.FlagSet) func(*cli, []string) error {
+	return func(cli *cli, args []string) error {
+		contract, err := cli.contract(gcChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.evaluate(contract, "ReadJob", args[0], cli.technicianID())
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "")
+	}
+}
+
+func customerCreate(*flag.FlagSet) func(*cli, []string) error {
+	return func(cli *cli, args []string) error {
+		contract, err := cli.contract(customerChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.submit(contract, "CreateCustomer", args[0])
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "customer "+args[0]+" created")
+	}
+}
+
+func customerShow(*flag.FlagSet) func(*cli, []string) error {
+	return func(cli *cli, args []string) error {
+		contract, err := cli.contract(customerChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.evaluate(contract, "ReadCustomer", args[0])
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "")
+	}
+}
+
+// slaFlags are the flags that describe an SLA, as the fields of the SLA requests of the C2B application
+type slaFlags struct {
+	level       *string
+	serviceType *string
+	target      *string
+	max         *string
+	min         *string
+	parameters  parameters
+}
+
+func addSLAFlags(flags *flag.FlagSet) *slaFlags {
+	sla := &slaFlags{
+		level:       flags.String("level", "", "service level, such as Gold, Silver or Bronze"),
+		serviceType: flags.String("service-type", "", "service type of the SLA, mowing when parameters are given without one"),
+		target:      flags.String("target", "", "target grass length of a mowing SLA"),
+		max:         flags.String("max", "", "maximum grass length of a mowing SLA"),
+		min:         flags.String("min", "", "minimum grass length of a mowing SLA"),
+		parameters:  parameters{},
+	}
+	flags.Var(sla.parameters, "param", "parameter of the service type as name=value, can be repeated")
+	return sla
+}
+
+// serviceSLA tells whether the SLA is given by service type and parameters rather than by grass lengths only
+func (sla *slaFlags) serviceSLA() bool {
+	return *sla.serviceType != "" || len(sla.parameters) > 0
+}
+
+// lengths returns the target, maximum and minimum grass lengths, zero when they are not given
+func (sla *slaFlags) lengths() ([]decimal.Decimal, error) {
+	lengths := make([]decimal.Decimal, 3)
+	for i, setting := range []struct {
+		name  string
+		value string
+	}{{"target", *sla.target}, {"max", *sla.max}, {"min", *sla.min}} {
+		if setting.value == "" {
+			continue
+		}
+		length, err := decimal.Parse(setting.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for flag -%s: %w", setting.value, setting.name, err)
+		}
+		lengths[i] = length
+	}
+	return lengths, nil
+}
+
+// parametersJSON returns the parameters of a service SLA, the grass lengths when no parameter is given
+func (sla *slaFlags) parametersJSON() (string, error) {
+	parameters := map[string]decimal.Decimal(sla.parameters)
+	if len(parameters) == 0 {
+		lengths, err := sla.lengths()
+		if err != nil {
+			return "", err
+		}
+		parameters = map[string]decimal.Decimal{
+			"TargetGrassLength": lengths[0],
+			"MaxGrassLength":    lengths[1],
+			"MinGrassLength":    lengths[2],
+		}
+	}
+	parametersJSON, err := json.Marshal(parameters)
+	return string(parametersJSON), err
+}
+
+func (sla *slaFlags) serviceTypeOrDefault() string {
+	if *sla.serviceType == "" {
+		return "mowing"
+	}
+	return *sla.serviceType
+}
+
+func slaCreate(flags *flag.FlagSet) func(*cli, []string) error {
+	sla := addSLAFlags(flags)
+	propertyID := flags.String("property", "", "property the SLA covers")
+	key := keyFlag(flags)
+	return func(cli *cli, args []string) error {
+		if *sla.level == "" {
+			return fmt.Errorf("flag -level is required")
+		}
+		contract, err := cli.contract(customerChaincode)
+		if err != nil {
+			return err
+		}
+
+		// as in the C2B application, a key derives the SLA ID so a retry proposes the same SLA
+		customerID := args[0]
+		slaID := uuid.New()
+		if *key != "" {
+			slaID = uuid.NewSHA1(uuid.NameSpaceURL, []byte("sla:"+customerID+":"+*key))
+		}
+
+		var result []byte
+		if sla.serviceSLA() || *propertyID != "" {
+			parametersJSON, err := sla.parametersJSON()
+			if err != nil {
+				return err
+			}
+			result, err = cli.submitIdempotent(contract, "CreateServiceSLA", *key, customerID, slaID.String(), *propertyID, sla.serviceTypeOrDefault(), *sla.level, parametersJSON)
+			if err != nil {
+				return err
+			}
+		} else {
+			lengths, err := sla.lengths()
+			if err != nil {
+				return err
+			}
+			result, err = cli.submitIdempotent(contract, "CreateSLA", *key, customerID, slaID.String(), *sla.level, lengths[0].String(), lengths[1].String(), lengths[2].String())
+			if err != nil {
+				return err
+			}
+		}
+		return cli.print(result, "SLA "+slaID.String()+" created")
+	}
+}
+
+func slaUpdate(flags *flag.FlagSet) func(*cli, []string) error {
+	sla := addSLAFlags(flags)
+	return func(cli *cli, args []string) error {
+		if *sla.serviceType != "" {
+			return fmt.Errorf("the service type of an SLA cannot be changed")
+		}
+		contract, err := cli.contract(customerChaincode)
+		if err != nil {
+			return err
+		}
+
+		// only the grass lengths that are given are changed, as in the C2B application
+		parameters := map[string]decimal.Decimal{}
+		for name, value := range sla.parameters {
+			parameters[name] = value
+		}
+		lengths, err := sla.lengths()
+		if err != nil {
+			return err
+		}
+		for i, name := range []string{"TargetGrassLength", "MaxGrassLength", "MinGrassLength"} {
+			if !lengths[i].IsZero() {
+				parameters[name] = lengths[i]
+			}
+		}
+		parametersJSON, err := json.Marshal(parameters)
+		if err != nil {
+			return err
+		}
+
+		result, err := cli.submit(contract, "UpdateSLA", args[0], args[1], *sla.level, string(parametersJSON))
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "SLA "+args[1]+" updated")
+	}
+}
+
+func slaEvaluate(flags *flag.FlagSet) func(*cli, []string) error {
+	sla := addSLAFlags(flags)
+	return func(cli *cli, _ []string) error {
+		if *sla.level == "" {
+			return fmt.Errorf("flag -level is required")
+		}
+		contract, err := cli.contract(mowerChaincode)
+		if err != nil {
+			return err
+		}
+
+		var result []byte
+		if sla.serviceSLA() {
+			parametersJSON, err := sla.parametersJSON()
+			if err != nil {
+				return err
+			}
+			result, err = cli.evaluate(contract, "EvaluateServiceSLA", sla.serviceTypeOrDefault(), *sla.level, parametersJSON, "[]")
+			if err != nil {
+				return err
+			}
+		} else {
+			lengths, err := sla.lengths()
+			if err != nil {
+				return err
+			}
+			result, err = cli.evaluate(contract, "EvaluateSLA", *sla.level, lengths[0].String(), lengths[1].String(), lengths[2].String())
+			if err != nil {
+				return err
+			}
+		}
+		return cli.print(result, "")
+	}
+}
+
+func slaShow(*flag.FlagSet) func(*cli, []string) error {
+	return func(cli *cli, args []string) error {
+		contract, err := cli.contract(mowerChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.evaluate(contract, "ReadSLA", args[0])
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "")
+	}
+}
+
+func slaRemove(*flag.FlagSet) func(*cli, []string) error {
+	return func(cli *cli, args []string) error {
+		contract, err := cli.contract(customerChaincode)
+		if err != nil {
+			return err
+		}
+		result, err := cli.submit(contract, "RemoveSLA", args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return cli.print(result, "SLA "+args[1]+" removed")
+	}
+}
+
+// keyFlag adds the idempotency key flag of the commands whose transactions have idempotent variants
+func keyFlag(flags *flag.FlagSet) *string {
+	return flags.String("key", "", "idempotency key, a command run again with the same key does not change the ledger again")
+}
+
+// parameters are the repeated -param name=value flags
+type parameters map[string]decimal.Decimal
+
+func (p parameters) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + p[name].String()
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (p parameters) Set(pair string) error {
+	name, value, ok := strings.Cut(pair, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("%q is not name=value", pair)
+	}
+	number, err := decimal.Parse(value)
+	if err != nil {
+		return err
+	}
+	p[name] = number
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nalle631/fabric-network/application/shared/bulk"
+)
+
+// customerImport imports the rows of the file as the C2B application does at POST /imports, but waits for the
+// import and prints its report. With -dry-run the rows are only priced.
+func customerImport(flags *flag.FlagSet) func(*cli, []string) error {
+	id := flags.String("id", "", "ID of the import, an import run again with the same ID and rows resumes; a new one by default")
+	format := flags.String("format", "", "csv or ndjson, from the extension of the file by default")
+	parallel := flags.Int("parallel", bulk.DefaultParallel, "how many customers are imported at once")
+	return func(cli *cli, args []string) error {
+		if *id == "" {
+			*id = uuid.NewString()
+		}
+		if err := bulk.CheckID(*id); err != nil {
+			return err
+		}
+		if *parallel < 1 {
+			return fmt.Errorf("flag -parallel must be at least 1")
+		}
+
+		rows, err := readRows(args[0], *format)
+		if err != nil {
+			return err
+		}
+		customer, err := cli.contract(customerChaincode)
+		if err != nil {
+			return err
+		}
+		mower, err := cli.contract(mowerChaincode)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cli.stderr, "import %s of %d rows\n", *id, len(rows))
+		ledger := bulk.Chaincodes{Customer: customer, Mower: mower, Transactions: cli.transactions}
+		report := bulk.New(*id, rows, ledger, bulk.Options{Parallel: *parallel, DryRun: cli.dryRun}).Run(cli.ctx)
+
+		// the table has a line per row, the counts go to standard error
+		var result []byte
+		if cli.output == "table" {
+			result, err = json.Marshal(report.Rows)
+		} else {
+			result, err = json.Marshal(report)
+		}
+		if err != nil {
+			return err
+		}
+		if err := cli.print(result, ""); err != nil {
+			return err
+		}
+		fmt.Fprintf(cli.stderr, "import %s %s: %s\n", report.ImportID, report.Status, counts(report))
+
+		notImported := report.Counts[bulk.StatusPending] + report.Counts[bulk.StatusInvalid] + report.Counts[bulk.StatusFailed]
+		if notImported > 0 {
+			return fmt.Errorf("%d of %d rows were not imported, run the import again with -id %s to resume it", notImported, len(rows), report.ImportID)
+		}
+		return nil
+	}
+}
+
+// readRows reads the rows of the file, or of standard input for -, in the format or that of its extension
+func readRows(path string, format string) ([]bulk.Row, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			format = bulk.FormatCSV
+		case ".ndjson", ".jsonl":
+			format = bulk.FormatNDJSON
+		default:
+			return nil, fmt.Errorf("the format of %s is not known from its extension, use -format", path)
+		}
+	}
+
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+	return bulk.Parse(reader, format)
+}
+
+// counts lists how many rows have each status, in the order rows go through them
+func counts(report bulk.Report) string {
+	parts := []string{}
+	for _, status := range []string{bulk.StatusCreated, bulk.StatusReplayed, bulk.StatusValid, bulk.StatusPending, bulk.StatusInvalid, bulk.StatusFailed} {
+		if report.Counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", report.Counts[status], status))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,281 @@
+// Command fabricnet runs the B2B and C2B workflows from the command line. It connects to the gateway peer with
+// the identity of a profile in the configuration file of an application, the same file and FABRIC_ environment
+// variables the REST applications read, and calls the chaincodes the way their handlers do.
+//
+//	fabricnet [flags] <group> <command> [flags] [arguments]
+//
+// Results are printed as JSON or as a table. With --dry-run the transactions of the command are only evaluated,
+// so the chaincode runs on the gateway peer and returns its result without anything being committed.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/config"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+)
+
+const (
+	// the identity of Org1 in the test network, used when no configuration file overrides it
+	cryptoPath = "../../test-network/organizations/peerOrganizations/org1.example.com"
+
+	// keys of the chaincodes in the configuration, those of the B2B and C2B applications
+	gcChaincode       = "gc"
+	customerChaincode = "customer"
+	mowerChaincode    = "mower"
+)
+
+// errUsage is returned by commands called with the wrong arguments, after their usage has been printed
+var errUsage = errors.New("invalid usage")
+
+// defaultConfig is User1 of Org1 in the test network with the chaincodes of both applications. The CLI serves
+// nothing, the address is only set because every profile needs one.
+func defaultConfig() config.Config {
+	return config.Config{
+		Server: config.Server{Address: ":0"},
+		Identity: config.Identity{
+			MSPID:    "Org1MSP",
+			CertPath: cryptoPath + "/users/User1@org1.example.com/msp/signcerts/User1@org1.example.com-cert.pem",
+			KeyPath:  cryptoPath + "/users/User1@org1.example.com/msp/keystore/",
+		},
+		Peer: config.Peer{
+			Endpoint: "localhost:7051",
+			TLS: config.TLS{
+				Enabled:      true,
+				CACertPath:   cryptoPath + "/peers/peer0.org1.example.com/tls/ca.crt",
+				HostOverride: "peer0.org1.example.com",
+			},
+		},
+		Chaincodes: map[string]config.Chaincode{
+			gcChaincode:       {Channel: "mychannel", Name: "gc"},
+			customerChaincode: {Channel: "customer", Name: "customer"},
+			mowerChaincode:    {Channel: "customer", Name: "mower"},
+		},
+	}
+}
+
+// cli is the state of one run: the flags every command accepts and the gateway, connected on first use
+type cli struct {
+	configPath string
+	profile    string
+	output     string
+	dryRun     bool
+
+	ctx          context.Context
+	stdout       io.Writer
+	stderr       io.Writer
+	config       *config.Config
+	gateway      *fabric.Gateway
+	transactions *transaction.Tracker
+}
+
+// globalFlags adds the flags every command accepts to the flag set, so they can be given before or after the
+// command
+func (cli *cli) globalFlags(flags *flag.FlagSet) {
+	flags.StringVar(&cli.configPath, "config", cli.configPath, "configuration file of an application, FABRIC_CONFIG or config.yaml by default")
+	flags.StringVar(&cli.profile, "profile", cli.profile, "profile of the configuration file, FABRIC_PROFILE or its default profile by default")
+	flags.StringVar(&cli.output, "output", cli.output, "output format, json or table")
+	flags.BoolVar(&cli.dryRun, "dry-run", cli.dryRun, "only evaluate the transactions, nothing is committed")
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cli := &cli{output: "json", ctx: ctx, stdout: os.Stdout, stderr: os.Stderr}
+	err := cli.run(os.Args[1:])
+	cli.close()
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		cli.printError(err)
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags and runs the command the arguments name
+func (cli *cli) run(args []string) error {
+	flags := flag.NewFlagSet("fabricnet", flag.ContinueOnError)
+	flags.SetOutput(cli.stderr)
+	cli.globalFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(cli.stderr, "Usage: fabricnet [flags] <group> <command> [flags] [arguments]\n\nCommands:\n")
+		for _, command := range commands {
+			fmt.Fprintf(cli.stderr, "  %-16s %-22s %s\n", command.group+" "+command.name, command.args, command.summary)
+		}
+		fmt.Fprintf(cli.stderr, "\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	args = flags.Args()
+	if len(args) < 2 {
+		flags.Usage()
+		return errUsage
+	}
+	for _, command := range commands {
+		if command.group == args[0] && command.name == args[1] {
+			return cli.runCommand(command, args[2:])
+		}
+	}
+	flags.Usage()
+	return fmt.Errorf("unknown command %s", strings.Join(args[:2], " "))
+}
+
+// runCommand parses the flags of the command and runs it with its arguments
+func (cli *cli) runCommand(command command, args []string) error {
+	name := command.group + " " + command.name
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(cli.stderr)
+	cli.globalFlags(flags)
+	flags.Usage = func() {
+		fmt.Fprintf(cli.stderr, "Usage: fabricnet %s [flags] %s\n\n%s\n\nFlags:\n", name, command.args, command.summary)
+		flags.PrintDefaults()
+	}
+	run := command.setup(flags)
+
+	// the flag package stops at the first argument, parse again after each one so flags can follow arguments
+	var positional []string
+	for {
+		err := flags.Parse(args)
+		if err != nil {
+			return err
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+	if cli.output != "json" && cli.output != "table" {
+		return fmt.Errorf("unknown output format %q, use json or table", cli.output)
+	}
+	if len(positional) != command.nargs {
+		flags.Usage()
+		return errUsage
+	}
+	return run(cli, positional)
+}
+
+// connect loads the configuration and connects to the gateway peer the first time a command needs them
+func (cli *cli) connect() error {
+	if cli.gateway != nil {
+		return nil
+	}
+
+	var err error
+	cli.config, err = config.Load(cli.configPath, cli.profile, defaultConfig())
+	if err != nil {
+		return err
+	}
+	cli.gateway, err = fabric.Connect(cli.config.Gateway())
+	if err != nil {
+		return err
+	}
+	cli.transactions = transaction.NewTracker(cli.config.TrackerOptions())
+	return nil
+}
+
+// contract returns the chaincode with the key in the configuration
+func (cli *cli) contract(key string) (*client.Contract, error) {
+	err := cli.connect()
+	if err != nil {
+		return nil, err
+	}
+	chaincode, ok := cli.config.Chaincodes[key]
+	if !ok {
+		return nil, fmt.Errorf("chaincode %s is not configured, the profile cannot run this command", key)
+	}
+	return cli.gateway.Contract(chaincode.Channel, chaincode.Name), nil
+}
+
+// technicianID is the technician organisation the B2B commands act for, the MSP ID of the identity as in the
+// B2B application
+func (cli *cli) technicianID() string {
+	return cli.config.Identity.MSPID
+}
+
+// evaluate evaluates the transaction
+func (cli *cli) evaluate(contract *client.Contract, name string, args ...string) ([]byte, error) {
+	result, err := contract.EvaluateTransaction(name, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %s: %w", name, err)
+	}
+	return result, nil
+}
+
+// submit submits the transaction and waits for its commit, retrying read conflicts, or only evaluates it on
+// a dry run
+func (cli *cli) submit(contract *client.Contract, name string, args ...string) ([]byte, error) {
+	return cli.submitIdempotent(contract, name, "", args...)
+}
+
+// submitIdempotent submits the transaction as submit does, with an idempotency key as the REST applications do
+// when the request has an Idempotency-Key header. The transaction of the key is reported on standard error.
+func (cli *cli) submitIdempotent(contract *client.Contract, name string, key string, args ...string) ([]byte, error) {
+	if cli.dryRun {
+		fmt.Fprintf(cli.stderr, "dry run: %s was evaluated and not submitted\n", name)
+		return cli.evaluate(contract, name, args...)
+	}
+
+	result, record, err := cli.transactions.SubmitIdempotent(cli.ctx, contract, name, key, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit %s: %w", name, err)
+	}
+	if record != nil {
+		replayed := ""
+		if record.Replayed {
+			replayed = ", replayed for the idempotency key"
+		}
+		fmt.Fprintf(cli.stderr, "transaction %s%s\n", record.TxID, replayed)
+	}
+	return result, nil
+}
+
+// close stops following transactions and closes the gateway connection
+func (cli *cli) close() {
+	cli.transactions.Close()
+	if cli.gateway != nil {
+		cli.gateway.Close()
+	}
+}
+
+// printError prints the error with the messages of the peers that rejected the transaction, as the error body
+// of the REST applications in JSON output
+func (cli *cli) printError(err error) {
+	if errors.Is(err, errUsage) {
+		return
+	}
+
+	response := apierror.From(err)
+	if cli.output == "json" {
+		encoder := json.NewEncoder(cli.stderr)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(response)
+		return
+	}
+
+	fmt.Fprintln(cli.stderr, "fabricnet:", err)
+	if response.TransactionID != "" {
+		fmt.Fprintln(cli.stderr, "transaction:", response.TransactionID)
+	}
+	for _, detail := range response.Details {
+		fmt.Fprintf(cli.stderr, "  %s (%s): %s\n", detail.Address, detail.MSPID, detail.Message)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// print prints the result of a transaction in the output format. Results that are not JSON are printed as they
+// are, and an empty result as the message of the command, if it has one.
+func (cli *cli) print(result []byte, empty string) error {
+	result = bytes.TrimSpace(result)
+	if len(result) == 0 {
+		if empty != "" {
+			fmt.Fprintln(cli.stdout, empty)
+		}
+		return nil
+	}
+	if !json.Valid(result) {
+		fmt.Fprintln(cli.stdout, string(result))
+		return nil
+	}
+
+	if cli.output == "table" {
+		return cli.printTable(result)
+	}
+	var indented bytes.Buffer
+	err := json.Indent(&indented, result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cli.stdout, indented.String())
+	return nil
+}
+
+// printTable prints an array of objects with a column per field and an object with a row per field. Nested
+// values are printed as compact JSON in their cell.
+func (cli *cli) printTable(result []byte) error {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(result))
+	decoder.UseNumber()
+	err := decoder.Decode(&value)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(cli.stdout, 0, 0, 2, ' ', 0)
+	switch value := value.(type) {
+	case []interface{}:
+		rows := make([]map[string]interface{}, 0, len(value))
+		for _, element := range value {
+			row, ok := element.(map[string]interface{})
+			if !ok {
+				row = map[string]interface{}{"VALUE": element}
+			}
+			rows = append(rows, row)
+		}
+		columns := columnsOf(rows)
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(columns, "\t")))
+		for _, row := range rows {
+			cells := make([]string, len(columns))
+			for i, column := range columns {
+				cells[i] = cell(row[column])
+			}
+			fmt.Fprintln(writer, strings.Join(cells, "\t"))
+		}
+	case map[string]interface{}:
+		fmt.Fprintln(writer, "FIELD\tVALUE")
+		for _, field := range columnsOf([]map[string]interface{}{value}) {
+			fmt.Fprintf(writer, "%s\t%s\n", field, cell(value[field]))
+		}
+	default:
+		fmt.Fprintln(writer, cell(value))
+	}
+	return writer.Flush()
+}
+
+// columnsOf returns the fields of the rows in order
+func columnsOf(rows []map[string]interface{}) []string {
+	seen := map[string]bool{}
+	columns := []string{}
+	for _, row := range rows {
+		for field := range row {
+			if !seen[field] {
+				seen[field] = true
+				columns = append(columns, field)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func cell(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return fmt.Sprint(value)
+	}
+	compact, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(compact)
+}
@@ -0,0 +1,213 @@
+// Package apierror turns the errors of gateway calls and of the chaincode into HTTP responses. Every error
+// response of the REST applications has the same JSON body: the message, a stable code clients can switch on,
+// the ID of the transaction when one was proposed and the messages of the peers that rejected it.
+package apierror
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Codes of the error responses
+const (
+	CodeInvalidRequest = "invalid_request"
+	CodeUnauthorized   = "unauthorized"
+	CodeForbidden      = "forbidden"
+	CodeNotFound       = "not_found"
+	CodeConflict       = "conflict"
+	CodeInternal       = "internal"
+	CodeBadGateway     = "bad_gateway"
+	CodeTimeout        = "timeout"
+)
+
+// Error is the body of an error response
+type Error struct {
+	Status        int      `json:"-"`
+	Message       string   `json:"error"`
+	Code          string   `json:"code"`
+	TransactionID string   `json:"transactionId,omitempty"`
+	Details       []Detail `json:"details,omitempty"`
+}
+
+// Detail is the message of a peer or orderer that rejected a transaction
+type Detail struct {
+	Address string `json:"address,omitempty"`
+	MSPID   string `json:"mspId,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// New creates an error response
+func New(status int, code string, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// BadRequest creates the response to a request that is not valid, such as a body that does not bind
+func BadRequest(err error) *Error {
+	return New(http.StatusBadRequest, CodeInvalidRequest, err.Error())
+}
+
+// NotFound creates the response to a request for something that does not exist
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, CodeNotFound, message)
+}
+
+// ParameterError responds to a request whose parameters could not be bound by the routes generated from an
+// OpenAPI spec, it is their error handler
+func ParameterError(c *gin.Context, err error, status int) {
+	Respond(c, New(status, CodeInvalidRequest, err.Error()))
+}
+
+// Responder is an error that is answered with its own response instead of an error response,
+// such as a transaction that was accepted without waiting for its commit
+type Responder interface {
+	error
+	Respond(c *gin.Context)
+}
+
+// Respond aborts the request with the response of the error. Errors that are not the fault of the client are logged.
+func Respond(c *gin.Context, err error) {
+	var responder Responder
+	if errors.As(err, &responder) {
+		responder.Respond(c)
+		return
+	}
+
+	response := From(err)
+	if response.Status >= http.StatusInternalServerError {
+		log.Printf("%s %s failed: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+	c.AbortWithStatusJSON(response.Status, response)
+}
+
+// chaincodeResponse is how the gateway prefixes the error messages of the chaincode
+var chaincodeResponse = regexp.MustCompile(`^chaincode response \d+, `)
+
+// relayedResponse is how a chaincode prefixes the error message of a chaincode it invoked, such as the customer
+// chaincode relaying the SLA transactions to the mower chaincode
+var relayedResponse = regexp.MustCompile(`(?i)^failed to invoke chaincode\. got error: `)
+
+// From returns the error response of an error. Errors of gateway calls are unwrapped into the transaction ID
+// and the peer messages, and the status is chosen from the gRPC status and, for errors returned by the
+// chaincode, from what the message says: 404 for something that does not exist, 409 for a conflict with the
+// ledger, 403 when the caller is not allowed and 400 for anything else the chaincode rejected. A message relayed
+// from another chaincode is classified by what that chaincode said. Failures of the network are 502 and timeouts 504.
+func From(err error) *Error {
+	var response *Error
+	if errors.As(err, &response) {
+		return response
+	}
+
+	response = &Error{Message: err.Error(), TransactionID: transactionID(err)}
+
+	var commitErr *client.CommitError
+	if errors.As(err, &commitErr) {
+		switch commitErr.Code {
+		case peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_PHANTOM_READ_CONFLICT, peer.TxValidationCode_DUPLICATE_TXID:
+			response.Status, response.Code = http.StatusConflict, CodeConflict
+		default:
+			response.Status, response.Code = http.StatusBadGateway, CodeBadGateway
+		}
+		return response
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		response.Status, response.Code = http.StatusGatewayTimeout, CodeTimeout
+		return response
+	}
+
+	grpcStatus, ok := status.FromError(err)
+	if !ok {
+		response.Status, response.Code = http.StatusInternalServerError, CodeInternal
+		return response
+	}
+
+	for _, detail := range grpcStatus.Details() {
+		if detail, ok := detail.(*gateway.ErrorDetail); ok {
+			response.Details = append(response.Details, Detail{Address: detail.Address, MSPID: detail.MspId, Message: detail.Message})
+		}
+	}
+
+	switch grpcStatus.Code() {
+	case codes.DeadlineExceeded:
+		response.Status, response.Code = http.StatusGatewayTimeout, CodeTimeout
+	case codes.InvalidArgument:
+		response.Status, response.Code = http.StatusBadRequest, CodeInvalidRequest
+	case codes.NotFound:
+		response.Status, response.Code = http.StatusNotFound, CodeNotFound
+	case codes.PermissionDenied:
+		response.Status, response.Code = http.StatusForbidden, CodeForbidden
+	case codes.Unknown, codes.Aborted:
+		// the chaincode rejected the transaction, its message is the message of the response
+		message := grpcStatus.Message()
+		if len(response.Details) > 0 {
+			message = response.Details[0].Message
+		}
+		response.Message = chaincodeResponse.ReplaceAllString(message, "")
+		response.Status, response.Code = classify(response.Message)
+	default:
+		response.Status, response.Code = http.StatusBadGateway, CodeBadGateway
+	}
+	return response
+}
+
+// phrases of the chaincode error messages and the status they are answered with, in the order they are checked.
+// The failures of the chaincode itself come last, since their messages often end in the reason of the rejection.
+var phrases = []struct {
+	phrases []string
+	status  int
+	code    string
+}{
+	{[]string{"not allowed", "cannot create customer", "must be set by", "must be owned by", "only the service owner", "only mowers and the service owner", "the caller is not", "cannot read the settlements", "can only be created from offers"}, http.StatusForbidden, CodeForbidden},
+	{[]string{"does not exist", "could not find", "not found", "there is no"}, http.StatusNotFound, CodeNotFound},
+	{[]string{"already exists", "already been", "still has", "only pending", "has not been", "is not done"}, http.StatusConflict, CodeConflict},
+	{[]string{"failed to", "unmarshal"}, http.StatusBadGateway, CodeBadGateway},
+}
+
+func classify(message string) (int, string) {
+	for relayedResponse.MatchString(message) {
+		message = relayedResponse.ReplaceAllString(message, "")
+	}
+
+	lower := strings.ToLower(message)
+	for _, class := range phrases {
+		for _, phrase := range class.phrases {
+			if strings.Contains(lower, phrase) {
+				return class.status, class.code
+			}
+		}
+	}
+	return http.StatusBadRequest, CodeInvalidRequest
+}
+
+func transactionID(err error) string {
+	var endorseErr *client.EndorseError
+	var submitErr *client.SubmitError
+	var commitStatusErr *client.CommitStatusError
+	var commitErr *client.CommitError
+	switch {
+	case errors.As(err, &endorseErr):
+		return endorseErr.TransactionID
+	case errors.As(err, &submitErr):
+		return submitErr.TransactionID
+	case errors.As(err, &commitStatusErr):
+		return commitStatusErr.TransactionID
+	case errors.As(err, &commitErr):
+		return commitErr.TransactionID
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package apierror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func chaincodeError(t *testing.T, code codes.Code, message string) error {
+	grpcStatus, err := status.New(code, "failed to endorse transaction, see attached details for more info").WithDetails(
+		&gateway.ErrorDetail{Address: "peer0.org1.example.com:7051", MspId: "Org1MSP", Message: "chaincode response 500, " + message},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return grpcStatus.Err()
+}
+
+func TestFromChaincodeErrors(t *testing.T) {
+	tests := []struct {
+		message string
+		status  int
+		code    string
+	}{
+		{"the SLA 1 does not exist", http.StatusNotFound, CodeNotFound},
+		{"could not find property with ID p1", http.StatusNotFound, CodeNotFound},
+		{"the customer c1 already exists", http.StatusConflict, CodeConflict},
+		{"the invoice c1-2024-05 has already been paid", http.StatusConflict, CodeConflict},
+		{"the caller is not allowed to access customer c1", http.StatusForbidden, CodeForbidden},
+		{"invalid service level: Platinum", http.StatusBadRequest, CodeInvalidRequest},
+		{"failed to read from world state: timeout", http.StatusBadGateway, CodeBadGateway},
+		{"only the service owner can create invoices", http.StatusForbidden, CodeForbidden},
+		{"only the service owner can close periods", http.StatusForbidden, CodeForbidden},
+		{"the caller is not the mower of property garden", http.StatusForbidden, CodeForbidden},
+		{"TechMSP cannot read the settlements of Org2MSP", http.StatusForbidden, CodeForbidden},
+		{"there is no general contract for Org2MSP", http.StatusNotFound, CodeNotFound},
+		{"failed to read customer c1: the asset c1 does not exist", http.StatusNotFound, CodeNotFound},
+		// the customer chaincode relays the SLA transactions to the mower chaincode
+		{"Failed to invoke chaincode. Got error: invalid service level: Platinum", http.StatusBadRequest, CodeInvalidRequest},
+		{"Failed to invoke chaincode. Got error: parameter TargetGrassLength must be between 2 and 10 cm, got 40", http.StatusBadRequest, CodeInvalidRequest},
+		{"Failed to invoke chaincode. Got error: the SLA sla-1 does not exist", http.StatusNotFound, CodeNotFound},
+		{"Failed to invoke chaincode. Got error: the caller is not allowed to access customer c1", http.StatusForbidden, CodeForbidden},
+		{"Failed to invoke chaincode. Got error: Failed to invoke chaincode. Got error: only the service owner can read all SLAs", http.StatusForbidden, CodeForbidden},
+		{"Failed to invoke chaincode. Got error: failed to unmarshal SLA: unexpected end of JSON input", http.StatusBadGateway, CodeBadGateway},
+	}
+
+	for _, test := range tests {
+		for _, code := range []codes.Code{codes.Aborted, codes.Unknown} {
+			err := fmt.Errorf("failed to submit transaction: %w", chaincodeError(t, code, test.message))
+			response := From(err)
+			if response.Status != test.status || response.Code != test.code {
+				t.Errorf("From(%q) = %d %s, want %d %s", test.message, response.Status, response.Code, test.status, test.code)
+			}
+			if response.Message != test.message {
+				t.Errorf("message = %q, want the chaincode message %q", response.Message, test.message)
+			}
+			if len(response.Details) != 1 || response.Details[0].MSPID != "Org1MSP" {
+				t.Errorf("details = %+v, want the peer message", response.Details)
+			}
+		}
+	}
+}
+
+func TestFromGatewayErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"unavailable peer", status.Error(codes.Unavailable, "connection refused"), http.StatusBadGateway, CodeBadGateway},
+		{"gRPC deadline", status.Error(codes.DeadlineExceeded, "deadline exceeded"), http.StatusGatewayTimeout, CodeTimeout},
+		{"context deadline", fmt.Errorf("commit status: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, CodeTimeout},
+		{"unknown chaincode", status.Error(codes.NotFound, "chaincode gc not found"), http.StatusNotFound, CodeNotFound},
+		{"invalid argument", status.Error(codes.InvalidArgument, "channel is required"), http.StatusBadRequest, CodeInvalidRequest},
+		{"MVCC conflict", &client.CommitError{TransactionID: "tx1", Code: peer.TxValidationCode_MVCC_READ_CONFLICT}, http.StatusConflict, CodeConflict},
+		{"endorsement policy", &client.CommitError{TransactionID: "tx1", Code: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE}, http.StatusBadGateway, CodeBadGateway},
+		{"not a gateway error", errors.New("failed to unmarshal result"), http.StatusInternalServerError, CodeInternal},
+		{"error response", NotFound("no such invoice"), http.StatusNotFound, CodeNotFound},
+	}
+
+	for _, test := range tests {
+		response := From(test.err)
+		if response.Status != test.status || response.Code != test.code {
+			t.Errorf("%s: From() = %d %s, want %d %s", test.name, response.Status, response.Code, test.status, test.code)
+		}
+	}
+
+	response := From(fmt.Errorf("wrapped: %w", &client.CommitError{TransactionID: "tx1", Code: peer.TxValidationCode_MVCC_READ_CONFLICT}))
+	if response.TransactionID != "tx1" {
+		t.Errorf("transaction ID = %q, want tx1", response.TransactionID)
+	}
+}
+
+func TestRespond(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/sla/1", nil)
+
+	Respond(c, chaincodeError(t, codes.Unknown, "the SLA 1 does not exist"))
+
+	if recorder.Code != http.StatusNotFound || !c.IsAborted() {
+		t.Fatalf("status = %d, aborted = %v", recorder.Code, c.IsAborted())
+	}
+	var body map[string]interface{}
+	err := json.Unmarshal(recorder.Body.Bytes(), &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body["error"] != "the SLA 1 does not exist" || body["code"] != CodeNotFound || body["details"] == nil {
+		t.Fatalf("body = %v", body)
+	}
+	if _, ok := body["transactionId"]; ok {
+		t.Fatalf("body = %v, an evaluation has no transaction ID", body)
+	}
+}
@@ -0,0 +1,165 @@
+// Package auth authenticates the users of the REST applications with JWT bearer tokens and maps every user
+// to the enrolled Fabric identity their transactions are signed with, so the chaincode can tell callers apart.
+package auth
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// DefaultRolesClaim is the claim holding the roles of a user, a list or a space separated string
+	DefaultRolesClaim = "roles"
+	// DefaultIdentityClaim is the claim holding the label of the Fabric identity of a user in the wallet.
+	// The subject is used as label when the token has no such claim.
+	DefaultIdentityClaim = "fabric_identity"
+)
+
+// Principal is an authenticated user
+type Principal struct {
+	Subject  string
+	Roles    []string
+	Identity string
+}
+
+// HasRole reports whether the principal has any of the roles
+func (principal *Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		for _, held := range principal.Roles {
+			if held == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Options configures how tokens are verified. Exactly one of JWKSURL, PublicKeyPath and HMACSecret is used.
+type Options struct {
+	JWKSURL       string
+	PublicKeyPath string
+	HMACSecret    string
+	Issuer        string
+	Audience      string
+	RolesClaim    string
+	IdentityClaim string
+}
+
+// Verifier verifies bearer tokens and returns who they were issued to
+type Verifier struct {
+	keyfunc       jwt.Keyfunc
+	parser        *jwt.Parser
+	rolesClaim    string
+	identityClaim string
+}
+
+// NewVerifier creates a verifier for tokens signed with a key from the JWKS endpoint, with the PEM encoded
+// RSA, ECDSA or Ed25519 public key in a file, or with a shared HMAC secret
+func NewVerifier(options Options) (*Verifier, error) {
+	sources := 0
+	for _, source := range []string{options.JWKSURL, options.PublicKeyPath, options.HMACSecret} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("exactly one of a JWKS URL, a public key or an HMAC secret is needed to verify tokens")
+	}
+
+	var keyfunc jwt.Keyfunc
+	var methods []string
+	switch {
+	case options.JWKSURL != "":
+		keys := newJWKS(options.JWKSURL)
+		keyfunc = keys.keyfunc
+		methods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+	case options.PublicKeyPath != "":
+		key, keyMethods, err := loadPublicKey(options.PublicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		keyfunc = func(*jwt.Token) (interface{}, error) { return key, nil }
+		methods = keyMethods
+	default:
+		secret := []byte(options.HMACSecret)
+		keyfunc = func(*jwt.Token) (interface{}, error) { return secret, nil }
+		methods = []string{"HS256", "HS384", "HS512"}
+	}
+
+	parserOptions := []jwt.ParserOption{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}
+	if options.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(options.Issuer))
+	}
+	if options.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(options.Audience))
+	}
+
+	verifier := &Verifier{
+		keyfunc:       keyfunc,
+		parser:        jwt.NewParser(parserOptions...),
+		rolesClaim:    options.RolesClaim,
+		identityClaim: options.IdentityClaim,
+	}
+	if verifier.rolesClaim == "" {
+		verifier.rolesClaim = DefaultRolesClaim
+	}
+	if verifier.identityClaim == "" {
+		verifier.identityClaim = DefaultIdentityClaim
+	}
+	return verifier, nil
+}
+
+func loadPublicKey(path string) (crypto.PublicKey, []string, error) {
+	keyPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(keyPEM); err == nil {
+		return key, []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(keyPEM); err == nil {
+		return key, []string{"ES256", "ES384", "ES512"}, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(keyPEM); err == nil {
+		return key, []string{"EdDSA"}, nil
+	}
+	return nil, nil, fmt.Errorf("%s is not a PEM encoded RSA, ECDSA or Ed25519 public key", path)
+}
+
+// Verify checks the signature, expiry, issuer and audience of the token and returns its principal
+func (verifier *Verifier) Verify(tokenString string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := verifier.parser.ParseWithClaims(tokenString, claims, verifier.keyfunc)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, errors.New("the token has no subject")
+	}
+
+	principal := &Principal{Subject: subject, Identity: subject}
+	if label, ok := claims[verifier.identityClaim].(string); ok && label != "" {
+		principal.Identity = label
+	}
+
+	switch roles := claims[verifier.rolesClaim].(type) {
+	case string:
+		principal.Roles = strings.Fields(roles)
+	case []interface{}:
+		for _, role := range roles {
+			if role, ok := role.(string); ok {
+				principal.Roles = append(principal.Roles, role)
+			}
+		}
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,340 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/wallet"
+)
+
+const secret = "test-secret"
+
+func hmacToken(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func validClaims(subject string, roles ...interface{}) jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   subject,
+		"iss":   "test-issuer",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": roles,
+	}
+}
+
+func TestVerifyHMAC(t *testing.T) {
+	verifier, err := NewVerifier(Options{HMACSecret: secret, Issuer: "test-issuer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	principal, err := verifier.Verify(hmacToken(t, validClaims("alice", "customer")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Principal{Subject: "alice", Roles: []string{"customer"}, Identity: "alice"}
+	if !reflect.DeepEqual(principal, want) {
+		t.Fatalf("Verify() = %+v, want %+v", principal, want)
+	}
+
+	claims := validClaims("bob")
+	claims["roles"] = "technician service-owner"
+	claims[DefaultIdentityClaim] = "technician1"
+	principal, err = verifier.Verify(hmacToken(t, claims))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.Identity != "technician1" || !principal.HasRole("service-owner") || principal.HasRole("customer") {
+		t.Fatalf("Verify() = %+v", principal)
+	}
+
+	expired := validClaims("alice")
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	wrongIssuer := validClaims("alice")
+	wrongIssuer["iss"] = "someone-else"
+	noExpiry := validClaims("alice")
+	delete(noExpiry, "exp")
+	for name, claims := range map[string]jwt.MapClaims{"expired": expired, "wrong issuer": wrongIssuer, "no expiry": noExpiry} {
+		if _, err := verifier.Verify(hmacToken(t, claims)); err == nil {
+			t.Errorf("Verify() of a token with %s returned no error", name)
+		}
+	}
+
+	other, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims("alice")).SignedString([]byte("other-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifier.Verify(other); err == nil {
+		t.Error("Verify() of a token signed with another secret returned no error")
+	}
+}
+
+func TestVerifyJWKS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid := "key-1"
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": kid,
+				"kty": "EC",
+				"use": "sig",
+				"crv": "P-256",
+				"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+				"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	verifier, err := NewVerifier(Options{JWKSURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, validClaims("alice"))
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := verifier.Verify(sign("key-1")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("the keys were fetched %d times, want once", fetches)
+	}
+
+	// an unknown key is only looked up again once the refresh interval has passed
+	if _, err := verifier.Verify(sign("key-2")); err == nil {
+		t.Fatal("Verify() of a token signed with an unknown key returned no error")
+	}
+	if fetches != 1 {
+		t.Fatalf("the keys were fetched %d times within the refresh interval", fetches)
+	}
+}
+
+func TestNewVerifierNeedsOneKeySource(t *testing.T) {
+	if _, err := NewVerifier(Options{}); err == nil {
+		t.Error("NewVerifier() without a key returned no error")
+	}
+	if _, err := NewVerifier(Options{HMACSecret: secret, JWKSURL: "http://localhost"}); err == nil {
+		t.Error("NewVerifier() with two keys returned no error")
+	}
+}
+
+// newTestGateway connects a gateway to an endpoint nothing listens on and creates a wallet with the identity alice
+func newTestGateway(t *testing.T) (*fabric.Gateway, wallet.Store) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "alice"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER})
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER})
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, certificatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, privateKeyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	gw, err := fabric.Connect(fabric.Config{MSPID: "Org1MSP", CertPath: certPath, KeyPath: keyPath, PeerEndpoint: "127.0.0.1:1", TLSDisabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { gw.Close() })
+
+	store, err := wallet.NewFileStore(filepath.Join(dir, "wallet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := wallet.NewIdentity("Org1MSP", certificatePEM, privateKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put("alice", id); err != nil {
+		t.Fatal(err)
+	}
+	return gw, store
+}
+
+func TestGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gw, store := newTestGateway(t)
+	verifier, err := NewVerifier(Options{HMACSecret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	guard := NewGuard(verifier, gw, store)
+
+	router := gin.New()
+	router.Use(guard.Authenticate())
+	router.GET("/customers", guard.Require("customer"), func(c *gin.Context) {
+		if GatewayFrom(c, gw) == gw {
+			t.Error("an authenticated request must use the gateway of its user")
+		}
+		principal, _ := PrincipalFrom(c)
+		c.String(http.StatusOK, principal.Subject)
+	})
+	router.GET("/owners", guard.Require("service-owner"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	guard.WithoutIdentity("/offline/proposals")
+	router.POST("/offline/proposals", guard.Require("technician"), func(c *gin.Context) {
+		if GatewayFrom(c, gw) != gw {
+			t.Error("a request to a route without identity must not select a gateway")
+		}
+		c.Status(http.StatusOK)
+	})
+
+	request := func(path string, token string) int {
+		method := http.MethodGet
+		if strings.HasPrefix(path, "/offline/") {
+			method = http.MethodPost
+		}
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	alice := hmacToken(t, validClaims("alice", "customer"))
+	tests := []struct {
+		name  string
+		path  string
+		token string
+		want  int
+	}{
+		{"no token", "/customers", "", http.StatusUnauthorized},
+		{"invalid token", "/customers", "not-a-token", http.StatusUnauthorized},
+		{"no identity in the wallet", "/customers", hmacToken(t, validClaims("mallory", "customer")), http.StatusForbidden},
+		{"missing role", "/owners", alice, http.StatusForbidden},
+		{"allowed", "/customers", alice, http.StatusOK},
+		{"route without identity", "/offline/proposals", hmacToken(t, validClaims("mallory", "technician")), http.StatusOK},
+		{"route without identity needs the role", "/offline/proposals", hmacToken(t, validClaims("mallory", "customer")), http.StatusForbidden},
+		{"route without identity needs a token", "/offline/proposals", "", http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		if got := request(test.path, test.token); got != test.want {
+			t.Errorf("%s: status %d, want %d", test.name, got, test.want)
+		}
+	}
+}
+
+func TestDisabledGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var guard *Guard
+
+	router := gin.New()
+	router.Use(guard.Authenticate())
+	router.GET("/owners", guard.Require("service-owner"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/owners", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("a disabled guard must let every request through, got %d", recorder.Code)
+	}
+}
+
+func TestRequireScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gw, store := newTestGateway(t)
+	verifier, err := NewVerifier(Options{HMACSecret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	guard := NewGuard(verifier, gw, store)
+	requireScopes := guard.RequireScopes("bearerAuth.Scopes")
+
+	// operation mimics a handler generated from an OpenAPI spec, which sets the scopes and calls its middlewares
+	operation := func(scopes ...string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set("bearerAuth.Scopes", scopes)
+			requireScopes(c)
+			if c.IsAborted() {
+				return
+			}
+			c.Status(http.StatusOK)
+		}
+	}
+
+	router := gin.New()
+	router.Use(guard.Authenticate())
+	router.GET("/customers", operation("customer", "service-owner"))
+	router.GET("/owners", operation("service-owner"))
+	router.GET("/services", operation())
+
+	alice := hmacToken(t, validClaims("alice", "customer"))
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/customers", http.StatusOK},
+		{"/owners", http.StatusForbidden},
+		{"/services", http.StatusOK},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, test.path, nil)
+		req.Header.Set("Authorization", "Bearer "+alice)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		if recorder.Code != test.want {
+			t.Errorf("%s: status %d, want %d", test.path, recorder.Code, test.want)
+		}
+	}
+}
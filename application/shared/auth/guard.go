@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/wallet"
+)
+
+const (
+	principalKey = "auth.principal"
+	gatewayKey   = "auth.gateway"
+)
+
+// Guard authenticates the requests of a router and checks the roles of its routes. Every authenticated request
+// transacts through the gateway of the Fabric identity of its user, loaded from the wallet the first time.
+// A nil guard, or one without a verifier, lets every request through as the identity of the application.
+type Guard struct {
+	verifier *Verifier
+	gateway  *fabric.Gateway
+	wallet   wallet.Store
+
+	// routes whose users need no identity in the wallet
+	keyless map[string]bool
+}
+
+// NewGuard creates a guard that verifies tokens with the verifier and finds the identities of users in the wallet
+func NewGuard(verifier *Verifier, gateway *fabric.Gateway, store wallet.Store) *Guard {
+	return &Guard{verifier: verifier, gateway: gateway, wallet: store}
+}
+
+// Enabled reports whether requests are authenticated
+func (guard *Guard) Enabled() bool {
+	return guard != nil && guard.verifier != nil
+}
+
+// WithoutIdentity lets users without an identity in the wallet call the routes, whose transactions their clients
+// sign themselves. Requests to them are authenticated and authorized but transact through no gateway of the user.
+func (guard *Guard) WithoutIdentity(routes ...string) {
+	if !guard.Enabled() {
+		return
+	}
+	if guard.keyless == nil {
+		guard.keyless = make(map[string]bool)
+	}
+	for _, route := range routes {
+		guard.keyless[route] = true
+	}
+}
+
+// Authenticate verifies the bearer token of every request and selects the gateway of the identity of its user.
+// Requests without a valid token are rejected with 401 and users without an identity in the wallet with 403,
+// except on the routes that need none.
+func (guard *Guard) Authenticate() gin.HandlerFunc {
+	if !guard.Enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			c.Header("WWW-Authenticate", `Bearer`)
+			apierror.Respond(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "a bearer token is required"))
+			return
+		}
+
+		principal, err := guard.verifier.Verify(token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			apierror.Respond(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "invalid token: "+err.Error()))
+			return
+		}
+
+		if guard.keyless[c.FullPath()] {
+			c.Set(principalKey, principal)
+			c.Next()
+			return
+		}
+
+		gw, err := guard.gateway.ForIdentity(principal.Identity, wallet.Loader(guard.wallet, principal.Identity))
+		if errors.Is(err, wallet.ErrNotFound) {
+			apierror.Respond(c, apierror.New(http.StatusForbidden, apierror.CodeForbidden, "no Fabric identity is enrolled for "+principal.Subject))
+			return
+		}
+		if err != nil {
+			log.Printf("failed to load the Fabric identity %s of %s: %v", principal.Identity, principal.Subject, err)
+			apierror.Respond(c, apierror.New(http.StatusInternalServerError, apierror.CodeInternal, "failed to load the Fabric identity of "+principal.Subject))
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Set(gatewayKey, gw)
+		c.Next()
+	}
+}
+
+// Require rejects requests whose user has none of the roles with 403. Without roles any authenticated user is allowed.
+func (guard *Guard) Require(roles ...string) gin.HandlerFunc {
+	if !guard.Enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		if guard.authorize(c, roles) {
+			c.Next()
+		}
+	}
+}
+
+// RequireScopes is Require for routes generated from an OpenAPI spec, which set the roles of the operation
+// as the scopes of its security requirement under the key before they call their middlewares
+func (guard *Guard) RequireScopes(key string) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if !guard.Enabled() {
+			return
+		}
+		roles, _ := c.Value(key).([]string)
+		guard.authorize(c, roles)
+	}
+}
+
+// authorize responds to requests whose user has none of the roles and reports whether the request is allowed
+func (guard *Guard) authorize(c *gin.Context, roles []string) bool {
+	principal, ok := PrincipalFrom(c)
+	if !ok {
+		apierror.Respond(c, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthorized, "authentication is required"))
+		return false
+	}
+	if len(roles) > 0 && !principal.HasRole(roles...) {
+		apierror.Respond(c, apierror.New(http.StatusForbidden, apierror.CodeForbidden, "this requires one of the roles "+strings.Join(roles, ", ")))
+		return false
+	}
+	return true
+}
+
+// PrincipalFrom returns the authenticated user of the request
+func PrincipalFrom(c *gin.Context) (*Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*Principal)
+	return principal, ok
+}
+
+// GatewayFrom returns the gateway of the identity of the user of the request,
+// or the fallback when requests are not authenticated
+func GatewayFrom(c *gin.Context, fallback *fabric.Gateway) *fabric.Gateway {
+	if value, ok := c.Get(gatewayKey); ok {
+		if gw, ok := value.(*fabric.Gateway); ok {
+			return gw
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval limits how often the keys are fetched again for a token signed with an unknown key
+const jwksRefreshInterval = time.Minute
+
+// jwks holds the keys of a JSON Web Key Set endpoint. They are fetched on the first token and again
+// when a token is signed with a key that is not in the set, which is how identity providers rotate keys.
+type jwks struct {
+	url        string
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func newJWKS(url string) *jwks {
+	return &jwks{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		keys:       make(map[string]crypto.PublicKey),
+	}
+}
+
+func (set *jwks) keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	if key, ok := set.lookup(kid); ok {
+		return key, nil
+	}
+	if time.Since(set.fetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	err := set.fetch()
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := set.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookup returns the key with the ID, or the only key of the set for a token without a key ID
+func (set *jwks) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(set.keys) == 1 {
+		for _, key := range set.keys {
+			return key, true
+		}
+	}
+	key, ok := set.keys[kid]
+	return key, ok
+}
+
+func (set *jwks) fetch() error {
+	set.fetchedAt = time.Now()
+
+	response, err := set.httpClient.Get(set.url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch signing keys: %s", response.Status)
+	}
+
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&document)
+	if err != nil {
+		return fmt.Errorf("invalid signing keys: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// keys of other types are skipped, they are not used to sign tokens for us
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	set.keys = keys
+	return nil
+}
+
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(bytes), nil
+}
@@ -0,0 +1,419 @@
+// Package bulk imports customers and their SLAs from CSV or NDJSON, for example when a housing association with
+// hundreds of customers is onboarded. Every row is priced before anything is submitted and rows that cannot be
+// priced are reported instead of submitted. The customers are then created in batches that run concurrently,
+// while the SLAs of one customer are created one after another since they change the same customer.
+//
+// An import is resumable. The SLA of a row is submitted with an idempotency key made of the import ID and the
+// key or line of the row, and customers that exist are not created again, so running an import again with the
+// same ID and rows replays what was already created and only submits the rest.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// DefaultParallel is how many customers are imported at once
+const DefaultParallel = 8
+
+// maxKeyLength is the longest idempotency key the chaincodes accept
+const maxKeyLength = 255
+
+// MaxIDLength is the longest import ID, which leaves room in the idempotency keys for the keys of the rows
+const MaxIDLength = 128
+
+// Statuses of a row
+const (
+	// StatusPending rows are valid and wait to be submitted, or were not submitted because the import stopped
+	StatusPending = "pending"
+	// StatusValid rows were priced by an import that only validates
+	StatusValid = "valid"
+	// StatusCreated rows created their SLA, or only their customer when they have no service level
+	StatusCreated = "created"
+	// StatusReplayed rows had been created before, their SLA by an earlier run of the import
+	StatusReplayed = "replayed"
+	// StatusInvalid rows could not be parsed or priced and were not submitted
+	StatusInvalid = "invalid"
+	// StatusFailed rows were submitted and failed
+	StatusFailed = "failed"
+)
+
+// Statuses of an import
+const (
+	StatusRunning  = "running"
+	StatusDone     = "done"
+	StatusCanceled = "canceled"
+)
+
+// Row is a customer and optionally an SLA for it, given like the SLA requests of the C2B application. Mowing SLAs
+// can be given with the grass lengths, SLAs of other service types need ServiceType and Parameters. A row without
+// a service level only creates its customer. Key identifies the row across runs of an import, its line when empty.
+type Row struct {
+	Line              int                        `json:"-"`
+	Key               string                     `json:"Key,omitempty"`
+	CustomerID        string                     `json:"CustomerID"`
+	PropertyID        string                     `json:"PropertyID,omitempty"`
+	ServiceType       string                     `json:"ServiceType,omitempty"`
+	ServiceLevel      string                     `json:"ServiceLevel,omitempty"`
+	Parameters        map[string]decimal.Decimal `json:"Parameters,omitempty"`
+	TargetGrassLength decimal.Decimal            `json:"TargetGrassLength,omitempty"`
+	MaxGrassLength    decimal.Decimal            `json:"MaxGrassLength,omitempty"`
+	MinGrassLength    decimal.Decimal            `json:"MinGrassLength,omitempty"`
+
+	// invalid is why the row could not be parsed
+	invalid error
+}
+
+// ServiceSLA tells whether the SLA of the row is given by service type and parameters rather than by grass
+// lengths only, so it is created and priced as an SLA of any service type
+func (row Row) ServiceSLA() bool {
+	return row.Parameters != nil || row.ServiceType != "" || row.PropertyID != ""
+}
+
+// ServiceParameters returns the parameters of the SLA, the grass lengths when none are given
+func (row Row) ServiceParameters() map[string]decimal.Decimal {
+	if row.Parameters != nil {
+		return row.Parameters
+	}
+	return map[string]decimal.Decimal{
+		"TargetGrassLength": row.TargetGrassLength,
+		"MaxGrassLength":    row.MaxGrassLength,
+		"MinGrassLength":    row.MinGrassLength,
+	}
+}
+
+// ServiceTypeOrDefault returns the service type of the SLA, mowing when none is given
+func (row Row) ServiceTypeOrDefault() string {
+	if row.ServiceType == "" {
+		return "mowing"
+	}
+	return row.ServiceType
+}
+
+// Ledger prices and creates what the rows describe. It is called concurrently, but never for two rows of the
+// same customer at once.
+type Ledger interface {
+	// Evaluate returns the annual price of the SLA of the row
+	Evaluate(ctx context.Context, row Row) (decimal.Money, error)
+	CustomerExists(ctx context.Context, customerID string) (bool, error)
+	CreateCustomer(ctx context.Context, customerID string) error
+	// CreateSLA creates the SLA of the row with the idempotency key and returns its ID, and whether it had
+	// already been created with the key
+	CreateSLA(ctx context.Context, row Row, key string) (slaID string, replayed bool, err error)
+}
+
+// Options of an import, zero values are the defaults
+type Options struct {
+	// Parallel is how many customers are imported at once, and how many rows are priced at once
+	Parallel int
+	// DryRun only prices the rows
+	DryRun bool
+}
+
+// Result is what became of a row
+type Result struct {
+	Line            int            `json:"Line"`
+	Key             string         `json:"Key"`
+	CustomerID      string         `json:"CustomerID"`
+	Status          string         `json:"Status"`
+	CustomerCreated bool           `json:"CustomerCreated"`
+	SLAID           string         `json:"SLAID,omitempty"`
+	AnnualTotal     *decimal.Money `json:"AnnualTotal,omitempty"`
+	Error           string         `json:"Error,omitempty"`
+}
+
+// Report is the progress of an import, with the result of every row in the order of the rows and how many rows
+// have each status
+type Report struct {
+	ImportID string         `json:"ImportID"`
+	Status   string         `json:"Status"`
+	DryRun   bool           `json:"DryRun"`
+	Started  time.Time      `json:"Started"`
+	Finished *time.Time     `json:"Finished,omitempty"`
+	Counts   map[string]int `json:"Counts"`
+	Rows     []Result       `json:"Rows"`
+}
+
+// Import is one run of an import. Its report can be read while it runs.
+type Import struct {
+	id      string
+	rows    []Row
+	ledger  Ledger
+	options Options
+
+	mu       sync.Mutex
+	status   string
+	started  time.Time
+	finished time.Time
+	results  []Result
+}
+
+// New prepares the import of the rows with the ID, which the idempotency keys of the rows are made of
+func New(id string, rows []Row, ledger Ledger, options Options) *Import {
+	if options.Parallel <= 0 {
+		options.Parallel = DefaultParallel
+	}
+
+	imp := &Import{id: id, rows: rows, ledger: ledger, options: options, status: StatusRunning, started: time.Now(), results: make([]Result, len(rows))}
+	keys := make(map[string]int)
+	for i, row := range rows {
+		result := Result{Line: row.Line, Key: row.Key, CustomerID: row.CustomerID, Status: StatusPending}
+		if result.Key == "" {
+			result.Key = strconv.Itoa(row.Line)
+		}
+
+		key := imp.key(result.Key)
+		switch {
+		case row.invalid != nil:
+			result.Status, result.Error = StatusInvalid, row.invalid.Error()
+		case row.CustomerID == "":
+			result.Status, result.Error = StatusInvalid, "CustomerID is not set"
+		case len(key) > maxKeyLength:
+			result.Status, result.Error = StatusInvalid, fmt.Sprintf("the idempotency key %s is longer than %d characters", key, maxKeyLength)
+		case !printable(key):
+			result.Status, result.Error = StatusInvalid, fmt.Sprintf("the idempotency key %q is not printable ASCII", key)
+		case keys[result.Key] != 0:
+			result.Status, result.Error = StatusInvalid, fmt.Sprintf("the key %s is also the key of line %d", result.Key, keys[result.Key])
+		}
+		if keys[result.Key] == 0 {
+			keys[result.Key] = row.Line
+		}
+		imp.results[i] = result
+	}
+	return imp
+}
+
+// CheckID returns an error when the import ID cannot be part of the idempotency keys of its rows
+func CheckID(id string) error {
+	if id == "" || len(id) > MaxIDLength {
+		return fmt.Errorf("the import ID must have 1 to %d characters", MaxIDLength)
+	}
+	if !printable(id) {
+		return fmt.Errorf("the import ID %q is not printable ASCII", id)
+	}
+	return nil
+}
+
+// printable reports whether the idempotency key only has characters the chaincodes accept in keys
+func printable(key string) bool {
+	for _, r := range key {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ID returns the ID of the import
+func (imp *Import) ID() string {
+	return imp.id
+}
+
+// key returns the idempotency key of the SLA of the row with the key
+func (imp *Import) key(rowKey string) string {
+	return "import:" + imp.id + ":" + rowKey
+}
+
+// Run prices every row and then, unless it is a dry run, creates the customers and SLAs of the valid rows. Rows
+// that are not reached before the context is done stay pending. It returns the final report.
+func (imp *Import) Run(ctx context.Context) Report {
+	imp.validate(ctx)
+	if !imp.options.DryRun {
+		imp.submit(ctx)
+	}
+
+	imp.mu.Lock()
+	imp.status = StatusDone
+	if ctx.Err() != nil {
+		imp.status = StatusCanceled
+	}
+	imp.finished = time.Now()
+	imp.mu.Unlock()
+	return imp.Report()
+}
+
+// validate prices the SLAs of the rows that have one, Parallel at a time
+func (imp *Import) validate(ctx context.Context) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, imp.options.Parallel)
+	for i, row := range imp.rows {
+		if imp.result(i).Status != StatusPending || row.ServiceLevel == "" {
+			if imp.options.DryRun {
+				imp.update(i, func(result *Result) {
+					if result.Status == StatusPending {
+						result.Status = StatusValid
+					}
+				})
+			}
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+		wg.Add(1)
+		go func(i int, row Row) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			price, err := imp.ledger.Evaluate(ctx, row)
+			imp.update(i, func(result *Result) {
+				if err != nil {
+					result.Status, result.Error = StatusInvalid, err.Error()
+					return
+				}
+				result.AnnualTotal = &price
+				if imp.options.DryRun {
+					result.Status = StatusValid
+				}
+			})
+		}(i, row)
+	}
+	wg.Wait()
+}
+
+// submit imports the customers of the pending rows in batches of Parallel customers. The customers of a batch
+// are imported concurrently and the next batch starts once all of them are done.
+func (imp *Import) submit(ctx context.Context) {
+	customers := []string{}
+	rowsOf := make(map[string][]int)
+	for i, row := range imp.rows {
+		if imp.result(i).Status != StatusPending {
+			continue
+		}
+		if _, ok := rowsOf[row.CustomerID]; !ok {
+			customers = append(customers, row.CustomerID)
+		}
+		rowsOf[row.CustomerID] = append(rowsOf[row.CustomerID], i)
+	}
+
+	for start := 0; start < len(customers) && ctx.Err() == nil; start += imp.options.Parallel {
+		end := start + imp.options.Parallel
+		if end > len(customers) {
+			end = len(customers)
+		}
+
+		var wg sync.WaitGroup
+		for _, customerID := range customers[start:end] {
+			wg.Add(1)
+			go func(customerID string) {
+				defer wg.Done()
+				imp.importCustomer(ctx, customerID, rowsOf[customerID])
+			}(customerID)
+		}
+		wg.Wait()
+	}
+}
+
+// importCustomer creates the customer unless it exists and then the SLAs of its rows one by one
+func (imp *Import) importCustomer(ctx context.Context, customerID string, rows []int) {
+	created, err := imp.ensureCustomer(ctx, customerID)
+	if err != nil {
+		for _, i := range rows {
+			imp.fail(i, fmt.Errorf("customer %s: %w", customerID, err))
+		}
+		return
+	}
+	imp.update(rows[0], func(result *Result) { result.CustomerCreated = created })
+
+	for _, i := range rows {
+		if ctx.Err() != nil {
+			return
+		}
+		row := imp.rows[i]
+		if row.ServiceLevel == "" {
+			imp.update(i, func(result *Result) {
+				result.Status = StatusReplayed
+				if created {
+					result.Status = StatusCreated
+				}
+			})
+			continue
+		}
+
+		slaID, replayed, err := imp.ledger.CreateSLA(ctx, row, imp.key(imp.result(i).Key))
+		if err != nil {
+			imp.fail(i, err)
+			continue
+		}
+		imp.update(i, func(result *Result) {
+			result.SLAID = slaID
+			result.Status = StatusCreated
+			if replayed {
+				result.Status = StatusReplayed
+			}
+		})
+	}
+}
+
+// ensureCustomer creates the customer if it does not exist yet and reports whether it did
+func (imp *Import) ensureCustomer(ctx context.Context, customerID string) (bool, error) {
+	exists, err := imp.ledger.CustomerExists(ctx, customerID)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+	err = imp.ledger.CreateCustomer(ctx, customerID)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (imp *Import) fail(i int, err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	imp.update(i, func(result *Result) {
+		result.Status, result.Error = StatusFailed, err.Error()
+	})
+}
+
+func (imp *Import) result(i int) Result {
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+	return imp.results[i]
+}
+
+func (imp *Import) update(i int, change func(result *Result)) {
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+	change(&imp.results[i])
+}
+
+// Report returns the progress of the import
+func (imp *Import) Report() Report {
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+
+	report := Report{
+		ImportID: imp.id,
+		Status:   imp.status,
+		DryRun:   imp.options.DryRun,
+		Started:  imp.started,
+		Counts:   make(map[string]int),
+		Rows:     make([]Result, len(imp.results)),
+	}
+	if !imp.finished.IsZero() {
+		finished := imp.finished
+		report.Finished = &finished
+	}
+	copy(report.Rows, imp.results)
+	for _, result := range imp.results {
+		report.Counts[result.Status]++
+	}
+	return report
+}
@@ -0,0 +1,227 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// fakeLedger keeps customers and SLAs in memory and records how many customers are imported at once
+type fakeLedger struct {
+	mu        sync.Mutex
+	customers map[string]bool
+	slas      map[string]string
+	evaluated int
+	active    map[string]bool
+	maxActive int
+	created   int
+	// wait blocks CreateSLA, which tells blocked it is waiting
+	wait    chan struct{}
+	blocked chan struct{}
+}
+
+func newFakeLedger() *fakeLedger {
+	return &fakeLedger{customers: make(map[string]bool), slas: make(map[string]string), active: make(map[string]bool)}
+}
+
+func (ledger *fakeLedger) Evaluate(_ context.Context, row Row) (decimal.Money, error) {
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+	ledger.evaluated++
+	if row.ServiceLevel != "Gold" && row.ServiceLevel != "Silver" {
+		return decimal.Money{}, fmt.Errorf("unknown service level %s", row.ServiceLevel)
+	}
+	return decimal.NewMoney(decimal.MustParse("1200"), "SEK"), nil
+}
+
+func (ledger *fakeLedger) CustomerExists(_ context.Context, customerID string) (bool, error) {
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+	if ledger.active[customerID] {
+		return false, fmt.Errorf("customer %s is imported twice at once", customerID)
+	}
+	ledger.active[customerID] = true
+	if len(ledger.active) > ledger.maxActive {
+		ledger.maxActive = len(ledger.active)
+	}
+	return ledger.customers[customerID], nil
+}
+
+func (ledger *fakeLedger) CreateCustomer(_ context.Context, customerID string) error {
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+	if customerID == "broken" {
+		delete(ledger.active, customerID)
+		return fmt.Errorf("the customer %s cannot be created", customerID)
+	}
+	ledger.customers[customerID] = true
+	return nil
+}
+
+func (ledger *fakeLedger) CreateSLA(ctx context.Context, row Row, key string) (string, bool, error) {
+	if ledger.wait != nil {
+		ledger.blocked <- struct{}{}
+		select {
+		case <-ledger.wait:
+		case <-ctx.Done():
+			return "", false, ctx.Err()
+		}
+	}
+
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+	delete(ledger.active, row.CustomerID)
+	if id, ok := ledger.slas[key]; ok {
+		return id, true, nil
+	}
+	ledger.created++
+	id := fmt.Sprintf("sla-%d", ledger.created)
+	ledger.slas[key] = id
+	return id, false, nil
+}
+
+const customersCSV = `CustomerID,ServiceLevel,TargetGrassLength,MaxGrassLength,MinGrassLength
+brf-1,Gold,5,7,3
+brf-1,Silver,4.5,6,3
+brf-2,Bronze,5,7,3
+brf-3,Gold,five,7,3
+broken,Gold,5,7,3
+`
+
+func TestParse(t *testing.T) {
+	rows, err := Parse(strings.NewReader(customersCSV), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 || rows[0].Line != 2 || rows[1].TargetGrassLength != decimal.MustParse("4.5") || rows[1].ServiceSLA() {
+		t.Fatalf("rows = %+v", rows)
+	}
+	if rows[3].invalid == nil {
+		t.Error("a grass length that is not a decimal is not reported")
+	}
+
+	rows, err = Parse(strings.NewReader("customerid, servicetype, servicelevel, HedgeHeight\nbrf-1, hedge, Gold, 1.8\n"), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows[0].CustomerID != "brf-1" || rows[0].ServiceTypeOrDefault() != "hedge" || rows[0].Parameters["HedgeHeight"] != decimal.MustParse("1.8") {
+		t.Fatalf("parameter row = %+v", rows[0])
+	}
+
+	ndjson := `{"CustomerID":"brf-1","Key":"a","ServiceLevel":"Gold","Parameters":{"TargetGrassLength":"5"}}
+
+{"CustomerID":"brf-2","Level":"Gold"}
+`
+	rows, err = Parse(strings.NewReader(ndjson), FormatNDJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Key != "a" || !rows[0].ServiceSLA() || rows[1].Line != 3 || rows[1].invalid == nil {
+		t.Fatalf("NDJSON rows = %+v", rows)
+	}
+
+	for _, test := range []struct {
+		format  string
+		content string
+	}{
+		{FormatCSV, "ServiceLevel\nGold\n"},
+		{FormatCSV, ""},
+		{FormatCSV, "CustomerID\n\"brf-1\n"},
+		{"xml", "<rows/>"},
+	} {
+		if _, err := Parse(strings.NewReader(test.content), test.format); err == nil {
+			t.Errorf("Parse(%q) of %s returned no error", test.content, test.format)
+		}
+	}
+}
+
+func TestImport(t *testing.T) {
+	rows, err := Parse(strings.NewReader(customersCSV+"brf-4,,,,\nbrf-1,Gold,5,7,3\n"), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows[6].Key = "2"
+	ledger := newFakeLedger()
+	ledger.customers["brf-4"] = true
+
+	report := New("hsb", rows, ledger, Options{Parallel: 2}).Run(context.Background())
+	if report.Status != StatusDone || report.Finished == nil {
+		t.Fatalf("report = %+v", report)
+	}
+	want := []string{StatusCreated, StatusCreated, StatusInvalid, StatusInvalid, StatusFailed, StatusReplayed, StatusInvalid}
+	for i, status := range want {
+		if report.Rows[i].Status != status {
+			t.Errorf("line %d: status %s (%s), want %s", report.Rows[i].Line, report.Rows[i].Status, report.Rows[i].Error, status)
+		}
+	}
+	first := report.Rows[0]
+	if first.SLAID == "" || !first.CustomerCreated || first.AnnualTotal == nil || *first.AnnualTotal != decimal.NewMoney(decimal.MustParse("1200"), "SEK") {
+		t.Errorf("first row = %+v", first)
+	}
+	if !strings.Contains(report.Rows[2].Error, "Bronze") || !strings.Contains(report.Rows[6].Error, "line 2") {
+		t.Errorf("invalid rows = %+v", report.Rows)
+	}
+	if report.Counts[StatusCreated] != 2 || ledger.maxActive > 2 {
+		t.Errorf("counts = %v, %d customers at once", report.Counts, ledger.maxActive)
+	}
+
+	// running the import again replays the SLAs instead of creating them again
+	ledger.active = make(map[string]bool)
+	report = New("hsb", rows, ledger, Options{}).Run(context.Background())
+	if report.Rows[0].Status != StatusReplayed || report.Rows[0].SLAID != first.SLAID || report.Rows[0].CustomerCreated || ledger.created != 2 {
+		t.Errorf("resumed row = %+v, %d SLAs created", report.Rows[0], ledger.created)
+	}
+
+	// a dry run only prices
+	ledger = newFakeLedger()
+	report = New("hsb", rows, ledger, Options{DryRun: true}).Run(context.Background())
+	if report.Counts[StatusValid] != 4 || len(ledger.customers) != 0 || ledger.evaluated != 4 {
+		t.Errorf("dry run counts = %v, %d customers created", report.Counts, len(ledger.customers))
+	}
+}
+
+func TestJobs(t *testing.T) {
+	rows, err := Parse(strings.NewReader(customersCSV), FormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ledger := newFakeLedger()
+	ledger.wait = make(chan struct{})
+	ledger.blocked = make(chan struct{}, len(rows))
+	jobs := NewJobs()
+
+	if err := jobs.Start(New("hsb", rows, ledger, Options{})); err != nil {
+		t.Fatal(err)
+	}
+	if err := jobs.Start(New("hsb", rows, ledger, Options{})); apierror.From(err).Status != http.StatusConflict {
+		t.Errorf("starting a running import again = %v", err)
+	}
+	if _, err := jobs.Report("other"); apierror.From(err).Status != http.StatusNotFound {
+		t.Errorf("report of an unknown import = %v", err)
+	}
+
+	// closing stops the import, and the rows that were not submitted stay pending so it can be resumed
+	<-ledger.blocked
+	jobs.Close()
+	report, err := jobs.Report("hsb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Status != StatusCanceled || report.Counts[StatusPending] != 2 {
+		t.Errorf("report of a stopped import = %+v", report)
+	}
+}
+
+func TestCheckID(t *testing.T) {
+	for id, valid := range map[string]bool{"hsb-2024": true, "": false, "hsb\n": false, strings.Repeat("a", MaxIDLength+1): false} {
+		if err := CheckID(id); (err == nil) != valid {
+			t.Errorf("CheckID(%q) = %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// Chaincodes is the ledger of the customer channel. Rows are priced with the mower chaincode and the customers
+// and SLAs are created with the customer chaincode, whose transactions are retried on read conflicts.
+type Chaincodes struct {
+	Customer     *client.Contract
+	Mower        *client.Contract
+	Transactions *transaction.Tracker
+}
+
+var _ Ledger = Chaincodes{}
+
+// Evaluate prices the SLA of the row with EvaluateSLA, or EvaluateServiceSLA for SLAs given with a service type
+func (chaincodes Chaincodes) Evaluate(ctx context.Context, row Row) (decimal.Money, error) {
+	var result []byte
+	var err error
+	if row.ServiceSLA() {
+		parametersJSON, marshalErr := json.Marshal(row.ServiceParameters())
+		if marshalErr != nil {
+			return decimal.Money{}, marshalErr
+		}
+		result, err = chaincodes.Mower.EvaluateWithContext(ctx, "EvaluateServiceSLA", client.WithArguments(row.ServiceTypeOrDefault(), row.ServiceLevel, string(parametersJSON), "[]"))
+	} else {
+		result, err = chaincodes.Mower.EvaluateWithContext(ctx, "EvaluateSLA", client.WithArguments(row.ServiceLevel, row.TargetGrassLength.String(), row.MaxGrassLength.String(), row.MinGrassLength.String()))
+	}
+	if err != nil {
+		return decimal.Money{}, fmt.Errorf("failed to evaluate transaction: %w", err)
+	}
+
+	var schedule struct {
+		AnnualTotal decimal.Money `json:"AnnualTotal"`
+	}
+	err = json.Unmarshal(result, &schedule)
+	if err != nil {
+		return decimal.Money{}, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return schedule.AnnualTotal, nil
+}
+
+// CustomerExists reports whether the customer has been created
+func (chaincodes Chaincodes) CustomerExists(ctx context.Context, customerID string) (bool, error) {
+	result, err := chaincodes.Customer.EvaluateWithContext(ctx, "CustomerExist", client.WithArguments(customerID))
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate transaction: %w", err)
+	}
+	var exists bool
+	err = json.Unmarshal(result, &exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateCustomer creates the customer
+func (chaincodes Chaincodes) CreateCustomer(ctx context.Context, customerID string) error {
+	_, err := chaincodes.Transactions.Submit(ctx, chaincodes.Customer, "CreateCustomer", customerID)
+	if err != nil {
+		return fmt.Errorf("failed to submit transaction: %w", err)
+	}
+	return nil
+}
+
+// CreateSLA creates the SLA of the row with its idempotent variant. As in the C2B application, the SLA ID is
+// derived from the key, so a replay proposes the same SLA.
+func (chaincodes Chaincodes) CreateSLA(ctx context.Context, row Row, key string) (string, bool, error) {
+	slaID := uuid.NewSHA1(uuid.NameSpaceURL, []byte("sla:"+row.CustomerID+":"+key)).String()
+
+	var result []byte
+	var record *transaction.Record
+	var err error
+	if row.ServiceSLA() {
+		parametersJSON, marshalErr := json.Marshal(row.ServiceParameters())
+		if marshalErr != nil {
+			return "", false, marshalErr
+		}
+		result, record, err = chaincodes.Transactions.SubmitIdempotent(ctx, chaincodes.Customer, "CreateServiceSLA", key, row.CustomerID, slaID, row.PropertyID, row.ServiceTypeOrDefault(), row.ServiceLevel, string(parametersJSON))
+	} else {
+		result, record, err = chaincodes.Transactions.SubmitIdempotent(ctx, chaincodes.Customer, "CreateSLA", key, row.CustomerID, slaID, row.ServiceLevel, row.TargetGrassLength.String(), row.MaxGrassLength.String(), row.MinGrassLength.String())
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to submit transaction: %w", err)
+	}
+
+	var sla struct {
+		ID string `json:"ID"`
+	}
+	err = json.Unmarshal(result, &sla)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return sla.ID, record.Replayed, nil
+}
@@ -0,0 +1,65 @@
+package bulk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+)
+
+// Jobs are the imports an application runs in the background. Their reports are kept in memory, so they are lost
+// when the application restarts, but an import started again with the same ID and rows resumes where it stopped.
+type Jobs struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	imports map[string]*Import
+}
+
+// NewJobs creates the jobs, which run until they are closed
+func NewJobs() *Jobs {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Jobs{ctx: ctx, cancel: cancel, imports: make(map[string]*Import)}
+}
+
+// Start runs the import in the background. An import with the ID that is still running is a conflict, the report
+// of one that has finished is replaced.
+func (jobs *Jobs) Start(imp *Import) error {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+
+	if running, ok := jobs.imports[imp.ID()]; ok && running.Report().Status == StatusRunning {
+		return apierror.New(http.StatusConflict, apierror.CodeConflict, "import "+imp.ID()+" is still running")
+	}
+	if jobs.ctx.Err() != nil {
+		return apierror.New(http.StatusServiceUnavailable, apierror.CodeInternal, "the application is shutting down")
+	}
+	jobs.imports[imp.ID()] = imp
+
+	jobs.wg.Add(1)
+	go func() {
+		defer jobs.wg.Done()
+		imp.Run(jobs.ctx)
+	}()
+	return nil
+}
+
+// Report returns the report of the import with the ID, a not found error when there is none
+func (jobs *Jobs) Report(id string) (Report, error) {
+	jobs.mu.Lock()
+	imp, ok := jobs.imports[id]
+	jobs.mu.Unlock()
+	if !ok {
+		return Report{}, apierror.NotFound("import " + id + " not found")
+	}
+	return imp.Report(), nil
+}
+
+// Close stops the running imports, whose rows that were not submitted yet stay pending, and waits for them
+func (jobs *Jobs) Close() {
+	jobs.cancel()
+	jobs.wg.Wait()
+}
@@ -0,0 +1,163 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// Formats of the rows of an import
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// ContentTypes are the media types of the formats
+var ContentTypes = map[string]string{
+	"text/csv":             FormatCSV,
+	"application/x-ndjson": FormatNDJSON,
+}
+
+// maxLineLength is the longest NDJSON line that is read
+const maxLineLength = 1 << 20
+
+// Parse reads the rows in the format. A row that cannot be read, such as one with an invalid decimal, is
+// returned with its error and reported as invalid by the import, only a file that cannot be read at all is an
+// error. Blank lines are skipped.
+//
+// CSV has a header with the columns of the rows, matched to the fields of Row without regard to case, and every
+// other column is a parameter of the service type of the SLA. Empty cells are left out. NDJSON has a Row object
+// on every line.
+func Parse(r io.Reader, format string) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatNDJSON:
+		return parseNDJSON(r)
+	}
+	return nil, fmt.Errorf("unknown format %q, use %s or %s", format, FormatCSV, FormatNDJSON)
+}
+
+func parseCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("the CSV has no header")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	columns := make([]string, len(header))
+	hasCustomer := false
+	for i, column := range header {
+		columns[i] = strings.TrimSpace(column)
+		if strings.EqualFold(columns[i], "CustomerID") {
+			hasCustomer = true
+		}
+	}
+	if !hasCustomer {
+		return nil, fmt.Errorf("the CSV has no CustomerID column")
+	}
+
+	rows := []Row{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			// quotes that do not match make the rest of the file unreadable
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line}
+		if len(record) != len(columns) {
+			row.invalid = fmt.Errorf("the row has %d cells and the header %d", len(record), len(columns))
+			rows = append(rows, row)
+			continue
+		}
+
+		for i, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			if err := row.set(columns[i], cell); err != nil {
+				row.invalid = err
+				break
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// set sets the field of the CSV column to the cell
+func (row *Row) set(column string, cell string) error {
+	text := map[string]*string{
+		"key":          &row.Key,
+		"customerid":   &row.CustomerID,
+		"propertyid":   &row.PropertyID,
+		"servicetype":  &row.ServiceType,
+		"servicelevel": &row.ServiceLevel,
+	}
+	if field, ok := text[strings.ToLower(column)]; ok {
+		*field = cell
+		return nil
+	}
+
+	value, err := decimal.Parse(cell)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", column, cell, err)
+	}
+	lengths := map[string]*decimal.Decimal{
+		"targetgrasslength": &row.TargetGrassLength,
+		"maxgrasslength":    &row.MaxGrassLength,
+		"mingrasslength":    &row.MinGrassLength,
+	}
+	if field, ok := lengths[strings.ToLower(column)]; ok {
+		*field = value
+		return nil
+	}
+
+	if row.Parameters == nil {
+		row.Parameters = make(map[string]decimal.Decimal)
+	}
+	row.Parameters[column] = value
+	return nil
+}
+
+func parseNDJSON(r io.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+
+	rows := []Row{}
+	for line := 1; scanner.Scan(); line++ {
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+
+		row := Row{}
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&row); err != nil {
+			row = Row{invalid: fmt.Errorf("invalid row: %w", err)}
+		}
+		row.Line = line
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid NDJSON: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,621 @@
+// Package config loads the identity, peer, timeouts and chaincode names a REST application runs with.
+// Settings come from the defaults of the application, then from a profile of a YAML file and last from
+// environment variables, so one binary can run as any organisation that has a profile.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nalle631/fabric-network/application/shared/auth"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/health"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/nalle631/fabric-network/application/shared/wallet"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of one organisation profile
+type Config struct {
+	Profile     string               `yaml:"-"`
+	Development bool                 `yaml:"development"`
+	Server      Server               `yaml:"server"`
+	Identity    Identity             `yaml:"identity"`
+	Peer        Peer                 `yaml:"peer"`
+	Timeouts    Timeouts             `yaml:"timeouts"`
+	Retry       Retry                `yaml:"retry"`
+	Chaincodes  map[string]Chaincode `yaml:"chaincodes"`
+	Auth        Auth                 `yaml:"auth"`
+	Wallet      Wallet               `yaml:"wallet"`
+	Health      Health               `yaml:"health"`
+	Settlement  Settlement           `yaml:"settlement"`
+	Callbacks   Callbacks            `yaml:"callbacks"`
+	BreachRelay BreachRelay          `yaml:"breachRelay"`
+}
+
+// Server is where the REST API listens
+type Server struct {
+	Address string `yaml:"address"`
+}
+
+// Identity is the enrolled identity transactions are signed with. The key path is a key file or an MSP keystore
+// directory, unless the signer is pkcs11 and the key is in an HSM.
+type Identity struct {
+	MSPID    string `yaml:"mspID"`
+	CertPath string `yaml:"certPath"`
+	KeyPath  string `yaml:"keyPath"`
+	Signer   string `yaml:"signer"`
+	PKCS11   PKCS11 `yaml:"pkcs11"`
+}
+
+// PKCS11 is the HSM token holding the private key of the identity, under the subject key identifier of its
+// certificate. The pin can only be set with FABRIC_PKCS11_PIN. Sessions is how many digests are signed at once.
+type PKCS11 struct {
+	Library  string `yaml:"library"`
+	Label    string `yaml:"label"`
+	Pin      string `yaml:"-"`
+	Sessions int    `yaml:"sessions"`
+}
+
+// Peer is the gateway peer and how its TLS certificate is verified
+type Peer struct {
+	Endpoint string `yaml:"endpoint"`
+	TLS      TLS    `yaml:"tls"`
+}
+
+// TLS verifies the peer against the CA certificate, with the host name of the peer when it differs from the endpoint
+type TLS struct {
+	Enabled      bool   `yaml:"enabled"`
+	CACertPath   string `yaml:"caCertPath"`
+	HostOverride string `yaml:"hostOverride"`
+}
+
+// Timeouts of the gateway calls. AsyncCommit is how long the commit of a transaction submitted with
+// Prefer: respond-async is followed, where CommitStatus is how long a request waits for its commit.
+type Timeouts struct {
+	Evaluate     time.Duration `yaml:"evaluate"`
+	Endorse      time.Duration `yaml:"endorse"`
+	Submit       time.Duration `yaml:"submit"`
+	CommitStatus time.Duration `yaml:"commitStatus"`
+	AsyncCommit  time.Duration `yaml:"asyncCommit"`
+}
+
+// Retry is how often a transaction that failed to commit on a read conflict is submitted, and how long the
+// retries wait at first and at most. Zero values are the defaults of the transaction package.
+type Retry struct {
+	MaxAttempts    int           `yaml:"maxAttempts"`
+	InitialBackoff time.Duration `yaml:"initialBackoff"`
+	MaxBackoff     time.Duration `yaml:"maxBackoff"`
+}
+
+// Chaincode is where a chaincode the application uses is deployed
+type Chaincode struct {
+	Channel string `yaml:"channel"`
+	Name    string `yaml:"name"`
+}
+
+// DefaultPath is the configuration file used when no other file is given, if it exists
+const DefaultPath = "config.yaml"
+
+// Auth is how the bearer tokens of users are verified. The HMAC secret can only be set with FABRIC_AUTH_HMAC_SECRET.
+// Authentication can only be disabled in a development profile, see Guard.
+type Auth struct {
+	Enabled       bool   `yaml:"enabled"`
+	JWKSURL       string `yaml:"jwksURL"`
+	PublicKeyPath string `yaml:"publicKeyPath"`
+	HMACSecret    string `yaml:"-"`
+	Issuer        string `yaml:"issuer"`
+	Audience      string `yaml:"audience"`
+	RolesClaim    string `yaml:"rolesClaim"`
+	IdentityClaim string `yaml:"identityClaim"`
+}
+
+// Wallet is the directory holding the Fabric identities of the users
+type Wallet struct {
+	Path string `yaml:"path"`
+}
+
+// Health is what the readiness probe checks. The chaincodes are evaluated on the peers of each organisation, so
+// the application is degraded when only some of them answer, or on the peers the gateway chooses without any.
+// A certificate that expires within the warning makes the application degraded.
+type Health struct {
+	Organizations      []string      `yaml:"organizations"`
+	CertificateWarning time.Duration `yaml:"certificateWarning"`
+}
+
+// Settlement is how the settlement service of the B2B application pays out closed periods through the token-sdk
+// issuer at IssuerURL. A zero interval only pays out on request. The token code is the currency of a settlement
+// unless TokenCode is set.
+type Settlement struct {
+	Interval  time.Duration `yaml:"interval"`
+	IssuerURL string        `yaml:"issuerURL"`
+	TokenCode string        `yaml:"tokenCode"`
+}
+
+// Callbacks are the hosts the final status of an asynchronous transaction may be posted to with a Callback-URL
+// header, host names or *.domain for the subdomains of a domain. Any host is allowed when none are listed, but
+// callbacks are never posted to loopback, link-local or private addresses.
+type Callbacks struct {
+	Hosts []string `yaml:"hosts"`
+}
+
+// BreachRelay is whether the C2B application relays the SLA breaches of the customer channel to the technician
+// channel as job offers, which needs an identity with the service owner role, and the file that keeps track of the
+// last relayed breach. Every running application needs a checkpoint file of its own.
+type BreachRelay struct {
+	Enabled    bool   `yaml:"enabled"`
+	Checkpoint string `yaml:"checkpoint"`
+}
+
+// file is the layout of a configuration file, a set of named profiles and the one used when none is chosen
+type file struct {
+	DefaultProfile string               `yaml:"defaultProfile"`
+	Profiles       map[string]yaml.Node `yaml:"profiles"`
+}
+
+// Load returns the configuration of the profile. The defaults are overridden by the profile in the file at path
+// and then by environment variables. An empty path is taken from FABRIC_CONFIG or is DefaultPath if that file
+// exists, and an empty profile is taken from FABRIC_PROFILE or the default profile of the file. The result is validated.
+func Load(path string, profile string, defaults Config) (*Config, error) {
+	if path == "" {
+		path = os.Getenv("FABRIC_CONFIG")
+	}
+	if path == "" {
+		if _, err := os.Stat(DefaultPath); err == nil {
+			path = DefaultPath
+		}
+	}
+	if profile == "" {
+		profile = os.Getenv("FABRIC_PROFILE")
+	}
+
+	config := defaults
+	config.Chaincodes = make(map[string]Chaincode)
+	for name, chaincode := range defaults.Chaincodes {
+		config.Chaincodes[name] = chaincode
+	}
+
+	if path != "" {
+		err := loadFile(path, profile, &config)
+		if err != nil {
+			return nil, err
+		}
+	} else if profile != "" {
+		return nil, fmt.Errorf("profile %s was chosen without a configuration file", profile)
+	}
+
+	err := applyEnv(&config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func loadFile(path string, profile string, config *Config) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var f file
+	err = yaml.Unmarshal(content, &f)
+	if err != nil {
+		return fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+
+	if profile == "" {
+		profile = f.DefaultProfile
+	}
+	if profile == "" && len(f.Profiles) == 1 {
+		for name := range f.Profiles {
+			profile = name
+		}
+	}
+	if profile == "" {
+		return fmt.Errorf("%s has several profiles and no defaultProfile, choose one of %s", path, strings.Join(profileNames(f), ", "))
+	}
+
+	node, ok := f.Profiles[profile]
+	if !ok {
+		return fmt.Errorf("%s has no profile %s, choose one of %s", path, profile, strings.Join(profileNames(f), ", "))
+	}
+
+	// fields the profile leaves out keep their defaults, which for chaincodes means decoding every entry
+	// on its own since a map entry is replaced as a whole
+	chaincodes := make(map[string]Chaincode)
+	for name, chaincode := range config.Chaincodes {
+		chaincodes[name] = chaincode
+	}
+	err = node.Decode(config)
+	if err != nil {
+		return fmt.Errorf("invalid profile %s in %s: %w", profile, path, err)
+	}
+
+	var chaincodeNodes struct {
+		Chaincodes map[string]yaml.Node `yaml:"chaincodes"`
+	}
+	err = node.Decode(&chaincodeNodes)
+	if err != nil {
+		return fmt.Errorf("invalid profile %s in %s: %w", profile, path, err)
+	}
+	for name, chaincodeNode := range chaincodeNodes.Chaincodes {
+		chaincode := chaincodes[name]
+		err = chaincodeNode.Decode(&chaincode)
+		if err != nil {
+			return fmt.Errorf("invalid chaincode %s of profile %s in %s: %w", name, profile, path, err)
+		}
+		chaincodes[name] = chaincode
+	}
+	config.Chaincodes = chaincodes
+	config.Profile = profile
+	return nil
+}
+
+func profileNames(f file) []string {
+	names := []string{}
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyEnv overrides the configuration with the FABRIC_ environment variables. The channel and name of a chaincode
+// are set with FABRIC_CHAINCODE_<NAME>_CHANNEL and FABRIC_CHAINCODE_<NAME>_NAME, where NAME is its key in upper case.
+func applyEnv(config *Config) error {
+	settings := map[string]*string{
+		"FABRIC_LISTEN_ADDRESS":          &config.Server.Address,
+		"FABRIC_MSP_ID":                  &config.Identity.MSPID,
+		"FABRIC_CERT_PATH":               &config.Identity.CertPath,
+		"FABRIC_KEY_PATH":                &config.Identity.KeyPath,
+		"FABRIC_SIGNER":                  &config.Identity.Signer,
+		"FABRIC_PKCS11_LIBRARY":          &config.Identity.PKCS11.Library,
+		"FABRIC_PKCS11_LABEL":            &config.Identity.PKCS11.Label,
+		"FABRIC_PKCS11_PIN":              &config.Identity.PKCS11.Pin,
+		"FABRIC_PEER_ENDPOINT":           &config.Peer.Endpoint,
+		"FABRIC_TLS_CA_CERT_PATH":        &config.Peer.TLS.CACertPath,
+		"FABRIC_TLS_HOST_OVERRIDE":       &config.Peer.TLS.HostOverride,
+		"FABRIC_AUTH_JWKS_URL":           &config.Auth.JWKSURL,
+		"FABRIC_AUTH_PUBLIC_KEY":         &config.Auth.PublicKeyPath,
+		"FABRIC_AUTH_HMAC_SECRET":        &config.Auth.HMACSecret,
+		"FABRIC_AUTH_ISSUER":             &config.Auth.Issuer,
+		"FABRIC_AUTH_AUDIENCE":           &config.Auth.Audience,
+		"FABRIC_WALLET_PATH":             &config.Wallet.Path,
+		"FABRIC_SETTLEMENT_ISSUER_URL":   &config.Settlement.IssuerURL,
+		"FABRIC_SETTLEMENT_TOKEN_CODE":   &config.Settlement.TokenCode,
+		"FABRIC_BREACH_RELAY_CHECKPOINT": &config.BreachRelay.Checkpoint,
+	}
+	for name, field := range settings {
+		if value, ok := os.LookupEnv(name); ok {
+			*field = value
+		}
+	}
+
+	flags := map[string]*bool{
+		"FABRIC_TLS_ENABLED":          &config.Peer.TLS.Enabled,
+		"FABRIC_AUTH_ENABLED":         &config.Auth.Enabled,
+		"FABRIC_DEVELOPMENT":          &config.Development,
+		"FABRIC_BREACH_RELAY_ENABLED": &config.BreachRelay.Enabled,
+	}
+	for name, field := range flags {
+		if value, ok := os.LookupEnv(name); ok {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, value, err)
+			}
+			*field = enabled
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"FABRIC_EVALUATE_TIMEOUT":           &config.Timeouts.Evaluate,
+		"FABRIC_ENDORSE_TIMEOUT":            &config.Timeouts.Endorse,
+		"FABRIC_SUBMIT_TIMEOUT":             &config.Timeouts.Submit,
+		"FABRIC_COMMIT_STATUS_TIMEOUT":      &config.Timeouts.CommitStatus,
+		"FABRIC_ASYNC_COMMIT_TIMEOUT":       &config.Timeouts.AsyncCommit,
+		"FABRIC_RETRY_INITIAL_BACKOFF":      &config.Retry.InitialBackoff,
+		"FABRIC_RETRY_MAX_BACKOFF":          &config.Retry.MaxBackoff,
+		"FABRIC_HEALTH_CERTIFICATE_WARNING": &config.Health.CertificateWarning,
+		"FABRIC_SETTLEMENT_INTERVAL":        &config.Settlement.Interval,
+	}
+	for name, field := range durations {
+		if value, ok := os.LookupEnv(name); ok {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, value, err)
+			}
+			*field = duration
+		}
+	}
+
+	lists := map[string]*[]string{
+		"FABRIC_HEALTH_ORGANIZATIONS": &config.Health.Organizations,
+		"FABRIC_CALLBACK_HOSTS":       &config.Callbacks.Hosts,
+	}
+	for name, field := range lists {
+		if value, ok := os.LookupEnv(name); ok {
+			*field = nil
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					*field = append(*field, item)
+				}
+			}
+		}
+	}
+
+	numbers := map[string]*int{
+		"FABRIC_RETRY_MAX_ATTEMPTS": &config.Retry.MaxAttempts,
+		"FABRIC_PKCS11_SESSIONS":    &config.Identity.PKCS11.Sessions,
+	}
+	for name, field := range numbers {
+		if value, ok := os.LookupEnv(name); ok {
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", name, value, err)
+			}
+			*field = number
+		}
+	}
+
+	for name, chaincode := range config.Chaincodes {
+		prefix := "FABRIC_CHAINCODE_" + strings.ToUpper(name) + "_"
+		if value, ok := os.LookupEnv(prefix + "CHANNEL"); ok {
+			chaincode.Channel = value
+		}
+		if value, ok := os.LookupEnv(prefix + "NAME"); ok {
+			chaincode.Name = value
+		}
+		config.Chaincodes[name] = chaincode
+	}
+
+	return nil
+}
+
+// Validate checks that every setting is present and that the identity and TLS files exist,
+// and reports every problem at once
+func (config Config) Validate() error {
+	var errs []error
+	require := func(value string, setting string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is not set", setting))
+		}
+	}
+	exists := func(path string, setting string) {
+		if path == "" {
+			errs = append(errs, fmt.Errorf("%s is not set", setting))
+			return
+		}
+		_, err := os.Stat(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", setting, err))
+		}
+	}
+
+	require(config.Server.Address, "server.address")
+	require(config.Identity.MSPID, "identity.mspID")
+	exists(config.Identity.CertPath, "identity.certPath")
+	switch config.Identity.Signer {
+	case "", fabric.SignerFile:
+		exists(config.Identity.KeyPath, "identity.keyPath")
+	case fabric.SignerPKCS11:
+		exists(config.Identity.PKCS11.Library, "identity.pkcs11.library")
+		require(config.Identity.PKCS11.Label, "identity.pkcs11.label")
+		require(config.Identity.PKCS11.Pin, "FABRIC_PKCS11_PIN")
+		if config.Identity.PKCS11.Sessions < 0 {
+			errs = append(errs, fmt.Errorf("identity.pkcs11.sessions cannot be negative"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("identity.signer %q is neither %s nor %s", config.Identity.Signer, fabric.SignerFile, fabric.SignerPKCS11))
+	}
+
+	if config.Peer.Endpoint == "" {
+		errs = append(errs, fmt.Errorf("peer.endpoint is not set"))
+	} else if _, _, err := net.SplitHostPort(config.Peer.Endpoint); err != nil {
+		errs = append(errs, fmt.Errorf("peer.endpoint %q is not host:port", config.Peer.Endpoint))
+	}
+	if config.Peer.TLS.Enabled {
+		exists(config.Peer.TLS.CACertPath, "peer.tls.caCertPath")
+	}
+
+	timeouts := map[string]time.Duration{
+		"timeouts.evaluate":         config.Timeouts.Evaluate,
+		"timeouts.endorse":          config.Timeouts.Endorse,
+		"timeouts.submit":           config.Timeouts.Submit,
+		"timeouts.commitStatus":     config.Timeouts.CommitStatus,
+		"timeouts.asyncCommit":      config.Timeouts.AsyncCommit,
+		"retry.initialBackoff":      config.Retry.InitialBackoff,
+		"retry.maxBackoff":          config.Retry.MaxBackoff,
+		"health.certificateWarning": config.Health.CertificateWarning,
+		"settlement.interval":       config.Settlement.Interval,
+	}
+	for setting, timeout := range timeouts {
+		if timeout < 0 {
+			errs = append(errs, fmt.Errorf("%s cannot be negative", setting))
+		}
+	}
+	if config.Retry.MaxAttempts < 0 {
+		errs = append(errs, fmt.Errorf("retry.maxAttempts cannot be negative"))
+	}
+
+	if config.Auth.Enabled {
+		sources := 0
+		for _, source := range []string{config.Auth.JWKSURL, config.Auth.PublicKeyPath, config.Auth.HMACSecret} {
+			if source != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			errs = append(errs, fmt.Errorf("auth needs exactly one of auth.jwksURL, auth.publicKeyPath and FABRIC_AUTH_HMAC_SECRET"))
+		}
+		if config.Auth.PublicKeyPath != "" {
+			exists(config.Auth.PublicKeyPath, "auth.publicKeyPath")
+		}
+		require(config.Wallet.Path, "wallet.path")
+	}
+
+	if config.Settlement.IssuerURL != "" {
+		issuerURL, err := url.Parse(config.Settlement.IssuerURL)
+		if err != nil || (issuerURL.Scheme != "http" && issuerURL.Scheme != "https") || issuerURL.Host == "" {
+			errs = append(errs, fmt.Errorf("settlement.issuerURL %q is not an http or https URL", config.Settlement.IssuerURL))
+		}
+	}
+
+	if config.BreachRelay.Enabled {
+		require(config.BreachRelay.Checkpoint, "breachRelay.checkpoint")
+	}
+
+	for _, host := range config.Callbacks.Hosts {
+		if host == "" || strings.ContainsAny(strings.TrimPrefix(host, "*."), "*/:@ ") {
+			errs = append(errs, fmt.Errorf("callbacks.hosts %q is neither a host name nor *.domain", host))
+		}
+	}
+
+	for name, chaincode := range config.Chaincodes {
+		require(chaincode.Channel, "chaincodes."+name+".channel")
+		require(chaincode.Name, "chaincodes."+name+".name")
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+
+	return fmt.Errorf("invalid configuration of profile %s:\n%w", config.profileName(), errors.Join(errs...))
+}
+
+// profileName returns the name of the profile, or default when the configuration holds only the defaults
+func (config Config) profileName() string {
+	if config.Profile == "" {
+		return "default"
+	}
+	return config.Profile
+}
+
+// Chaincode returns where the chaincode with the key is deployed. Unknown keys are a programming error.
+func (config Config) Chaincode(name string) Chaincode {
+	chaincode, ok := config.Chaincodes[name]
+	if !ok {
+		panic(fmt.Sprintf("chaincode %s is not configured", name))
+	}
+	return chaincode
+}
+
+// AuthOptions returns the settings tokens are verified with
+func (config Config) AuthOptions() auth.Options {
+	return auth.Options{
+		JWKSURL:       config.Auth.JWKSURL,
+		PublicKeyPath: config.Auth.PublicKeyPath,
+		HMACSecret:    config.Auth.HMACSecret,
+		Issuer:        config.Auth.Issuer,
+		Audience:      config.Auth.Audience,
+		RolesClaim:    config.Auth.RolesClaim,
+		IdentityClaim: config.Auth.IdentityClaim,
+	}
+}
+
+// TrackerOptions returns the settings transactions are retried and submitted asynchronously with, and the hosts
+// their callbacks may be posted to
+func (config Config) TrackerOptions() transaction.Options {
+	return transaction.Options{
+		CommitTimeout: config.Timeouts.AsyncCommit,
+		CallbackHosts: config.Callbacks.Hosts,
+		Retry: transaction.RetryPolicy{
+			MaxAttempts:    config.Retry.MaxAttempts,
+			InitialBackoff: config.Retry.InitialBackoff,
+			MaxBackoff:     config.Retry.MaxBackoff,
+		},
+	}
+}
+
+// Guard returns the guard that authenticates the requests of the application and transacts as the identities
+// of its users in the wallet, or nil when authentication is disabled so every request uses the gateway identity.
+// Since anyone who reaches the application then transacts as the organisation, that is refused unless the
+// profile is marked as a development profile.
+func (config Config) Guard(gateway *fabric.Gateway) (*auth.Guard, error) {
+	if !config.Auth.Enabled {
+		if !config.Development {
+			return nil, fmt.Errorf("authentication is disabled in profile %s, which is only allowed with development: true or FABRIC_DEVELOPMENT=true", config.profileName())
+		}
+		return nil, nil
+	}
+
+	verifier, err := auth.NewVerifier(config.AuthOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := wallet.NewFileStore(config.Wallet.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return auth.NewGuard(verifier, gateway, store), nil
+}
+
+// Readiness returns the checks of the readiness probe: the connection to the gateway peer, an evaluation of
+// every chaincode, and the expiry of the identity certificate and, with TLS, of the CA certificate of the peer.
+// The checks together take up to the evaluate timeout.
+func (config Config) Readiness(gateway *fabric.Gateway) *health.Checker {
+	warning := config.Health.CertificateWarning
+	if warning == 0 {
+		warning = health.DefaultCertificateWarning
+	}
+
+	checks := []health.Check{health.Gateway(gateway)}
+	names := []string{}
+	for name := range config.Chaincodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		chaincode := config.Chaincodes[name]
+		contract := gateway.Contract(chaincode.Channel, chaincode.Name)
+		checks = append(checks, health.Chaincode(name, health.ContractEvaluator(contract), config.Health.Organizations))
+	}
+
+	checks = append(checks, health.Certificate("identity", config.Identity.CertPath, warning))
+	if config.Peer.TLS.Enabled {
+		checks = append(checks, health.Certificate("tls ca", config.Peer.TLS.CACertPath, warning))
+	}
+	return health.NewChecker(config.Timeouts.Evaluate, checks...)
+}
+
+// Gateway returns the gateway settings of the configuration
+func (config Config) Gateway() fabric.Config {
+	var hsm *fabric.HSMOptions
+	if config.Identity.Signer == fabric.SignerPKCS11 {
+		hsm = &fabric.HSMOptions{
+			Library:  config.Identity.PKCS11.Library,
+			Label:    config.Identity.PKCS11.Label,
+			Pin:      config.Identity.PKCS11.Pin,
+			Sessions: config.Identity.PKCS11.Sessions,
+		}
+	}
+
+	return fabric.Config{
+		MSPID:               config.Identity.MSPID,
+		CertPath:            config.Identity.CertPath,
+		KeyPath:             config.Identity.KeyPath,
+		PeerEndpoint:        config.Peer.Endpoint,
+		TLSDisabled:         !config.Peer.TLS.Enabled,
+		TLSCertPath:         config.Peer.TLS.CACertPath,
+		GatewayPeer:         config.Peer.TLS.HostOverride,
+		HSM:                 hsm,
+		EvaluateTimeout:     config.Timeouts.Evaluate,
+		EndorseTimeout:      config.Timeouts.Endorse,
+		SubmitTimeout:       config.Timeouts.Submit,
+		CommitStatusTimeout: config.Timeouts.CommitStatus,
+	}
+}
@@ -0,0 +1,339 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testDefaults returns defaults whose identity and TLS files exist in a temporary directory
+func testDefaults(t *testing.T) Config {
+	dir := t.TempDir()
+	for _, name := range []string{"cert.pem", "key.pem", "ca.pem"} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte("test"), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return Config{
+		Server: Server{Address: ":5001"},
+		Identity: Identity{
+			MSPID:    "Org1MSP",
+			CertPath: filepath.Join(dir, "cert.pem"),
+			KeyPath:  filepath.Join(dir, "key.pem"),
+		},
+		Peer: Peer{
+			Endpoint: "localhost:7051",
+			TLS:      TLS{Enabled: true, CACertPath: filepath.Join(dir, "ca.pem"), HostOverride: "peer0.org1.example.com"},
+		},
+		Chaincodes: map[string]Chaincode{
+			"customer": {Channel: "customer", Name: "customer"},
+			"mower":    {Channel: "customer", Name: "mower"},
+		},
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const profiles = `
+defaultProfile: org1
+profiles:
+  org1:
+    timeouts:
+      endorse: 20s
+  org2:
+    server:
+      address: ":5002"
+    identity:
+      mspID: Org2MSP
+    peer:
+      endpoint: localhost:9051
+    chaincodes:
+      mower:
+        name: mower2
+`
+
+func TestLoadDefaults(t *testing.T) {
+	defaults := testDefaults(t)
+
+	config, err := Load("", "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Identity.MSPID != "Org1MSP" || config.Chaincode("mower").Name != "mower" {
+		t.Fatalf("Load() = %+v, want the defaults", config)
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	defaults := testDefaults(t)
+	path := writeFile(t, profiles)
+
+	config, err := Load(path, "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Profile != "org1" || config.Timeouts.Endorse != 20*time.Second || config.Identity.MSPID != "Org1MSP" {
+		t.Fatalf("default profile = %+v", config)
+	}
+
+	config, err = Load(path, "org2", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Identity.MSPID != "Org2MSP" || config.Peer.Endpoint != "localhost:9051" || config.Server.Address != ":5002" {
+		t.Fatalf("profile org2 = %+v", config)
+	}
+	if config.Chaincode("mower") != (Chaincode{Channel: "customer", Name: "mower2"}) {
+		t.Fatalf("mower chaincode = %+v, want the channel from the defaults and the name from the profile", config.Chaincode("mower"))
+	}
+	if config.Peer.TLS.HostOverride != "peer0.org1.example.com" {
+		t.Fatalf("the TLS settings the profile leaves out must keep their defaults, got %+v", config.Peer.TLS)
+	}
+	if defaults.Chaincodes["mower"].Name != "mower" {
+		t.Fatal("loading a profile changed the defaults")
+	}
+
+	_, err = Load(path, "org3", defaults)
+	if err == nil || !strings.Contains(err.Error(), "choose one of org1, org2") {
+		t.Fatalf("unknown profile error = %v", err)
+	}
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	defaults := testDefaults(t)
+	path := writeFile(t, profiles)
+	t.Setenv("FABRIC_PROFILE", "org2")
+	t.Setenv("FABRIC_MSP_ID", "Org3MSP")
+	t.Setenv("FABRIC_SUBMIT_TIMEOUT", "7s")
+	t.Setenv("FABRIC_ASYNC_COMMIT_TIMEOUT", "5m")
+	t.Setenv("FABRIC_RETRY_MAX_ATTEMPTS", "6")
+	t.Setenv("FABRIC_RETRY_MAX_BACKOFF", "3s")
+	t.Setenv("FABRIC_HEALTH_ORGANIZATIONS", "Org1MSP, Org2MSP")
+	t.Setenv("FABRIC_DEVELOPMENT", "true")
+	t.Setenv("FABRIC_CHAINCODE_CUSTOMER_CHANNEL", "customers")
+
+	config, err := Load(path, "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Profile != "org2" || config.Identity.MSPID != "Org3MSP" || config.Timeouts.Submit != 7*time.Second {
+		t.Fatalf("Load() = %+v, want the environment to override the profile", config)
+	}
+	if options := config.TrackerOptions(); options.CommitTimeout != 5*time.Minute || options.Retry.MaxAttempts != 6 || options.Retry.MaxBackoff != 3*time.Second {
+		t.Fatalf("tracker options = %+v", config.TrackerOptions())
+	}
+	if len(config.Health.Organizations) != 2 || config.Health.Organizations[1] != "Org2MSP" {
+		t.Fatalf("health organizations = %q", config.Health.Organizations)
+	}
+	if !config.Development {
+		t.Fatal("FABRIC_DEVELOPMENT did not mark the profile as a development profile")
+	}
+	if config.Chaincode("customer").Channel != "customers" {
+		t.Fatalf("customer chaincode = %+v", config.Chaincode("customer"))
+	}
+
+	t.Setenv("FABRIC_EVALUATE_TIMEOUT", "soon")
+	_, err = Load(path, "", defaults)
+	if err == nil || !strings.Contains(err.Error(), "FABRIC_EVALUATE_TIMEOUT") {
+		t.Fatalf("invalid timeout error = %v", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	defaults := testDefaults(t)
+	defaults.Identity.MSPID = ""
+	defaults.Identity.KeyPath = filepath.Join(t.TempDir(), "missing")
+	defaults.Peer.Endpoint = "localhost"
+	defaults.Chaincodes["mower"] = Chaincode{Channel: "customer"}
+
+	_, err := Load("", "", defaults)
+	if err == nil {
+		t.Fatal("Load() of an invalid configuration returned no error")
+	}
+	for _, want := range []string{"identity.mspID is not set", "identity.keyPath", "peer.endpoint \"localhost\" is not host:port", "chaincodes.mower.name is not set"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	defaults = testDefaults(t)
+	defaults.Peer.TLS = TLS{Enabled: false}
+	_, err = Load("", "", defaults)
+	if err != nil {
+		t.Fatalf("TLS settings must not be required when TLS is disabled: %v", err)
+	}
+}
+
+func TestAuthNeedsOneKeySourceAndAWallet(t *testing.T) {
+	defaults := testDefaults(t)
+	t.Setenv("FABRIC_AUTH_ENABLED", "true")
+
+	_, err := Load("", "", defaults)
+	if err == nil || !strings.Contains(err.Error(), "wallet.path") || !strings.Contains(err.Error(), "auth.jwksURL") {
+		t.Fatalf("Load() without a key source and wallet = %v", err)
+	}
+
+	t.Setenv("FABRIC_AUTH_HMAC_SECRET", "secret")
+	t.Setenv("FABRIC_WALLET_PATH", filepath.Join(t.TempDir(), "wallet"))
+	config, err := Load("", "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	guard, err := config.Guard(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !guard.Enabled() {
+		t.Fatal("Guard() of an enabled configuration does not authenticate requests")
+	}
+
+	config.Auth.Enabled = false
+	_, err = config.Guard(nil)
+	if err == nil || !strings.Contains(err.Error(), "development") {
+		t.Fatalf("Guard() of a disabled configuration outside development = %v", err)
+	}
+
+	config.Development = true
+	guard, err = config.Guard(nil)
+	if err != nil || guard.Enabled() {
+		t.Fatalf("Guard() of a disabled configuration = %v, %v", guard, err)
+	}
+}
+
+func TestPKCS11SignerNeedsAToken(t *testing.T) {
+	defaults := testDefaults(t)
+	defaults.Identity.KeyPath = ""
+	t.Setenv("FABRIC_SIGNER", "pkcs11")
+
+	_, err := Load("", "", defaults)
+	if err == nil {
+		t.Fatal("Load() of a pkcs11 signer without a token returned no error")
+	}
+	for _, want := range []string{"identity.pkcs11.library", "identity.pkcs11.label", "FABRIC_PKCS11_PIN"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "identity.keyPath") {
+		t.Errorf("the key path must not be required with an HSM: %v", err)
+	}
+
+	t.Setenv("FABRIC_PKCS11_LIBRARY", defaults.Identity.CertPath)
+	t.Setenv("FABRIC_PKCS11_LABEL", "ForFabric")
+	t.Setenv("FABRIC_PKCS11_PIN", "98765432")
+	t.Setenv("FABRIC_PKCS11_SESSIONS", "2")
+	config, err := Load("", "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hsm := config.Gateway().HSM
+	if hsm == nil || hsm.Label != "ForFabric" || hsm.Pin != "98765432" || hsm.Sessions != 2 {
+		t.Fatalf("Gateway().HSM = %+v", hsm)
+	}
+
+	t.Setenv("FABRIC_SIGNER", "yubikey")
+	_, err = Load("", "", defaults)
+	if err == nil || !strings.Contains(err.Error(), "identity.signer") {
+		t.Fatalf("unknown signer error = %v", err)
+	}
+}
+
+func TestSettlement(t *testing.T) {
+	defaults := testDefaults(t)
+	path := writeFile(t, `
+profiles:
+  org1:
+    settlement:
+      interval: 1h
+      issuerURL: http://issuer:9100/api/v1
+`)
+
+	config, err := Load(path, "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Settlement.Interval != time.Hour || config.Settlement.IssuerURL != "http://issuer:9100/api/v1" {
+		t.Fatalf("settlement = %+v", config.Settlement)
+	}
+
+	t.Setenv("FABRIC_SETTLEMENT_INTERVAL", "15m")
+	t.Setenv("FABRIC_SETTLEMENT_TOKEN_CODE", "USD")
+	config, err = Load(path, "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Settlement.Interval != 15*time.Minute || config.Settlement.TokenCode != "USD" {
+		t.Fatalf("settlement = %+v, want the environment to override the profile", config.Settlement)
+	}
+
+	t.Setenv("FABRIC_SETTLEMENT_INTERVAL", "-1h")
+	t.Setenv("FABRIC_SETTLEMENT_ISSUER_URL", "issuer:9100")
+	_, err = Load(path, "", defaults)
+	if err == nil {
+		t.Fatal("Load() of an invalid settlement returned no error")
+	}
+	for _, want := range []string{"settlement.interval cannot be negative", "settlement.issuerURL"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestCallbackHosts(t *testing.T) {
+	defaults := testDefaults(t)
+	t.Setenv("FABRIC_CALLBACK_HOSTS", "hooks.example.com, *.client.example.org")
+
+	config, err := Load("", "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hosts := config.TrackerOptions().CallbackHosts; len(hosts) != 2 || hosts[1] != "*.client.example.org" {
+		t.Fatalf("callback hosts = %q", hosts)
+	}
+
+	t.Setenv("FABRIC_CALLBACK_HOSTS", "https://hooks.example.com/tx,*.*.example.org")
+	_, err = Load("", "", defaults)
+	if err == nil || !strings.Contains(err.Error(), `callbacks.hosts "https://hooks.example.com/tx"`) || !strings.Contains(err.Error(), `callbacks.hosts "*.*.example.org"`) {
+		t.Fatalf("invalid callback hosts error = %v", err)
+	}
+}
+
+func TestBreachRelay(t *testing.T) {
+	defaults := testDefaults(t)
+	path := writeFile(t, profiles)
+
+	config, err := Load(path, "", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.BreachRelay.Enabled {
+		t.Fatal("the breach relay is enabled by default")
+	}
+
+	t.Setenv("FABRIC_BREACH_RELAY_ENABLED", "true")
+	_, err = Load(path, "", defaults)
+	if err == nil || !strings.Contains(err.Error(), "breachRelay.checkpoint is not set") {
+		t.Fatalf("enabled breach relay without checkpoint error = %v", err)
+	}
+
+	t.Setenv("FABRIC_BREACH_RELAY_CHECKPOINT", "breach-relay-org2.json")
+	config, err = Load(path, "org2", defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.BreachRelay.Enabled || config.BreachRelay.Checkpoint != "breach-relay-org2.json" {
+		t.Fatalf("breach relay = %+v", config.BreachRelay)
+	}
+}
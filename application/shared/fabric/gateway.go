@@ -0,0 +1,424 @@
+// Package fabric holds the long-lived connection of a REST application to its Fabric gateway peer.
+// A single gRPC connection and gateway are shared by every handler, the identity and signing key are
+// read once at startup, and the connection is watched so that it reconnects when the peer comes back.
+package fabric
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Config describes the identity a gateway signs with and the peer it connects to
+type Config struct {
+	MSPID        string
+	CertPath     string
+	KeyPath      string
+	PeerEndpoint string
+	// TLSCertPath is the CA certificate the peer is verified with and GatewayPeer its host name,
+	// when it differs from the host of the endpoint. Neither is used if TLS is disabled.
+	TLSDisabled bool
+	TLSCertPath string
+	GatewayPeer string
+
+	// HSM is the token that holds the private key of the identity, which is then not read from KeyPath
+	HSM *HSMOptions
+
+	EvaluateTimeout     time.Duration
+	EndorseTimeout      time.Duration
+	SubmitTimeout       time.Duration
+	CommitStatusTimeout time.Duration
+
+	// DialOptions are added to the options of the gRPC connection, for example interceptors that observe the calls
+	DialOptions []grpc.DialOption
+}
+
+// withDefaults fills in the timeouts the applications have always used
+func (config Config) withDefaults() Config {
+	if config.EvaluateTimeout == 0 {
+		config.EvaluateTimeout = 5 * time.Second
+	}
+	if config.EndorseTimeout == 0 {
+		config.EndorseTimeout = 15 * time.Second
+	}
+	if config.SubmitTimeout == 0 {
+		config.SubmitTimeout = 5 * time.Second
+	}
+	if config.CommitStatusTimeout == 0 {
+		config.CommitStatusTimeout = 1 * time.Minute
+	}
+	return config
+}
+
+// Gateway is a gateway connection shared by every request of an application. It is safe for concurrent use.
+// Requests made on behalf of other identities use the gateway of that identity, which shares the gRPC connection.
+type Gateway struct {
+	connection *grpc.ClientConn
+	gateway    *client.Gateway
+	config     Config
+	root       *Gateway
+
+	mu         sync.Mutex
+	contracts  map[string]*client.Contract
+	identities map[string]*Gateway
+
+	done      chan struct{}
+	closeOnce sync.Once
+	closeSign func() error
+}
+
+// Connect connects to the gateway peer of the configuration. The connection is established lazily by gRPC
+// and re-established with backoff whenever it is lost, so Connect only fails on invalid identity or TLS material.
+func Connect(config Config) (*Gateway, error) {
+	config = config.withDefaults()
+
+	id, err := NewIdentity(config.MSPID, config.CertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sign, closeSign, err := newSigner(config)
+	if err != nil {
+		return nil, err
+	}
+
+	transportCredentials := insecure.NewCredentials()
+	if !config.TLSDisabled {
+		transportCredentials, err = NewTLSCredentials(config.TLSCertPath, config.GatewayPeer)
+		if err != nil {
+			closeSign()
+			return nil, err
+		}
+	}
+
+	connection, err := NewGrpcConnection(config.PeerEndpoint, transportCredentials, config.DialOptions...)
+	if err != nil {
+		closeSign()
+		return nil, err
+	}
+
+	gw, err := connectGateway(config, connection, id, sign)
+	if err != nil {
+		connection.Close()
+		closeSign()
+		return nil, err
+	}
+
+	g := &Gateway{
+		connection: connection,
+		gateway:    gw,
+		config:     config,
+		contracts:  make(map[string]*client.Contract),
+		identities: make(map[string]*Gateway),
+		done:       make(chan struct{}),
+		closeSign:  closeSign,
+	}
+	go g.watch(config.PeerEndpoint)
+
+	return g, nil
+}
+
+func connectGateway(config Config, connection *grpc.ClientConn, id identity.Identity, sign identity.Sign) (*client.Gateway, error) {
+	gw, err := client.Connect(
+		id,
+		client.WithSign(sign),
+		client.WithClientConnection(connection),
+		client.WithEvaluateTimeout(config.EvaluateTimeout),
+		client.WithEndorseTimeout(config.EndorseTimeout),
+		client.WithSubmitTimeout(config.SubmitTimeout),
+		client.WithCommitStatusTimeout(config.CommitStatusTimeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to gateway %s: %w", config.PeerEndpoint, err)
+	}
+	return gw, nil
+}
+
+// IdentityLoader returns the identity and signing function of an identity the first time a gateway is needed for it
+type IdentityLoader func() (identity.Identity, identity.Sign, error)
+
+// ForIdentity returns the gateway that transacts as the identity with the label, which shares the gRPC connection
+// of this gateway. The identity is only loaded the first time and the gateway is kept until Forget or Close.
+func (g *Gateway) ForIdentity(label string, load IdentityLoader) (*Gateway, error) {
+	root := g
+	if g.root != nil {
+		root = g.root
+	}
+
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	if gw, ok := root.identities[label]; ok {
+		return gw, nil
+	}
+
+	id, sign, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	gw, err := connectGateway(root.config, root.connection, id, sign)
+	if err != nil {
+		return nil, err
+	}
+
+	identityGateway := &Gateway{
+		connection: root.connection,
+		gateway:    gw,
+		config:     root.config,
+		root:       root,
+		contracts:  make(map[string]*client.Contract),
+		done:       root.done,
+	}
+	root.identities[label] = identityGateway
+	return identityGateway, nil
+}
+
+// Forget drops the gateway of the identity with the label, so that the identity is loaded again on the
+// next request, for example after it has been re-enrolled
+func (g *Gateway) Forget(label string) {
+	root := g
+	if g.root != nil {
+		root = g.root
+	}
+
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	if gw, ok := root.identities[label]; ok {
+		gw.gateway.Close()
+		delete(root.identities, label)
+	}
+}
+
+// ErrNoKey is returned when a gateway of an offline identity is asked to sign, because only its client has the key
+var ErrNoKey = errors.New("the application has no key of the identity, its client signs its transactions")
+
+// Offline returns a gateway for an identity whose private key only its client holds, which shares the gRPC
+// connection of this gateway. It cannot sign, so the proposals, transactions and commit status requests it
+// builds are signed by the client and passed back with their signatures. Unlike the gateways of ForIdentity it
+// is not kept, the caller closes it.
+func (g *Gateway) Offline(id identity.Identity) (*client.Gateway, error) {
+	root := g
+	if g.root != nil {
+		root = g.root
+	}
+	return connectGateway(root.config, root.connection, id, func([]byte) ([]byte, error) {
+		return nil, ErrNoKey
+	})
+}
+
+// Network returns the network of the channel
+func (g *Gateway) Network(channelName string) *client.Network {
+	return g.gateway.GetNetwork(channelName)
+}
+
+// Contract returns the chaincode on the channel. Contracts are created once and reused by every request.
+func (g *Gateway) Contract(channelName string, chaincodeName string) *client.Contract {
+	key := channelName + "/" + chaincodeName
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	contract, ok := g.contracts[key]
+	if !ok {
+		contract = g.gateway.GetNetwork(channelName).GetContract(chaincodeName)
+		g.contracts[key] = contract
+	}
+	return contract
+}
+
+// Gateways returns how many gateways share the connection, the gateway of the application and those of the
+// identities of its users
+func (g *Gateway) Gateways() int {
+	root := g
+	if g.root != nil {
+		root = g.root
+	}
+
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	return 1 + len(root.identities)
+}
+
+// State returns the connectivity state of the connection to the gateway peer
+func (g *Gateway) State() connectivity.State {
+	return g.connection.GetState()
+}
+
+// Healthy reports whether requests can be sent to the gateway peer, either over an established
+// connection or over one gRPC will open on the next request
+func (g *Gateway) Healthy() bool {
+	state := g.State()
+	return state == connectivity.Ready || state == connectivity.Idle
+}
+
+// WaitReady connects to the gateway peer and waits until the connection is ready or the context is done
+func (g *Gateway) WaitReady(ctx context.Context) error {
+	g.connection.Connect()
+	for {
+		state := g.connection.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if state == connectivity.Shutdown {
+			return fmt.Errorf("the gateway connection has been closed")
+		}
+		if !g.connection.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("the gateway peer is not reachable: %w", ctx.Err())
+		}
+	}
+}
+
+// Close closes the gateway, the gateways of every other identity and the gRPC connection they share, and then
+// the HSM sessions of its signer. Requests that are still running fail. Closing the gateway of another identity
+// only forgets it.
+func (g *Gateway) Close() error {
+	if g.root != nil {
+		g.root.mu.Lock()
+		defer g.root.mu.Unlock()
+		for label, gw := range g.root.identities {
+			if gw == g {
+				delete(g.root.identities, label)
+			}
+		}
+		return g.gateway.Close()
+	}
+
+	var err error
+	g.closeOnce.Do(func() {
+		close(g.done)
+
+		g.mu.Lock()
+		for label, gw := range g.identities {
+			gw.gateway.Close()
+			delete(g.identities, label)
+		}
+		g.mu.Unlock()
+
+		g.gateway.Close()
+		err = errors.Join(g.connection.Close(), g.closeSign())
+	})
+	return err
+}
+
+// watch logs every change of the connectivity state and reconnects as soon as an idle connection
+// has been dropped, so the first request after the peer restarts does not pay for the reconnect
+func (g *Gateway) watch(peerEndpoint string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-g.done
+		cancel()
+	}()
+
+	state := g.connection.GetState()
+	for g.connection.WaitForStateChange(ctx, state) {
+		state = g.connection.GetState()
+		switch state {
+		case connectivity.Idle:
+			g.connection.Connect()
+		case connectivity.TransientFailure:
+			log.Printf("gateway connection to %s lost, reconnecting", peerEndpoint)
+		case connectivity.Ready:
+			log.Printf("gateway connection to %s ready", peerEndpoint)
+		case connectivity.Shutdown:
+			return
+		}
+	}
+}
+
+// NewTLSCredentials verifies the gateway peer against the CA certificate, with the host name
+// of the peer if it is not empty
+func NewTLSCredentials(tlsCertPath string, gatewayPeer string) (credentials.TransportCredentials, error) {
+	certificate, err := loadCertificate(tlsCertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(certificate)
+	return credentials.NewClientTLSFromCert(certPool, gatewayPeer), nil
+}
+
+// NewGrpcConnection creates a gRPC connection to the gateway peer. Keepalive pings detect
+// a dead peer between requests, and gRPC reconnects with backoff whenever the connection is lost.
+func NewGrpcConnection(peerEndpoint string, transportCredentials credentials.TransportCredentials, options ...grpc.DialOption) (*grpc.ClientConn, error) {
+	options = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+	}, options...)
+	connection, err := grpc.Dial(peerEndpoint, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC connection: %w", err)
+	}
+
+	return connection, nil
+}
+
+// NewIdentity creates a client identity from an X.509 certificate
+func NewIdentity(mspID string, certPath string) (*identity.X509Identity, error) {
+	certificate, err := loadCertificate(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity.NewX509Identity(mspID, certificate)
+}
+
+// NewSign creates a function that signs message digests with the private key, which is read
+// from the key file or, for an MSP keystore directory, from the first file in it
+func NewSign(keyPath string) (identity.Sign, error) {
+	info, err := os.Stat(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+	if info.IsDir() {
+		files, err := os.ReadDir(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key directory: %w", err)
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no private key in %s", keyPath)
+		}
+		keyPath = path.Join(keyPath, files[0].Name())
+	}
+
+	privateKeyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+
+	privateKey, err := identity.PrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity.NewPrivateKeySign(privateKey)
+}
+
+func loadCertificate(filename string) (*x509.Certificate, error) {
+	certificatePEM, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate file: %w", err)
+	}
+	return identity.CertificateFromPEM(certificatePEM)
+}
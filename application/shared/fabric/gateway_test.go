@@ -0,0 +1,116 @@
+package fabric
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+)
+
+// writeCredentials writes a self-signed certificate and its key, the key into an MSP style keystore directory
+func writeCredentials(t *testing.T) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyDir := filepath.Join(dir, "keystore")
+	err = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Mkdir(keyDir, 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(keyDir, "priv_sk"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyDir
+}
+
+func TestForIdentity(t *testing.T) {
+	certPath, keyDir := writeCredentials(t)
+
+	// nothing listens on the endpoint, which is fine since gRPC only connects on the first request
+	gw, err := Connect(Config{MSPID: "Org1MSP", CertPath: certPath, KeyPath: keyDir, PeerEndpoint: "127.0.0.1:1", TLSDisabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gw.Close()
+
+	loads := 0
+	load := func() (identity.Identity, identity.Sign, error) {
+		loads++
+		id, err := NewIdentity("Org2MSP", certPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		sign, err := NewSign(keyDir)
+		return id, sign, err
+	}
+
+	alice, err := gw.ForIdentity("alice", load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := gw.ForIdentity("alice", load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alice != again || loads != 1 {
+		t.Fatalf("the gateway of an identity must be reused, got %d loads", loads)
+	}
+	if alice.connection != gw.connection {
+		t.Fatal("the gateway of an identity must share the gRPC connection")
+	}
+	if gw.Gateways() != 2 || alice.Gateways() != 2 {
+		t.Fatalf("Gateways() = %d, want the gateway of the application and of alice", gw.Gateways())
+	}
+	if alice.Contract("customer", "mower") != alice.Contract("customer", "mower") {
+		t.Fatal("contracts must be reused")
+	}
+
+	gw.Forget("alice")
+	_, err = gw.ForIdentity("alice", load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 {
+		t.Fatalf("a forgotten identity must be loaded again, got %d loads", loads)
+	}
+
+	err = gw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gw.identities) != 0 {
+		t.Fatal("closing the gateway must close the gateways of every identity")
+	}
+}
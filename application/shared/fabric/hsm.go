@@ -0,0 +1,71 @@
+//go:build pkcs11
+
+package fabric
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+)
+
+// hsmPool is a pool of HSM signers of the same key, each with a session of its own
+type hsmPool struct {
+	factory *identity.HSMSignerFactory
+	signers chan identity.Sign
+	closes  []identity.HSMSignClose
+}
+
+// NewHSMSign creates a function that signs message digests with the private key of the certificate in the HSM,
+// and the function that closes its sessions and releases the PKCS#11 library. A process can only load the
+// library once, so it should have a single HSM signer.
+func NewHSMSign(options HSMOptions, certificate *x509.Certificate) (identity.Sign, func() error, error) {
+	ski, err := SKI(certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+	sessions := options.Sessions
+	if sessions <= 0 {
+		sessions = DefaultHSMSessions
+	}
+
+	factory, err := identity.NewHSMSignerFactory(options.Library)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load PKCS#11 library %s: %w", options.Library, err)
+	}
+
+	pool := &hsmPool{factory: factory, signers: make(chan identity.Sign, sessions)}
+	for i := 0; i < sessions; i++ {
+		sign, close, err := factory.NewHSMSigner(identity.HSMSignerOptions{
+			Label:      options.Label,
+			Pin:        options.Pin,
+			Identifier: string(ski),
+		})
+		if err != nil {
+			pool.close()
+			return nil, nil, fmt.Errorf("failed to open HSM session with token %s: %w", options.Label, err)
+		}
+		pool.signers <- sign
+		pool.closes = append(pool.closes, close)
+	}
+	return pool.sign, pool.close, nil
+}
+
+// sign signs with the first free session
+func (pool *hsmPool) sign(digest []byte) ([]byte, error) {
+	sign := <-pool.signers
+	defer func() { pool.signers <- sign }()
+	return sign(digest)
+}
+
+// close closes every session and finalizes the library
+func (pool *hsmPool) close() error {
+	var errs []error
+	for _, close := range pool.closes {
+		errs = append(errs, close())
+	}
+	pool.closes = nil
+	pool.factory.Dispose()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,14 @@
+//go:build !pkcs11
+
+package fabric
+
+import (
+	"crypto/x509"
+
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+)
+
+// NewHSMSign fails without the pkcs11 build tag, which needs cgo
+func NewHSMSign(HSMOptions, *x509.Certificate) (identity.Sign, func() error, error) {
+	return nil, nil, ErrNoPKCS11
+}
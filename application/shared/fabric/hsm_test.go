@@ -0,0 +1,195 @@
+//go:build pkcs11
+
+package fabric
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+)
+
+const (
+	testLabel = "ForFabric"
+	testPin   = "98765432"
+)
+
+// softHSMLibrary returns the SoftHSM library of PKCS11_LIB or of one of the places it is installed in
+func softHSMLibrary(t *testing.T) string {
+	candidates := []string{
+		os.Getenv("PKCS11_LIB"),
+		"/usr/lib/softhsm/libsofthsm2.so",
+		"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+		"/usr/local/lib/softhsm/libsofthsm2.so",
+		"/opt/homebrew/lib/softhsm/libsofthsm2.so",
+	}
+	for _, library := range candidates {
+		if library == "" {
+			continue
+		}
+		if _, err := os.Stat(library); err == nil {
+			return library
+		}
+	}
+	t.Skip("SoftHSM is not installed, set PKCS11_LIB to its library")
+	return ""
+}
+
+// newSoftHSMToken initialises a token in a temporary SoftHSM token directory
+func newSoftHSMToken(t *testing.T) string {
+	library := softHSMLibrary(t)
+	util, err := exec.LookPath("softhsm2-util")
+	if err != nil {
+		t.Skip("softhsm2-util is not installed")
+	}
+
+	dir := t.TempDir()
+	tokens := filepath.Join(dir, "tokens")
+	if err := os.Mkdir(tokens, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	conf := filepath.Join(dir, "softhsm2.conf")
+	content := fmt.Sprintf("directories.tokendir = %s\nobjectstore.backend = file\nlog.level = ERROR\n", tokens)
+	if err := os.WriteFile(conf, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SOFTHSM2_CONF", conf)
+
+	output, err := exec.Command(util, "--init-token", "--free", "--label", testLabel, "--pin", testPin, "--so-pin", testPin).CombinedOutput()
+	if err != nil {
+		t.Fatalf("softhsm2-util: %v: %s", err, output)
+	}
+	return library
+}
+
+// generateKey generates a P-256 key pair in the token under the SKI of its public key, as a Fabric CA client
+// enrolling with an HSM does, and returns the public key
+func generateKey(t *testing.T, library string) *ecdsa.PublicKey {
+	ctx := pkcs11.New(library)
+	if ctx == nil {
+		t.Fatalf("failed to load %s", library)
+	}
+	defer ctx.Destroy()
+	if err := ctx.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Finalize()
+
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := ctx.OpenSession(slots[0], pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.CloseSession(session)
+	if err := ctx.Login(session, pkcs11.CKU_USER, testPin); err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Logout(session)
+
+	p256, err := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, privateKey, err := ctx.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, p256),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attributes, err := ctx.GetAttributeValue(session, publicKey, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var point []byte
+	if _, err := asn1.Unmarshal(attributes[0].Value, &point); err != nil {
+		t.Fatal(err)
+	}
+
+	ski := sha256.Sum256(point)
+	for _, key := range []pkcs11.ObjectHandle{publicKey, privateKey} {
+		if err := ctx.SetAttributeValue(session, key, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_ID, ski[:])}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(point[1:33]),
+		Y:     new(big.Int).SetBytes(point[33:]),
+	}
+}
+
+func TestHSMSign(t *testing.T) {
+	library := newSoftHSMToken(t)
+	publicKey := generateKey(t, library)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := newCertificate(t, publicKey, caKey)
+
+	sign, closeSign, err := NewHSMSign(HSMOptions{Library: library, Label: testLabel, Pin: testPin, Sessions: 2}, certificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// more digests than sessions are signed at once, so some wait for a free session
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			digest := sha256.Sum256([]byte(fmt.Sprintf("transaction %d", i)))
+			signature, err := sign(digest[:])
+			if err != nil {
+				errs <- err
+				return
+			}
+			if !ecdsa.VerifyASN1(publicKey, digest[:], signature) {
+				errs <- fmt.Errorf("the signature of digest %d does not verify", i)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if err := closeSign(); err != nil {
+		t.Fatalf("closing the HSM sessions: %v", err)
+	}
+
+	// the library is released, so it can be loaded again, and a token that does not exist is reported
+	_, _, err = NewHSMSign(HSMOptions{Library: library, Label: "missing", Pin: testPin}, certificate)
+	if err == nil {
+		t.Fatal("NewHSMSign() of a missing token returned no error")
+	}
+}
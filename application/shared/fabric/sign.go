@@ -0,0 +1,65 @@
+package fabric
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+)
+
+// Signers of the identity of a gateway
+const (
+	// SignerFile signs with the PEM private key at the key path
+	SignerFile = "file"
+	// SignerPKCS11 signs with a private key that never leaves a PKCS#11 hardware security module
+	SignerPKCS11 = "pkcs11"
+)
+
+// DefaultHSMSessions is how many sessions with the HSM sign concurrently
+const DefaultHSMSessions = 4
+
+// ErrNoPKCS11 is returned for an HSM signer by applications built without the pkcs11 build tag
+var ErrNoPKCS11 = errors.New("PKCS#11 signing is not built in, build the application with -tags pkcs11")
+
+// HSMOptions is the token of a PKCS#11 HSM that holds the private key of the identity. The key is found by its
+// CKA_ID, the subject key identifier of the certificate of the identity. Each session signs one digest at a
+// time, so the sessions are pooled.
+type HSMOptions struct {
+	// Library is the PKCS#11 library of the HSM, such as /usr/lib/softhsm/libsofthsm2.so
+	Library  string
+	Label    string
+	Pin      string
+	Sessions int
+}
+
+// newSigner returns the signing function of the identity of the configuration and what releases it
+func newSigner(config Config) (identity.Sign, func() error, error) {
+	if config.HSM == nil {
+		sign, err := NewSign(config.KeyPath)
+		return sign, func() error { return nil }, err
+	}
+
+	certificate, err := loadCertificate(config.CertPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewHSMSign(*config.HSM, certificate)
+}
+
+// SKI returns the subject key identifier the private key of the certificate is stored under in an HSM, the
+// SHA-256 hash of the uncompressed public key point as the Fabric PKCS#11 provider computes it
+func SKI(certificate *x509.Certificate) ([]byte, error) {
+	publicKey, ok := certificate.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("the certificate of %s has no ECDSA public key", certificate.Subject.CommonName)
+	}
+	ecdhKey, err := publicKey.ECDH()
+	if err != nil {
+		return nil, err
+	}
+	ski := sha256.Sum256(ecdhKey.Bytes())
+	return ski[:], nil
+}
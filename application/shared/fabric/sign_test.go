@@ -0,0 +1,58 @@
+package fabric
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// newCertificate creates a self-signed certificate of the public key, signed with the private key
+func newCertificate(t *testing.T, publicKey any, privateKey any) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, publicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(certificateDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate
+}
+
+func TestSKI(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := newCertificate(t, &privateKey.PublicKey, privateKey)
+
+	ski, err := SKI(certificate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sha256.Sum256(elliptic.Marshal(elliptic.P256(), privateKey.X, privateKey.Y))
+	if string(ski) != string(want[:]) {
+		t.Fatalf("SKI() = %x, want %x", ski, want)
+	}
+
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SKI(newCertificate(t, edPublicKey, edPrivateKey)); err == nil {
+		t.Fatal("SKI() of an Ed25519 certificate returned no error")
+	}
+}
@@ -0,0 +1,242 @@
+// Package health answers the liveness and readiness probes of a REST application. Liveness only says that the
+// process serves requests. Readiness probes what the application needs to transact: the connection to the
+// gateway peer, a cheap evaluation of every chaincode on the peers of the organisations it is endorsed by, and
+// the expiry of the identity and TLS certificates, and reports every dependency on its own.
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+)
+
+// Statuses of a dependency and of the application. A degraded application still serves requests, but some
+// of them may fail, for example when only some organisations can endorse or a certificate expires soon.
+const (
+	StatusUp       = "up"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+const (
+	// DefaultTimeout is how long the readiness checks may take together
+	DefaultTimeout = 5 * time.Second
+	// DefaultCertificateWarning is how long before its expiry a certificate makes the application degraded
+	DefaultCertificateWarning = 30 * 24 * time.Hour
+
+	// MetadataFunction is the function every contractapi chaincode answers without reading the ledger
+	MetadataFunction = "org.hyperledger.fabric:GetMetadata"
+)
+
+// Result is the status of one dependency
+type Result struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Message  string `json:"message,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report is the status of the application and of each of its dependencies. The application is down when a
+// dependency is down and degraded when a dependency is degraded.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Probe checks a dependency and returns its status and what is wrong with it, or what it found when it is up
+type Probe func(ctx context.Context) (string, string)
+
+// Check is a named probe
+type Check struct {
+	Name  string
+	Probe Probe
+}
+
+// Checker runs the readiness checks of an application
+type Checker struct {
+	timeout time.Duration
+	checks  []Check
+}
+
+// NewChecker creates a checker that runs the checks concurrently, for up to the timeout or DefaultTimeout
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout, checks: checks}
+}
+
+// Check runs every check and reports the status of the application. A check that does not return before the
+// timeout is down.
+func (checker *Checker) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, checker.timeout)
+	defer cancel()
+
+	results := make([]Result, len(checker.checks))
+	var wg sync.WaitGroup
+	for i, check := range checker.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			start := time.Now()
+			status, message := check.Probe(ctx)
+			results[i] = Result{Name: check.Name, Status: status, Message: message, Duration: time.Since(start).Round(time.Millisecond).String()}
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: results}
+	for _, result := range results {
+		switch {
+		case result.Status == StatusDown:
+			report.Status = StatusDown
+		case result.Status == StatusDegraded && report.Status == StatusUp:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// Ready answers the readiness probe with the report, with 503 when the application is down so the orchestrator
+// sends it no requests, and with 200 when it is up or degraded
+func (checker *Checker) Ready(c *gin.Context) {
+	if checker == nil {
+		c.JSON(http.StatusOK, Report{Status: StatusUp, Checks: []Result{}})
+		return
+	}
+
+	report := checker.Check(c.Request.Context())
+	code := http.StatusOK
+	if report.Status == StatusDown {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, report)
+}
+
+// Live answers the liveness probe. It checks no dependency, so an application that cannot reach the gateway
+// peer is not restarted but only taken out of service by its readiness probe.
+func Live(c *gin.Context) {
+	c.JSON(http.StatusOK, Report{Status: StatusUp, Checks: []Result{}})
+}
+
+// Gateway checks that the connection to the gateway peer is ready, or becomes ready before the timeout
+func Gateway(gateway *fabric.Gateway) Check {
+	return Check{Name: "gateway", Probe: func(ctx context.Context) (string, string) {
+		if gateway.Healthy() {
+			return StatusUp, "connection is " + strings.ToLower(gateway.State().String())
+		}
+		err := gateway.WaitReady(ctx)
+		if err != nil {
+			return StatusDown, err.Error()
+		}
+		return StatusUp, "connection is ready"
+	}}
+}
+
+// Evaluator evaluates the metadata of a chaincode on the peers of the organisation, or on any peer the gateway
+// chooses when the organisation is empty
+type Evaluator func(ctx context.Context, organization string) error
+
+// ContractEvaluator evaluates the metadata of the chaincode of the contract
+func ContractEvaluator(contract *client.Contract) Evaluator {
+	return func(ctx context.Context, organization string) error {
+		var options []client.ProposalOption
+		if organization != "" {
+			options = append(options, client.WithEndorsingOrganizations(organization))
+		}
+		proposal, err := contract.NewProposal(MetadataFunction, options...)
+		if err != nil {
+			return err
+		}
+		_, err = proposal.EvaluateWithContext(ctx)
+		return err
+	}
+}
+
+// Chaincode checks that the chaincode answers on the peers of each organisation. It is degraded when only
+// some of the organisations answer and down when none does. Without organisations the gateway peer chooses.
+func Chaincode(name string, evaluate Evaluator, organizations []string) Check {
+	return Check{Name: "chaincode " + name, Probe: func(ctx context.Context) (string, string) {
+		if len(organizations) == 0 {
+			err := evaluate(ctx, "")
+			if err != nil {
+				return StatusDown, err.Error()
+			}
+			return StatusUp, ""
+		}
+
+		errs := make([]error, len(organizations))
+		var wg sync.WaitGroup
+		for i, organization := range organizations {
+			wg.Add(1)
+			go func(i int, organization string) {
+				defer wg.Done()
+				err := evaluate(ctx, organization)
+				if err != nil {
+					errs[i] = fmt.Errorf("%s: %w", organization, err)
+				}
+			}(i, organization)
+		}
+		wg.Wait()
+
+		failed := 0
+		for _, err := range errs {
+			if err != nil {
+				failed++
+			}
+		}
+		switch {
+		case failed == 0:
+			return StatusUp, "answered on the peers of " + strings.Join(organizations, ", ")
+		case failed < len(organizations):
+			return StatusDegraded, errors.Join(errs...).Error()
+		}
+		return StatusDown, errors.Join(errs...).Error()
+	}}
+}
+
+// Certificate checks the certificate in the PEM file. It is down when the certificate cannot be read or is not
+// valid now, and degraded when it expires within the warning.
+func Certificate(name string, path string, warning time.Duration) Check {
+	return Check{Name: "certificate " + name, Probe: func(context.Context) (string, string) {
+		certificate, err := readCertificate(path)
+		if err != nil {
+			return StatusDown, err.Error()
+		}
+
+		now := time.Now()
+		expiry := certificate.NotAfter.UTC().Format(time.RFC3339)
+		switch {
+		case now.Before(certificate.NotBefore):
+			return StatusDown, "not valid before " + certificate.NotBefore.UTC().Format(time.RFC3339)
+		case now.After(certificate.NotAfter):
+			return StatusDown, "expired at " + expiry
+		case now.Add(warning).After(certificate.NotAfter):
+			return StatusDegraded, "expires at " + expiry
+		}
+		return StatusUp, "expires at " + expiry
+	}}
+}
+
+func readCertificate(path string) (*x509.Certificate, error) {
+	certificatePEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate file: %w", err)
+	}
+	block, _ := pem.Decode(certificatePEM)
+	if block == nil {
+		return nil, fmt.Errorf("%s holds no PEM certificate", path)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
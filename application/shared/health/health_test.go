@@ -0,0 +1,172 @@
+package health
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeCertificate writes a self-signed certificate that is valid between the times
+func writeCertificate(t *testing.T, notBefore time.Time, notAfter time.Time) string {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user1"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "cert.pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER}), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func fixed(status string) Probe {
+	return func(context.Context) (string, string) { return status, "" }
+}
+
+func TestCheckReportsTheWorstStatus(t *testing.T) {
+	tests := []struct {
+		statuses []string
+		want     string
+	}{
+		{[]string{StatusUp, StatusUp}, StatusUp},
+		{[]string{StatusUp, StatusDegraded}, StatusDegraded},
+		{[]string{StatusDegraded, StatusDown, StatusUp}, StatusDown},
+	}
+	for _, test := range tests {
+		var checks []Check
+		for i, status := range test.statuses {
+			checks = append(checks, Check{Name: string(rune('a' + i)), Probe: fixed(status)})
+		}
+		report := NewChecker(0, checks...).Check(context.Background())
+		if report.Status != test.want || len(report.Checks) != len(checks) || report.Checks[0].Name != "a" {
+			t.Errorf("%v: report = %+v, want %s", test.statuses, report, test.want)
+		}
+	}
+}
+
+func TestCheckTimesOut(t *testing.T) {
+	hanging := func(ctx context.Context) (string, string) {
+		<-ctx.Done()
+		return StatusDown, ctx.Err().Error()
+	}
+	report := NewChecker(10*time.Millisecond, Check{Name: "gateway", Probe: hanging}).Check(context.Background())
+	if report.Status != StatusDown || !strings.Contains(report.Checks[0].Message, "deadline") {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestChaincode(t *testing.T) {
+	evaluate := func(ctx context.Context, organization string) error {
+		if organization == "Org2MSP" {
+			return errors.New("no peers available to evaluate chaincode gc")
+		}
+		return nil
+	}
+
+	tests := []struct {
+		organizations []string
+		want          string
+	}{
+		{nil, StatusUp},
+		{[]string{"Org1MSP"}, StatusUp},
+		{[]string{"Org1MSP", "Org2MSP"}, StatusDegraded},
+		{[]string{"Org2MSP"}, StatusDown},
+	}
+	for _, test := range tests {
+		status, message := Chaincode("gc", evaluate, test.organizations).Probe(context.Background())
+		if status != test.want {
+			t.Errorf("%v: %s %q, want %s", test.organizations, status, message, test.want)
+		}
+		if status != StatusUp && !strings.Contains(message, "Org2MSP: no peers") {
+			t.Errorf("%v: message %q does not name the organisation", test.organizations, message)
+		}
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		want      string
+	}{
+		{"valid", now.Add(-time.Hour), now.Add(365 * 24 * time.Hour), StatusUp},
+		{"expiring", now.Add(-time.Hour), now.Add(24 * time.Hour), StatusDegraded},
+		{"expired", now.Add(-2 * time.Hour), now.Add(-time.Hour), StatusDown},
+		{"not yet valid", now.Add(time.Hour), now.Add(2 * time.Hour), StatusDown},
+	}
+	for _, test := range tests {
+		path := writeCertificate(t, test.notBefore, test.notAfter)
+		status, message := Certificate("identity", path, DefaultCertificateWarning).Probe(context.Background())
+		if status != test.want {
+			t.Errorf("%s: %s %q, want %s", test.name, status, message, test.want)
+		}
+	}
+
+	status, _ := Certificate("tls", filepath.Join(t.TempDir(), "missing.pem"), DefaultCertificateWarning).Probe(context.Background())
+	if status != StatusDown {
+		t.Fatalf("a missing certificate is %s", status)
+	}
+}
+
+func TestReady(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		status string
+		code   int
+	}{
+		{StatusUp, http.StatusOK},
+		{StatusDegraded, http.StatusOK},
+		{StatusDown, http.StatusServiceUnavailable},
+	}
+	for _, test := range tests {
+		router := gin.New()
+		router.GET("/readyz", NewChecker(0, Check{Name: "gateway", Probe: fixed(test.status)}).Ready)
+		router.GET("/healthz", Live)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var report Report
+		if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+			t.Fatal(err)
+		}
+		if recorder.Code != test.code || report.Status != test.status || report.Checks[0].Name != "gateway" {
+			t.Errorf("%s: %d %s", test.status, recorder.Code, recorder.Body.String())
+		}
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		if recorder.Code != http.StatusOK {
+			t.Errorf("liveness with %s dependencies = %d", test.status, recorder.Code)
+		}
+	}
+}
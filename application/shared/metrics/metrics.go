@@ -0,0 +1,258 @@
+// Package metrics exports Prometheus metrics of a REST application at /metrics: the latency of its routes, of the
+// gateway calls and of the endorsement and commit of every chaincode function it submits, the errors of the
+// gateway calls by type and the state of the gateway connection. The dashboard in
+// test-network/prometheus-grafana shows them next to the metrics of the peers and orderers.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// Namespace prefixes the names of the metrics of the applications
+const Namespace = "fabric_app"
+
+// Gateway error types, one for each call of the gateway service and one for transactions the peers did not
+// validate
+const (
+	ErrorEvaluate     = "evaluate"
+	ErrorEndorse      = "endorse"
+	ErrorSubmit       = "submit"
+	ErrorCommitStatus = "commit_status"
+	ErrorEvents       = "chaincode_events"
+	ErrorCommit       = "commit"
+)
+
+// Metrics are the metrics of an application, in a registry of their own so the application only exports what it
+// measures and the Go runtime and process metrics
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests      *prometheus.HistogramVec
+	calls         *prometheus.HistogramVec
+	phases        *prometheus.HistogramVec
+	commits       *prometheus.CounterVec
+	gatewayErrors *prometheus.CounterVec
+}
+
+var _ transaction.Observer = (*Metrics)(nil)
+
+// New creates the metrics of an application
+func New() *Metrics {
+	metrics := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		calls: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "gateway_call_duration_seconds",
+			Help:      "Latency of the calls to the gateway peer by gateway method and gRPC status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		phases: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "transaction_phase_duration_seconds",
+			Help:      "Latency of the endorsement, submission to the orderer and commit of submitted transactions by chaincode function.",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"chaincode", "function", "phase"}),
+		commits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "transactions_committed_total",
+			Help:      "Submitted transactions whose commit is known by chaincode function and validation code.",
+		}, []string{"chaincode", "function", "validation_code"}),
+		gatewayErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "gateway_errors_total",
+			Help:      "Failed gateway calls and invalid transactions by error type and gRPC status or validation code.",
+		}, []string{"type", "code"}),
+	}
+
+	metrics.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.requests,
+		metrics.calls,
+		metrics.phases,
+		metrics.commits,
+		metrics.gatewayErrors,
+	)
+	return metrics
+}
+
+// Registry returns the registry the metrics are exported from, for an application to add metrics of its own
+func (metrics *Metrics) Registry() *prometheus.Registry {
+	return metrics.registry
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (metrics *Metrics) Handler() gin.HandlerFunc {
+	if metrics == nil {
+		return func(c *gin.Context) { c.Status(http.StatusNotFound) }
+	}
+	return gin.WrapH(promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{}))
+}
+
+// Middleware measures the latency of the requests by the route they matched, so the IDs in paths do not make
+// a series of their own. Requests that matched no route are measured as route "unmatched".
+func (metrics *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if metrics == nil {
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.requests.WithLabelValues(route, c.Request.Method, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObservePhase records how long a phase of a submitted transaction took
+func (metrics *Metrics) ObservePhase(chaincode string, function string, phase string, duration time.Duration) {
+	metrics.phases.WithLabelValues(chaincode, function, phase).Observe(duration.Seconds())
+}
+
+// ObserveCommit counts the commit of a submitted transaction, and an error when the peers did not validate it
+func (metrics *Metrics) ObserveCommit(chaincode string, function string, code peer.TxValidationCode) {
+	metrics.commits.WithLabelValues(chaincode, function, code.String()).Inc()
+	if code != peer.TxValidationCode_VALID {
+		metrics.gatewayErrors.WithLabelValues(ErrorCommit, code.String()).Inc()
+	}
+}
+
+// DialOptions returns the interceptors that measure the calls to the gateway peer, for fabric.Config.DialOptions
+func (metrics *Metrics) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(metrics.interceptUnary),
+		grpc.WithChainStreamInterceptor(metrics.interceptStream),
+	}
+}
+
+func (metrics *Metrics) interceptUnary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	metrics.observeCall(method, start, err)
+	return err
+}
+
+// interceptStream measures how long a stream took to open, the chaincode events the breach relay follows
+func (metrics *Metrics) interceptStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	metrics.observeCall(method, start, err)
+	return stream, err
+}
+
+func (metrics *Metrics) observeCall(method string, start time.Time, err error) {
+	name := method[strings.LastIndex(method, "/")+1:]
+	code := status.Code(err).String()
+	metrics.calls.WithLabelValues(name, code).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.gatewayErrors.WithLabelValues(ErrorType(name), code).Inc()
+	}
+}
+
+// ErrorType returns the gateway error type of a failed call of the gateway method
+func ErrorType(method string) string {
+	switch method {
+	case "Evaluate":
+		return ErrorEvaluate
+	case "Endorse":
+		return ErrorEndorse
+	case "Submit":
+		return ErrorSubmit
+	case "CommitStatus":
+		return ErrorCommitStatus
+	case "ChaincodeEvents":
+		return ErrorEvents
+	}
+	return strings.ToLower(method)
+}
+
+// WatchGateway exports the connectivity state of the gateway connection and how many gateways share it
+func (metrics *Metrics) WatchGateway(gateway *fabric.Gateway) {
+	metrics.registry.MustRegister(&gatewayCollector{gateway: gateway})
+}
+
+// WatchTracker exports how often the tracker retried transactions after read conflicts
+func (metrics *Metrics) WatchTracker(tracker *transaction.Tracker) {
+	metrics.registry.MustRegister(&retryCollector{tracker: tracker})
+}
+
+var (
+	connectionStateDesc = prometheus.NewDesc(Namespace+"_gateway_connection_state",
+		"Connectivity state of the gRPC connection to the gateway peer, 1 for the current state.", []string{"state"}, nil)
+	gatewaysDesc = prometheus.NewDesc(Namespace+"_gateway_connections_open",
+		"Open gateways on the connection to the gateway peer, the one of the application and one per user identity.", nil, nil)
+	retriesDesc = prometheus.NewDesc(Namespace+"_transaction_retries_total",
+		"Transactions submitted again after they failed to commit on a read conflict by chaincode function and validation code.", []string{"chaincode", "function", "validation_code"}, nil)
+	gaveUpDesc = prometheus.NewDesc(Namespace+"_transaction_retries_exhausted_total",
+		"Transactions that still failed on a read conflict after the last attempt by chaincode function and validation code.", []string{"chaincode", "function", "validation_code"}, nil)
+)
+
+// connectionStates are the connectivity states a gRPC connection can be in
+var connectionStates = []connectivity.State{connectivity.Idle, connectivity.Connecting, connectivity.Ready, connectivity.TransientFailure, connectivity.Shutdown}
+
+type gatewayCollector struct {
+	gateway *fabric.Gateway
+}
+
+func (collector *gatewayCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- connectionStateDesc
+	descs <- gatewaysDesc
+}
+
+func (collector *gatewayCollector) Collect(metrics chan<- prometheus.Metric) {
+	current := collector.gateway.State()
+	for _, state := range connectionStates {
+		value := 0.0
+		if state == current {
+			value = 1
+		}
+		metrics <- prometheus.MustNewConstMetric(connectionStateDesc, prometheus.GaugeValue, value, state.String())
+	}
+
+	gateways := 0
+	if current != connectivity.Shutdown {
+		gateways = collector.gateway.Gateways()
+	}
+	metrics <- prometheus.MustNewConstMetric(gatewaysDesc, prometheus.GaugeValue, float64(gateways))
+}
+
+type retryCollector struct {
+	tracker *transaction.Tracker
+}
+
+func (collector *retryCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- retriesDesc
+	descs <- gaveUpDesc
+}
+
+func (collector *retryCollector) Collect(metrics chan<- prometheus.Metric) {
+	for _, count := range collector.tracker.RetryCounts() {
+		metrics <- prometheus.MustNewConstMetric(retriesDesc, prometheus.CounterValue, float64(count.Retries), count.Chaincode, count.Function, count.ValidationCode)
+		metrics <- prometheus.MustNewConstMetric(gaveUpDesc, prometheus.CounterValue, float64(count.GaveUp), count.Chaincode, count.Function, count.ValidationCode)
+	}
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMiddlewareMeasuresRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	metrics := New()
+
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/metrics", metrics.Handler())
+	router.GET("/customer/:customerID/sla", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/customer/c1/sla", "/customer/c2/sla", "/missing"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if count := testutil.CollectAndCount(metrics.requests); count != 2 {
+		t.Fatalf("%d series, want one for the route and one for unmatched requests", count)
+	}
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := recorder.Body.String()
+	for _, want := range []string{
+		`fabric_app_http_request_duration_seconds_count{method="GET",route="/customer/:customerID/sla",status="200"} 2`,
+		`fabric_app_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics has no %s", want)
+		}
+	}
+}
+
+func TestObserveTransactions(t *testing.T) {
+	metrics := New()
+	metrics.ObservePhase("gc", "TakeJob", transaction.PhaseEndorse, 20*time.Millisecond)
+	metrics.ObserveCommit("gc", "TakeJob", peer.TxValidationCode_VALID)
+	metrics.ObserveCommit("gc", "TakeJob", peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+	if count := testutil.CollectAndCount(metrics.phases); count != 1 {
+		t.Fatalf("%d phase series", count)
+	}
+	if value := testutil.ToFloat64(metrics.commits.WithLabelValues("gc", "TakeJob", "MVCC_READ_CONFLICT")); value != 1 {
+		t.Fatalf("conflicting commits = %v", value)
+	}
+	if value := testutil.ToFloat64(metrics.gatewayErrors.WithLabelValues(ErrorCommit, "MVCC_READ_CONFLICT")); value != 1 {
+		t.Fatalf("commit errors = %v", value)
+	}
+	if count := testutil.CollectAndCount(metrics.gatewayErrors); count != 1 {
+		t.Fatalf("a valid commit must not count as an error, %d error series", count)
+	}
+}
+
+func TestInterceptorCountsGatewayErrors(t *testing.T) {
+	metrics := New()
+	invoke := func(err error) grpc.UnaryInvoker {
+		return func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			return err
+		}
+	}
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	calls := []struct {
+		method string
+		err    error
+	}{
+		{"/gateway.Gateway/Evaluate", nil},
+		{"/gateway.Gateway/Endorse", status.Error(codes.Aborted, "failed to endorse transaction")},
+		{"/gateway.Gateway/Submit", unavailable},
+		{"/gateway.Gateway/Submit", unavailable},
+	}
+	for _, call := range calls {
+		err := metrics.interceptUnary(context.Background(), call.method, nil, nil, nil, invoke(call.err))
+		if !errors.Is(err, call.err) {
+			t.Fatalf("the interceptor returned %v instead of %v", err, call.err)
+		}
+	}
+
+	if value := testutil.ToFloat64(metrics.gatewayErrors.WithLabelValues(ErrorSubmit, "Unavailable")); value != 2 {
+		t.Fatalf("submit errors = %v", value)
+	}
+	if value := testutil.ToFloat64(metrics.gatewayErrors.WithLabelValues(ErrorEndorse, "Aborted")); value != 1 {
+		t.Fatalf("endorse errors = %v", value)
+	}
+	if count := testutil.CollectAndCount(metrics.calls); count != 3 {
+		t.Fatalf("%d call series, want Evaluate OK, Endorse Aborted and Submit Unavailable", count)
+	}
+}
+
+func TestWatchTracker(t *testing.T) {
+	metrics := New()
+	tracker := transaction.NewTracker(transaction.Options{})
+	defer tracker.Close()
+	metrics.WatchTracker(tracker)
+
+	// a tracker that has not retried anything exports no series, rather than zeros for functions it does not know
+	if count := testutil.CollectAndCount(&retryCollector{tracker: tracker}); count != 0 {
+		t.Fatalf("%d retry series", count)
+	}
+}
@@ -0,0 +1,242 @@
+// Package offline submits transactions that are signed by their clients, so the private key of an identity never
+// leaves the device of its user. A transaction takes four requests. The application builds the proposal for the
+// certificate of the client and returns its bytes and the digest to sign. The client sends back the bytes with
+// the signature of the digest, the application has the proposal endorsed and returns the transaction envelope to
+// sign in the same way, then submits the signed envelope to the orderer and returns the commit status request,
+// whose signed copy finally waits for the commit. The application keeps nothing between the requests, everything
+// it needs is in the bytes, and the peers and orderer verify the signatures against the certificate in them.
+package offline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"github.com/nalle631/fabric-network/application/shared/transaction"
+	"google.golang.org/protobuf/proto"
+)
+
+// Unsigned is a serialized proposal, transaction envelope or commit status request. The client signs the digest,
+// the SHA-256 hash of what it is asked to sign, with its private key and sends the bytes back with the signature.
+type Unsigned struct {
+	TransactionID string `json:"transactionId"`
+	Bytes         []byte `json:"bytes"`
+	Digest        []byte `json:"digest"`
+	// Result is what the chaincode returned, once the proposal is endorsed
+	Result string `json:"result,omitempty"`
+}
+
+// Signed is the bytes of an Unsigned with the signature of its digest
+type Signed struct {
+	Bytes     []byte `json:"bytes"`
+	Signature []byte `json:"signature"`
+}
+
+// Committed is a transaction the peers validated
+type Committed struct {
+	TransactionID  string `json:"transactionId"`
+	BlockNumber    uint64 `json:"blockNumber"`
+	ValidationCode string `json:"validationCode"`
+}
+
+// NewIdentity creates the identity of a client from its PEM certificate
+func NewIdentity(mspID string, certificatePEM []byte) (*identity.X509Identity, error) {
+	certificate, err := identity.CertificateFromPEM(certificatePEM)
+	if err != nil {
+		return nil, apierror.BadRequest(fmt.Errorf("invalid certificate: %w", err))
+	}
+	return identity.NewX509Identity(mspID, certificate)
+}
+
+// Propose builds the proposal of the transaction for the identity of the client
+func Propose(gw *fabric.Gateway, id identity.Identity, channel string, chaincode string, name string, args ...string) (*Unsigned, error) {
+	offline, err := gw.Offline(id)
+	if err != nil {
+		return nil, err
+	}
+	defer offline.Close()
+
+	proposal, err := offline.GetNetwork(channel).GetContract(chaincode).NewProposal(name, client.WithArguments(args...))
+	if err != nil {
+		return nil, err
+	}
+	proposalBytes, err := proposal.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &Unsigned{TransactionID: proposal.TransactionID(), Bytes: proposalBytes, Digest: proposal.Digest()}, nil
+}
+
+// Endorse has the signed proposal endorsed and returns the transaction envelope with the result of the chaincode
+func Endorse(ctx context.Context, gw *fabric.Gateway, signed Signed) (*Unsigned, error) {
+	offline, err := connect(gw, signed, "proposal", proposalCreator)
+	if err != nil {
+		return nil, err
+	}
+	defer offline.Close()
+
+	proposal, err := offline.NewSignedProposal(signed.Bytes, signed.Signature)
+	if err != nil {
+		return nil, apierror.BadRequest(err)
+	}
+	endorsed, err := proposal.EndorseWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transactionBytes, err := endorsed.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &Unsigned{TransactionID: endorsed.TransactionID(), Bytes: transactionBytes, Digest: endorsed.Digest(), Result: string(endorsed.Result())}, nil
+}
+
+// Submit sends the signed transaction envelope to the orderer and returns the commit status request
+func Submit(ctx context.Context, gw *fabric.Gateway, signed Signed) (*Unsigned, error) {
+	offline, err := connect(gw, signed, "transaction", transactionCreator)
+	if err != nil {
+		return nil, err
+	}
+	defer offline.Close()
+
+	endorsed, err := offline.NewSignedTransaction(signed.Bytes, signed.Signature)
+	if err != nil {
+		return nil, apierror.BadRequest(err)
+	}
+	commit, err := endorsed.SubmitWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commitBytes, err := commit.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &Unsigned{TransactionID: commit.TransactionID(), Bytes: commitBytes, Digest: commit.Digest()}, nil
+}
+
+// Status waits for the commit of the transaction of the signed commit status request. A transaction the peers
+// did not validate is an error, as for the transactions the application signs.
+func Status(ctx context.Context, gw *fabric.Gateway, signed Signed) (*Committed, error) {
+	offline, err := connect(gw, signed, "commit status request", commitCreator)
+	if err != nil {
+		return nil, err
+	}
+	defer offline.Close()
+
+	commit, err := offline.NewSignedCommit(signed.Bytes, signed.Signature)
+	if err != nil {
+		return nil, apierror.BadRequest(err)
+	}
+	status, err := commit.StatusWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !status.Successful {
+		return nil, transaction.NewCommitError(status.TransactionID, status.Code)
+	}
+	return &Committed{TransactionID: status.TransactionID, BlockNumber: status.BlockNumber, ValidationCode: status.Code.String()}, nil
+}
+
+// connect returns a gateway for the identity that created the signed message
+func connect(gw *fabric.Gateway, signed Signed, what string, creatorOf func([]byte) ([]byte, error)) (*client.Gateway, error) {
+	if len(signed.Bytes) == 0 {
+		return nil, apierror.BadRequest(fmt.Errorf("the %s is missing", what))
+	}
+	if len(signed.Signature) == 0 {
+		return nil, apierror.BadRequest(fmt.Errorf("the %s is not signed", what))
+	}
+	creator, err := creatorOf(signed.Bytes)
+	if err != nil {
+		return nil, apierror.BadRequest(fmt.Errorf("invalid %s: %w", what, err))
+	}
+	id, err := identityOf(creator)
+	if err != nil {
+		return nil, apierror.BadRequest(fmt.Errorf("invalid creator of the %s: %w", what, err))
+	}
+	return gw.Offline(id)
+}
+
+// identityOf returns the identity of a serialized creator
+func identityOf(creator []byte) (identity.Identity, error) {
+	serialized := &msp.SerializedIdentity{}
+	err := proto.Unmarshal(creator, serialized)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := identity.CertificateFromPEM(serialized.GetIdBytes())
+	if err != nil {
+		return nil, err
+	}
+	return identity.NewX509Identity(serialized.GetMspid(), certificate)
+}
+
+// proposalCreator returns the creator of a serialized proposal
+func proposalCreator(proposalBytes []byte) ([]byte, error) {
+	proposed := &gateway.ProposedTransaction{}
+	err := proto.Unmarshal(proposalBytes, proposed)
+	if err != nil {
+		return nil, err
+	}
+	proposal := &peer.Proposal{}
+	err = proto.Unmarshal(proposed.GetProposal().GetProposalBytes(), proposal)
+	if err != nil {
+		return nil, err
+	}
+	header := &common.Header{}
+	err = proto.Unmarshal(proposal.GetHeader(), header)
+	if err != nil {
+		return nil, err
+	}
+	return signatureCreator(header.GetSignatureHeader())
+}
+
+// transactionCreator returns the creator of a serialized transaction envelope
+func transactionCreator(transactionBytes []byte) ([]byte, error) {
+	prepared := &gateway.PreparedTransaction{}
+	err := proto.Unmarshal(transactionBytes, prepared)
+	if err != nil {
+		return nil, err
+	}
+	payload := &common.Payload{}
+	err = proto.Unmarshal(prepared.GetEnvelope().GetPayload(), payload)
+	if err != nil {
+		return nil, err
+	}
+	return signatureCreator(payload.GetHeader().GetSignatureHeader())
+}
+
+// commitCreator returns the identity of a serialized commit status request
+func commitCreator(commitBytes []byte) ([]byte, error) {
+	signed := &gateway.SignedCommitStatusRequest{}
+	err := proto.Unmarshal(commitBytes, signed)
+	if err != nil {
+		return nil, err
+	}
+	request := &gateway.CommitStatusRequest{}
+	err = proto.Unmarshal(signed.GetRequest(), request)
+	if err != nil {
+		return nil, err
+	}
+	if len(request.GetIdentity()) == 0 {
+		return nil, fmt.Errorf("it has no identity")
+	}
+	return request.GetIdentity(), nil
+}
+
+func signatureCreator(signatureHeaderBytes []byte) ([]byte, error) {
+	signatureHeader := &common.SignatureHeader{}
+	err := proto.Unmarshal(signatureHeaderBytes, signatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	if len(signatureHeader.GetCreator()) == 0 {
+		return nil, fmt.Errorf("it has no creator")
+	}
+	return signatureHeader.GetCreator(), nil
+}
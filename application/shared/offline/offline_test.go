@@ -0,0 +1,202 @@
+package offline
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+	"google.golang.org/protobuf/proto"
+)
+
+// newCredentials creates a self-signed certificate and its key
+func newCredentials(t *testing.T, commonName string) ([]byte, *ecdsa.PrivateKey) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER}), privateKey
+}
+
+// newTestGateway connects the gateway of the application to an endpoint nothing listens on
+func newTestGateway(t *testing.T) *fabric.Gateway {
+	certificatePEM, privateKey := newCredentials(t, "application")
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, certificatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	gw, err := fabric.Connect(fabric.Config{MSPID: "Org1MSP", CertPath: certPath, KeyPath: keyPath, PeerEndpoint: "127.0.0.1:1", TLSDisabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { gw.Close() })
+	return gw
+}
+
+func serializedIdentity(t *testing.T, id identity.Identity) []byte {
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: id.MspID(), IdBytes: id.Credentials()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return creator
+}
+
+func TestProposeForTheClient(t *testing.T) {
+	gw := newTestGateway(t)
+	certificatePEM, privateKey := newCredentials(t, "technician")
+	id, err := NewIdentity("Org2MSP", certificatePEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsigned, err := Propose(gw, id, "mychannel", "gc", "TakeJob", "job1", "Org2MSP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unsigned.TransactionID == "" || len(unsigned.Digest) != 32 {
+		t.Fatalf("unsigned = %+v", unsigned)
+	}
+
+	// the proposal is created by the client, not by the identity of the application
+	creator, err := proposalCreator(unsigned.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposer, err := identityOf(creator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proposer.MspID() != "Org2MSP" || string(proposer.Credentials()) != string(id.Credentials()) {
+		t.Fatalf("the proposal was created by %s", proposer.MspID())
+	}
+
+	// the client signs the digest with a key the application never sees
+	sign, err := identity.NewPrivateKeySign(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := sign(unsigned.Digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ecdsa.VerifyASN1(&privateKey.PublicKey, unsigned.Digest, signature) {
+		t.Fatal("the digest does not verify")
+	}
+}
+
+func TestSignedMessagesAreChecked(t *testing.T) {
+	gw := newTestGateway(t)
+	certificatePEM, _ := newCredentials(t, "technician")
+	id, err := NewIdentity("Org2MSP", certificatePEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := Propose(gw, id, "mychannel", "gc", "CreateGeneralContract")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		signed Signed
+	}{
+		{"unsigned", Signed{Bytes: unsigned.Bytes}},
+		{"missing", Signed{Signature: []byte("signature")}},
+		{"not a proposal", Signed{Bytes: []byte("not a proposal"), Signature: []byte("signature")}},
+	}
+	for _, test := range tests {
+		_, err := Endorse(context.Background(), gw, test.signed)
+		if response := apierror.From(err); response.Status != http.StatusBadRequest {
+			t.Errorf("%s: %v", test.name, err)
+		}
+	}
+
+	_, err = NewIdentity("Org2MSP", []byte("not a certificate"))
+	if response := apierror.From(err); response.Status != http.StatusBadRequest {
+		t.Errorf("invalid certificate: %v", err)
+	}
+}
+
+func TestCreators(t *testing.T) {
+	certificatePEM, _ := newCredentials(t, "technician")
+	id, err := NewIdentity("Org2MSP", certificatePEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creator := serializedIdentity(t, id)
+
+	signatureHeader, err := proto.Marshal(&common.SignatureHeader{Creator: creator})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := proto.Marshal(&common.Payload{Header: &common.Header{SignatureHeader: signatureHeader}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transactionBytes, err := proto.Marshal(&gateway.PreparedTransaction{TransactionId: "tx1", Envelope: &common.Envelope{Payload: payload}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := proto.Marshal(&gateway.CommitStatusRequest{ChannelId: "mychannel", TransactionId: "tx1", Identity: creator})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitBytes, err := proto.Marshal(&gateway.SignedCommitStatusRequest{Request: request})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, creatorOf := range map[string]func() ([]byte, error){
+		"transaction": func() ([]byte, error) { return transactionCreator(transactionBytes) },
+		"commit":      func() ([]byte, error) { return commitCreator(commitBytes) },
+	} {
+		got, err := creatorOf()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if string(got) != string(creator) {
+			t.Errorf("%s: wrong creator", name)
+		}
+	}
+
+	if _, err := transactionCreator(commitBytes); err == nil {
+		t.Error("a commit status request is not a transaction")
+	}
+}
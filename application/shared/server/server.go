@@ -0,0 +1,48 @@
+// Package server runs the HTTP server of a REST application until it is asked to stop,
+// then drains the requests in flight before the application releases its connections.
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long requests in flight are given to finish on shutdown
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Run serves the handler on the address until the context is done. The server then stops accepting
+// connections and waits up to the shutdown timeout for the requests in flight before the cleanup
+// functions are called in order, for example to close the gateway the handlers use.
+func Run(ctx context.Context, addr string, handler http.Handler, shutdownTimeout time.Duration, cleanup ...func()) error {
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: handler,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	var err error
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		log.Printf("shutting down the server on %s", addr)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		err = srv.Shutdown(shutdownCtx)
+		cancel()
+	}
+
+	for _, f := range cleanup {
+		f()
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
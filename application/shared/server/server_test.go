@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func freeAddr(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func waitListening(t *testing.T, addr string) {
+	for i := 0; i < 100; i++ {
+		connection, err := net.Dial("tcp", addr)
+		if err == nil {
+			connection.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("the server never listened on %s", addr)
+}
+
+func TestRunDrainsRequestsBeforeCleanup(t *testing.T) {
+	addr := freeAddr(t)
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		io.WriteString(w, "done")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cleaned := make(chan struct{})
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- Run(ctx, addr, handler, time.Second, func() { close(cleaned) })
+	}()
+
+	waitListening(t, addr)
+
+	var response *http.Response
+	var err error
+	requestDone := make(chan struct{})
+	go func() {
+		response, err = http.Get("http://" + addr)
+		close(requestDone)
+	}()
+	<-started
+	cancel()
+	<-requestDone
+
+	if err != nil {
+		t.Fatalf("request in flight failed: %v", err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if string(body) != "done" {
+		t.Fatalf("got %q, want the request in flight to finish", body)
+	}
+
+	if err := <-runErr; err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+	select {
+	case <-cleaned:
+	default:
+		t.Fatal("cleanup was not called")
+	}
+}
+
+func TestRunReturnsListenErrors(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	cleaned := false
+	err = Run(context.Background(), listener.Addr().String(), http.NotFoundHandler(), time.Second, func() { cleaned = true })
+	if err == nil {
+		t.Fatal("Run() on an address in use returned no error")
+	}
+	if !cleaned {
+		t.Fatal("cleanup was not called")
+	}
+}
@@ -0,0 +1,72 @@
+package transaction
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which is as internal as the private ranges
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// callbackHostAllowed reports whether callbacks may be posted to the host. An entry of the allow-list is a host
+// name, or *.domain for every subdomain of domain, and every host is allowed when the list is empty.
+func callbackHostAllowed(host string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, entry := range allowed {
+		entry = strings.TrimSuffix(strings.ToLower(entry), ".")
+		if domain, ok := strings.CutPrefix(entry, "*."); ok {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		} else if host == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// publicAddress reports whether the address is reachable from the internet, so a callback to it cannot reach the
+// application itself or the services of its network, such as the peers or the metadata service of a cloud
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified() && !sharedAddressSpace.Contains(ip)
+}
+
+// newCallbackClient returns the client callbacks are posted with by default. It only connects to public addresses,
+// which are checked once the host name has been resolved so a name that resolves to an internal address is refused
+// too. It does not use a proxy, which would connect on its behalf, and does not follow redirects.
+func newCallbackClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !publicAddress(ip) {
+				return fmt.Errorf("callbacks are not posted to the internal address %s", host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package transaction
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCallbackHostAllowed(t *testing.T) {
+	allowed := []string{"hooks.example.com", "*.client.example.org"}
+	for _, test := range []struct {
+		host    string
+		allowed []string
+		want    bool
+	}{
+		{"anything.example.net", nil, true},
+		{"hooks.example.com", allowed, true},
+		{"Hooks.Example.com.", allowed, true},
+		{"api.hooks.example.com", allowed, false},
+		{"a.client.example.org", allowed, true},
+		{"b.a.client.example.org", allowed, true},
+		{"client.example.org", allowed, false},
+		{"evilclient.example.org", allowed, false},
+		{"example.com", allowed, false},
+	} {
+		if got := callbackHostAllowed(test.host, test.allowed); got != test.want {
+			t.Errorf("callbackHostAllowed(%q, %q) = %v", test.host, test.allowed, got)
+		}
+	}
+}
+
+func TestPublicAddress(t *testing.T) {
+	for address, want := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"100.64.0.1":       false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"0.0.0.0":          false,
+		"::":               false,
+		"224.0.0.1":        false,
+		"::ffff:127.0.0.1": false,
+	} {
+		if got := publicAddress(net.ParseIP(address)); got != want {
+			t.Errorf("publicAddress(%s) = %v", address, got)
+		}
+	}
+}
+
+func TestCallbackClientRefusesInternalAddresses(t *testing.T) {
+	posted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted = true
+	}))
+	defer server.Close()
+
+	tracker := NewTracker(Options{})
+	defer tracker.Close()
+
+	// localhost is only known to be internal once it has been resolved
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	for _, callback := range []string{server.URL, "http://localhost:" + port} {
+		err := tracker.postOnce(callback, []byte(`{}`))
+		if err == nil || !strings.Contains(err.Error(), "internal address") {
+			t.Errorf("posting to %s = %v", callback, err)
+		}
+	}
+	if posted {
+		t.Error("a callback reached the loopback address")
+	}
+}
+
+func TestMiddlewareOnlyAcceptsAllowedCallbackHosts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tracker := NewTracker(Options{CallbackHosts: []string{"*.client.example.com"}})
+	defer tracker.Close()
+
+	router := gin.New()
+	router.Use(tracker.Middleware())
+	router.POST("/sla", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for callback, want := range map[string]int{
+		"https://hooks.client.example.com/tx": http.StatusOK,
+		"https://attacker.example.net/tx":     http.StatusBadRequest,
+	} {
+		request := httptest.NewRequest(http.MethodPost, "/sla", nil)
+		request.Header.Set("Prefer", "respond-async")
+		request.Header.Set(CallbackHeader, callback)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		if recorder.Code != want {
+			t.Errorf("callback %s: %d, want %d", callback, recorder.Code, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header with the key a client retries a request with. The chaincode keeps
+	// the outcome of the first transaction with the key, and retries get that outcome instead of a new transaction.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// ReplayedHeader is true on responses that are the outcome of an earlier request with the same idempotency key
+	ReplayedHeader = "Idempotent-Replayed"
+	// TransactionIDHeader is the ID of the transaction that made the change a request with an idempotency key asked for
+	TransactionIDHeader = "Transaction-ID"
+
+	// maxIdempotencyKeyLength is the longest idempotency key the chaincodes accept
+	maxIdempotencyKeyLength = 255
+)
+
+// Record is the deduplication record the chaincode returns for a transaction submitted with an idempotency key.
+// The transaction ID and result are those of the first transaction with the key.
+type Record struct {
+	Key      string `json:"Key"`
+	Function string `json:"Function"`
+	TxID     string `json:"TxID"`
+	Result   string `json:"Result"`
+	Replayed bool   `json:"Replayed"`
+}
+
+// IdempotencyKey returns the idempotency key of the request, empty when it has none. A key that is not 1 to 255
+// printable ASCII characters is an error response.
+func IdempotencyKey(c *gin.Context) (string, error) {
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if len(key) > maxIdempotencyKeyLength {
+		return "", apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, fmt.Sprintf("%s is longer than %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+	}
+	for _, r := range key {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return "", apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, IdempotencyKeyHeader+" must be printable ASCII")
+		}
+	}
+	return key, nil
+}
+
+// SubmitIdempotent submits the transaction, or with an idempotency key its idempotent variant, which is named
+// after it with the suffix Idempotent, takes the key as its first argument and returns the deduplication record.
+// The result is then the result of the first transaction with the key. The record is nil without a key.
+func (tracker *Tracker) SubmitIdempotent(ctx context.Context, contract *client.Contract, name string, key string, args ...string) ([]byte, *Record, error) {
+	if key == "" {
+		result, err := tracker.Submit(ctx, contract, name, args...)
+		return result, nil, err
+	}
+
+	recordJSON, err := tracker.Submit(ctx, contract, name+"Idempotent", append([]string{key}, args...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	var record Record
+	err = json.Unmarshal(recordJSON, &record)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal the deduplication record: %w", err)
+	}
+	return []byte(record.Result), &record, nil
+}
+
+// SetHeaders tells the client which transaction made the change and whether the response is a replay
+func (record *Record) SetHeaders(c *gin.Context) {
+	c.Header(TransactionIDHeader, record.TxID)
+	c.Header(ReplayedHeader, strconv.FormatBool(record.Replayed))
+}
@@ -0,0 +1,50 @@
+package transaction
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"", true},
+		{"7d4c5a1e-2b1f-4e0a-9a57-1f0d9c3b8e21", true},
+		{strings.Repeat("k", maxIdempotencyKeyLength), true},
+		{strings.Repeat("k", maxIdempotencyKeyLength+1), false},
+		{"nyckel-å", false},
+	}
+	for _, test := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/job/take", nil)
+		c.Request.Header.Set(IdempotencyKeyHeader, test.key)
+
+		key, err := IdempotencyKey(c)
+		if test.valid && (err != nil || key != test.key) {
+			t.Errorf("IdempotencyKey(%q) = %q, %v", test.key, key, err)
+		}
+		if !test.valid && apierror.From(err).Status != http.StatusBadRequest {
+			t.Errorf("IdempotencyKey(%q) = %v, want 400", test.key, err)
+		}
+	}
+}
+
+func TestRecordSetHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+
+	record := &Record{Key: "key-1", Function: "TakeJob", TxID: "tx1", Replayed: true}
+	record.SetHeaders(c)
+	if recorder.Header().Get(TransactionIDHeader) != "tx1" || recorder.Header().Get(ReplayedHeader) != "true" {
+		t.Fatalf("headers = %v", recorder.Header())
+	}
+}
@@ -0,0 +1,169 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+)
+
+const (
+	// DefaultMaxAttempts is how often a transaction is submitted before a conflict is given up on
+	DefaultMaxAttempts = 4
+	// DefaultInitialBackoff is about how long the first retry waits
+	DefaultInitialBackoff = 100 * time.Millisecond
+	// DefaultMaxBackoff is the longest a retry waits
+	DefaultMaxBackoff = 2 * time.Second
+
+	// RetriesHeader is the response header with how often the transaction of the request was submitted again
+	RetriesHeader = "Transaction-Retries"
+)
+
+// RetryPolicy is how a transaction that failed to commit because another transaction changed the keys it read,
+// such as the general contract or a customer, is endorsed and submitted again. The backoff doubles from the
+// initial backoff up to the maximum and every wait is a random duration between half of it and all of it, so
+// requests that conflicted with each other do not retry at the same time. MaxAttempts 1 turns retries off.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// RetryCount is how often the transactions of a chaincode function were retried after a validation code, and
+// how often they still failed with it after the last attempt
+type RetryCount struct {
+	Chaincode      string `json:"chaincode"`
+	Function       string `json:"function"`
+	ValidationCode string `json:"validationCode"`
+	Retries        uint64 `json:"retries"`
+	GaveUp         uint64 `json:"gaveUp"`
+}
+
+type retryKey struct {
+	chaincode string
+	function  string
+	code      peer.TxValidationCode
+}
+
+type headerKey struct{}
+
+// withDefaults replaces the zero values of the policy with the defaults
+func (policy RetryPolicy) withDefaults() RetryPolicy {
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = DefaultMaxAttempts
+	}
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = DefaultInitialBackoff
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = DefaultMaxBackoff
+	}
+	return policy
+}
+
+// backoff returns how long to wait before the retry, the first one being 1
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	backoff := policy.InitialBackoff
+	for i := 1; i < retry && backoff < policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+// Retryable returns the validation code of a transaction that failed to commit only because it conflicted with
+// another transaction, so endorsing it again against the new state can succeed
+func Retryable(err error) (peer.TxValidationCode, bool) {
+	var commitErr *client.CommitError
+	if !errors.As(err, &commitErr) {
+		return 0, false
+	}
+	switch commitErr.Code {
+	case peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_PHANTOM_READ_CONFLICT:
+		return commitErr.Code, true
+	}
+	return 0, false
+}
+
+// retry calls submit until it does not fail with a retryable commit error, the attempts are used up or the
+// context is done, and returns how often it retried
+func (tracker *Tracker) retry(ctx context.Context, chaincode string, name string, submit func() ([]byte, error)) ([]byte, int, error) {
+	policy := tracker.options.Retry
+	for attempt := 1; ; attempt++ {
+		result, err := submit()
+		code, retryable := Retryable(err)
+		if !retryable {
+			return result, attempt - 1, err
+		}
+		if attempt >= policy.MaxAttempts {
+			tracker.count(retryKey{chaincode, name, code}, 0, 1)
+			return nil, attempt - 1, err
+		}
+		tracker.count(retryKey{chaincode, name, code}, 1, 0)
+
+		select {
+		case <-ctx.Done():
+			return nil, attempt, err
+		case <-time.After(policy.backoff(attempt)):
+		}
+	}
+}
+
+func (tracker *Tracker) count(key retryKey, retries uint64, gaveUp uint64) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	count, ok := tracker.retries[key]
+	if !ok {
+		count = &RetryCount{Chaincode: key.chaincode, Function: key.function, ValidationCode: key.code.String()}
+		tracker.retries[key] = count
+	}
+	count.Retries += retries
+	count.GaveUp += gaveUp
+}
+
+// RetryCounts returns the retries of every chaincode function that was retried since the tracker was created
+func (tracker *Tracker) RetryCounts() []RetryCount {
+	if tracker == nil {
+		return nil
+	}
+
+	tracker.mu.Lock()
+	counts := make([]RetryCount, 0, len(tracker.retries))
+	for _, count := range tracker.retries {
+		counts = append(counts, *count)
+	}
+	tracker.mu.Unlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Chaincode != counts[j].Chaincode {
+			return counts[i].Chaincode < counts[j].Chaincode
+		}
+		if counts[i].Function != counts[j].Function {
+			return counts[i].Function < counts[j].Function
+		}
+		return counts[i].ValidationCode < counts[j].ValidationCode
+	})
+	return counts
+}
+
+// RespondRetryCounts responds with the retry counts
+func (tracker *Tracker) RespondRetryCounts(c *gin.Context) {
+	c.IndentedJSON(http.StatusOK, tracker.RetryCounts())
+}
+
+// setRetries tells the client of the request of the context how often its transaction was retried
+func setRetries(ctx context.Context, retries int) {
+	if header, ok := ctx.Value(headerKey{}).(http.Header); ok && retries > 0 {
+		header.Set(RetriesHeader, strconv.Itoa(retries))
+	}
+}
@@ -0,0 +1,138 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+)
+
+// conflicts returns a submit that fails to commit with the codes in order and then succeeds
+func conflicts(codes ...peer.TxValidationCode) (func() ([]byte, error), *int) {
+	attempts := 0
+	return func() ([]byte, error) {
+		attempts++
+		if attempts <= len(codes) {
+			return nil, &client.CommitError{TransactionID: "tx", Code: codes[attempts-1]}
+		}
+		return []byte(`{"ID":"job1"}`), nil
+	}, &attempts
+}
+
+func retryTracker(maxAttempts int) *Tracker {
+	return NewTracker(Options{Retry: RetryPolicy{MaxAttempts: maxAttempts, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}})
+}
+
+func TestRetryResubmitsConflicts(t *testing.T) {
+	tracker := retryTracker(4)
+	defer tracker.Close()
+
+	submit, attempts := conflicts(peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_PHANTOM_READ_CONFLICT)
+	result, retries, err := tracker.retry(context.Background(), "gc", "TakeJob", submit)
+	if err != nil || string(result) != `{"ID":"job1"}` || retries != 2 || *attempts != 3 {
+		t.Fatalf("retry() = %s, %d, %v after %d attempts", result, retries, err, *attempts)
+	}
+
+	counts := tracker.RetryCounts()
+	want := []RetryCount{
+		{Chaincode: "gc", Function: "TakeJob", ValidationCode: "MVCC_READ_CONFLICT", Retries: 1},
+		{Chaincode: "gc", Function: "TakeJob", ValidationCode: "PHANTOM_READ_CONFLICT", Retries: 1},
+	}
+	if len(counts) != len(want) || counts[0] != want[0] || counts[1] != want[1] {
+		t.Fatalf("RetryCounts() = %+v, want %+v", counts, want)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	tracker := retryTracker(2)
+	defer tracker.Close()
+
+	submit, attempts := conflicts(peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	_, retries, err := tracker.retry(context.Background(), "customer", "CreateSLA", submit)
+	if _, ok := Retryable(err); !ok || retries != 1 || *attempts != 2 {
+		t.Fatalf("retry() = %d, %v after %d attempts, want the conflict after 2 attempts", retries, err, *attempts)
+	}
+	if counts := tracker.RetryCounts(); len(counts) != 1 || counts[0].Retries != 1 || counts[0].GaveUp != 1 {
+		t.Fatalf("RetryCounts() = %+v", counts)
+	}
+}
+
+func TestRetryOnlyRetriesConflicts(t *testing.T) {
+	tracker := retryTracker(4)
+	defer tracker.Close()
+
+	submit, attempts := conflicts(peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	_, retries, err := tracker.retry(context.Background(), "gc", "TakeJob", submit)
+	if err == nil || retries != 0 || *attempts != 1 {
+		t.Fatalf("retry() = %d, %v after %d attempts, want the policy failure at once", retries, err, *attempts)
+	}
+
+	failure := errors.New("job job1 does not exist")
+	_, retries, err = tracker.retry(context.Background(), "gc", "TakeJob", func() ([]byte, error) { return nil, failure })
+	if !errors.Is(err, failure) || retries != 0 {
+		t.Fatalf("retry() = %d, %v", retries, err)
+	}
+	if counts := tracker.RetryCounts(); len(counts) != 0 {
+		t.Fatalf("RetryCounts() = %+v", counts)
+	}
+}
+
+func TestRetryStopsWithTheRequest(t *testing.T) {
+	tracker := NewTracker(Options{Retry: RetryPolicy{InitialBackoff: time.Hour, MaxBackoff: time.Hour}})
+	defer tracker.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	submit, attempts := conflicts(peer.TxValidationCode_MVCC_READ_CONFLICT)
+	_, _, err := tracker.retry(ctx, "gc", "TakeJob", submit)
+	if _, ok := Retryable(err); !ok || *attempts != 1 {
+		t.Fatalf("retry() = %v after %d attempts", err, *attempts)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}.withDefaults()
+	tests := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{4, 400 * time.Millisecond, 800 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+	for _, test := range tests {
+		for i := 0; i < 100; i++ {
+			if backoff := policy.backoff(test.retry); backoff < test.min || backoff > test.max {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", test.retry, backoff, test.min, test.max)
+			}
+		}
+	}
+}
+
+func TestMiddlewareReportsRetries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tracker := retryTracker(4)
+	defer tracker.Close()
+
+	router := gin.New()
+	router.Use(tracker.Middleware())
+	router.POST("/job/take", func(c *gin.Context) {
+		submit, _ := conflicts(peer.TxValidationCode_MVCC_READ_CONFLICT)
+		_, retries, _ := tracker.retry(c.Request.Context(), "gc", "TakeJob", submit)
+		setRetries(c.Request.Context(), retries)
+		c.String(http.StatusOK, "taken")
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/job/take", nil))
+	if recorder.Header().Get(RetriesHeader) != "1" {
+		t.Fatalf("headers = %v", recorder.Header())
+	}
+}
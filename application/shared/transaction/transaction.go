@@ -0,0 +1,492 @@
+// Package transaction submits the transactions of the REST handlers. By default a handler waits for the commit
+// of its transaction, which can take longer than an HTTP client waits. A client that sends Prefer: respond-async
+// gets 202 with the transaction ID as soon as the transaction is endorsed and ordered instead, and follows its
+// commit on GET /tx/{id} or with a callback URL the tracker posts the final status to. Transactions of requests
+// with an Idempotency-Key header are submitted as their idempotent variants, which run once per key. Transactions
+// that fail to commit on a read conflict with a concurrent transaction are endorsed and submitted again.
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"github.com/nalle631/fabric-network/application/shared/auth"
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultCommitTimeout is how long the commit of an asynchronous transaction is waited for
+	DefaultCommitTimeout = 10 * time.Minute
+	// DefaultRetention is how long the status of a transaction is kept once its commit is known
+	DefaultRetention = time.Hour
+	// DefaultCallbackTimeout is how long a callback may take before it is tried again
+	DefaultCallbackTimeout = 10 * time.Second
+
+	// CallbackHeader is the request header with the URL the final status of an asynchronous transaction is posted to
+	CallbackHeader = "Callback-URL"
+
+	// callbackAttempts is how often a callback is tried before it is given up
+	callbackAttempts = 3
+)
+
+// Statuses of a transaction. A tracked transaction was always endorsed and accepted by the orderer.
+const (
+	StatusOrdered   = "ordered"
+	StatusCommitted = "committed"
+	StatusInvalid   = "invalid"
+	StatusUnknown   = "unknown"
+)
+
+// Phases of a submitted transaction that are observed
+const (
+	PhaseEndorse = "endorse"
+	PhaseSubmit  = "submit"
+	PhaseCommit  = "commit"
+)
+
+// Status is what is known about a transaction that was submitted asynchronously. Committed transactions have
+// the number of their block and the validation code of the peer, such as VALID or MVCC_READ_CONFLICT, and only
+// VALID transactions have changed the ledger. The status is unknown when the commit could not be waited for.
+type Status struct {
+	TransactionID  string          `json:"transactionId"`
+	Chaincode      string          `json:"chaincode"`
+	Function       string          `json:"function"`
+	Status         string          `json:"status"`
+	BlockNumber    uint64          `json:"blockNumber,omitempty"`
+	ValidationCode string          `json:"validationCode,omitempty"`
+	Result         json.RawMessage `json:"result,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	SubmittedAt    time.Time       `json:"submittedAt"`
+	CommittedAt    *time.Time      `json:"committedAt,omitempty"`
+}
+
+// Final reports whether the commit of the transaction is known or was given up on
+func (status Status) Final() bool {
+	return status.Status != StatusOrdered
+}
+
+// Options configures a tracker, zero values are replaced by the defaults. Callbacks are only accepted for the hosts
+// of CallbackHosts, host names or *.domain for the subdomains of a domain, or for every host when it is empty. The
+// default HTTP client only posts them to public addresses.
+type Options struct {
+	CommitTimeout time.Duration
+	Retention     time.Duration
+	HTTPClient    *http.Client
+	CallbackHosts []string
+	Retry         RetryPolicy
+	Observer      Observer
+}
+
+// Observer is told how long the phases of the submitted transactions took and with which validation code they
+// committed, for example to export them as metrics. It is called concurrently.
+type Observer interface {
+	ObservePhase(chaincode string, function string, phase string, duration time.Duration)
+	ObserveCommit(chaincode string, function string, code peer.TxValidationCode)
+}
+
+// Tracker submits transactions and follows the commits of those that were submitted asynchronously.
+// Statuses are kept in memory, so they are lost when the application restarts.
+type Tracker struct {
+	options Options
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	mu      sync.Mutex
+	records map[string]*record
+	retries map[retryKey]*RetryCount
+}
+
+type record struct {
+	status   Status
+	owner    string
+	callback string
+	final    time.Time
+}
+
+// preference is how the client of a request asked for its transactions to be submitted
+type preference struct {
+	callback string
+	owner    string
+}
+
+type preferenceKey struct{}
+
+// committer is the commit of an ordered transaction
+type committer interface {
+	TransactionID() string
+	StatusWithContext(ctx context.Context, opts ...grpc.CallOption) (*client.Status, error)
+}
+
+// NewTracker creates a tracker, which follows commits until it is closed
+func NewTracker(options Options) *Tracker {
+	if options.CommitTimeout == 0 {
+		options.CommitTimeout = DefaultCommitTimeout
+	}
+	if options.Retention == 0 {
+		options.Retention = DefaultRetention
+	}
+	if options.HTTPClient == nil {
+		options.HTTPClient = newCallbackClient(DefaultCallbackTimeout)
+	}
+	options.Retry = options.Retry.withDefaults()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Tracker{options: options, ctx: ctx, cancel: cancel, records: make(map[string]*record), retries: make(map[retryKey]*RetryCount)}
+}
+
+// Middleware lets the handlers of requests with Prefer: respond-async submit asynchronously and tells the
+// clients how often their transactions were retried. A callback URL in the Callback-URL header must be an
+// absolute http or https URL of an allowed host that is not an internal IP address, other requests are rejected
+// with 400.
+func (tracker *Tracker) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), headerKey{}, c.Writer.Header()))
+		if !prefersAsync(c.Request.Header.Values("Prefer")) {
+			return
+		}
+
+		callback := c.GetHeader(CallbackHeader)
+		if callback != "" {
+			target, err := url.Parse(callback)
+			if err != nil || !target.IsAbs() || (target.Scheme != "http" && target.Scheme != "https") {
+				apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, CallbackHeader+" must be an absolute http or https URL"))
+				return
+			}
+			ip := net.ParseIP(target.Hostname())
+			if !callbackHostAllowed(target.Hostname(), tracker.options.CallbackHosts) || (ip != nil && !publicAddress(ip)) {
+				apierror.Respond(c, apierror.New(http.StatusBadRequest, apierror.CodeInvalidRequest, "callbacks are not posted to "+target.Hostname()))
+				return
+			}
+		}
+
+		async := preference{callback: callback}
+		if principal, ok := auth.PrincipalFrom(c); ok {
+			async.owner = principal.Subject
+		}
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), preferenceKey{}, async))
+	}
+}
+
+// prefersAsync reports whether the Prefer headers ask for respond-async, as in RFC 7240
+func prefersAsync(headers []string) bool {
+	for _, header := range headers {
+		for _, preference := range strings.Split(header, ",") {
+			token, _, _ := strings.Cut(preference, ";")
+			token, _, _ = strings.Cut(token, "=")
+			if strings.EqualFold(strings.TrimSpace(token), "respond-async") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Submit submits the transaction and returns its result once it is committed. A transaction that fails to
+// commit on a read conflict is submitted again as the retry policy allows, and the Transaction-Retries header
+// of the response says how often. When the request of the context asked for respond-async, it returns as soon
+// as the transaction is ordered with an *Accepted error instead, which apierror.Respond answers with 202, and
+// the commit is followed in the background. Asynchronous transactions are not retried, their status reports the
+// conflict.
+func (tracker *Tracker) Submit(ctx context.Context, contract *client.Contract, name string, args ...string) ([]byte, error) {
+	if tracker == nil {
+		return contract.SubmitTransaction(name, args...)
+	}
+	async, ok := ctx.Value(preferenceKey{}).(preference)
+	if !ok {
+		result, retries, err := tracker.retry(ctx, contract.ChaincodeName(), name, func() ([]byte, error) {
+			return tracker.submit(contract, name, args)
+		})
+		setRetries(ctx, retries)
+		return result, err
+	}
+
+	result, commit, err := tracker.submitAsync(contract, name, args)
+	if err != nil {
+		return nil, err
+	}
+	status := tracker.track(commit, contract.ChaincodeName(), name, result, async)
+	return nil, &Accepted{Status: status}
+}
+
+// Synchronous returns a context whose transactions are submitted and waited for like those of a request without
+// respond-async, for handlers that need the results of their transactions to carry on
+func Synchronous(ctx context.Context) context.Context {
+	return context.WithValue(ctx, preferenceKey{}, nil)
+}
+
+// submitAsync endorses the transaction and sends it to the orderer, as contract.SubmitAsync does
+func (tracker *Tracker) submitAsync(contract *client.Contract, name string, args []string) ([]byte, *client.Commit, error) {
+	proposal, err := contract.NewProposal(name, client.WithArguments(args...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start := time.Now()
+	endorsed, err := proposal.Endorse()
+	tracker.observePhase(contract.ChaincodeName(), name, PhaseEndorse, start)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start = time.Now()
+	commit, err := endorsed.Submit()
+	tracker.observePhase(contract.ChaincodeName(), name, PhaseSubmit, start)
+	if err != nil {
+		return endorsed.Result(), nil, err
+	}
+	return endorsed.Result(), commit, nil
+}
+
+// submit submits the transaction and waits for its commit, as contract.SubmitTransaction does
+func (tracker *Tracker) submit(contract *client.Contract, name string, args []string) ([]byte, error) {
+	result, commit, err := tracker.submitAsync(contract, name, args)
+	if err != nil {
+		return result, err
+	}
+
+	start := time.Now()
+	status, err := commit.Status()
+	tracker.observePhase(contract.ChaincodeName(), name, PhaseCommit, start)
+	if err != nil {
+		return result, err
+	}
+	tracker.observeCommit(contract.ChaincodeName(), name, status.Code)
+	if !status.Successful {
+		return nil, NewCommitError(status.TransactionID, status.Code)
+	}
+	return result, nil
+}
+
+// NewCommitError returns the error of a transaction the peers did not validate, for transactions whose commit
+// status was not read by the tracker
+func NewCommitError(transactionID string, code peer.TxValidationCode) error {
+	return &commitError{&client.CommitError{TransactionID: transactionID, Code: code}}
+}
+
+// commitError is a *client.CommitError with the message the gateway client gives it, which cannot be set
+// outside of the client package
+type commitError struct {
+	err *client.CommitError
+}
+
+func (e *commitError) Error() string {
+	return fmt.Sprintf("transaction %s failed to commit with status code %d (%s)", e.err.TransactionID, int32(e.err.Code), e.err.Code)
+}
+
+func (e *commitError) Unwrap() error {
+	return e.err
+}
+
+func (tracker *Tracker) observePhase(chaincode string, function string, phase string, start time.Time) {
+	if tracker.options.Observer != nil {
+		tracker.options.Observer.ObservePhase(chaincode, function, phase, time.Since(start))
+	}
+}
+
+func (tracker *Tracker) observeCommit(chaincode string, function string, code peer.TxValidationCode) {
+	if tracker.options.Observer != nil {
+		tracker.options.Observer.ObserveCommit(chaincode, function, code)
+	}
+}
+
+// track records the ordered transaction and follows its commit in the background
+func (tracker *Tracker) track(commit committer, chaincode string, name string, result []byte, async preference) Status {
+	status := Status{
+		TransactionID: commit.TransactionID(),
+		Chaincode:     chaincode,
+		Function:      name,
+		Status:        StatusOrdered,
+		Result:        resultJSON(result),
+		SubmittedAt:   time.Now().UTC(),
+	}
+
+	tracker.mu.Lock()
+	tracker.prune()
+	tracker.records[status.TransactionID] = &record{status: status, owner: async.owner, callback: async.callback}
+	tracker.wg.Add(1)
+	tracker.mu.Unlock()
+
+	go tracker.follow(commit)
+	return status
+}
+
+// resultJSON returns the result of the chaincode as JSON, as it is when the chaincode returned JSON and as a
+// string otherwise
+func resultJSON(result []byte) json.RawMessage {
+	if len(result) == 0 {
+		return nil
+	}
+	if json.Valid(result) {
+		return result
+	}
+	encoded, _ := json.Marshal(string(result))
+	return encoded
+}
+
+// prune forgets the transactions whose commit has been known for longer than the retention
+func (tracker *Tracker) prune() {
+	cutoff := time.Now().Add(-tracker.options.Retention)
+	for id, record := range tracker.records {
+		if !record.final.IsZero() && record.final.Before(cutoff) {
+			delete(tracker.records, id)
+		}
+	}
+}
+
+// follow waits for the commit of the transaction, records its status and posts it to the callback
+func (tracker *Tracker) follow(commit committer) {
+	defer tracker.wg.Done()
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(tracker.ctx, tracker.options.CommitTimeout)
+	commitStatus, err := commit.StatusWithContext(ctx)
+	cancel()
+
+	tracker.mu.Lock()
+	record := tracker.records[commit.TransactionID()]
+	chaincode, function := record.status.Chaincode, record.status.Function
+	if err != nil {
+		record.status.Status = StatusUnknown
+		record.status.Error = fmt.Sprintf("failed to get the commit status: %v", err)
+	} else {
+		committedAt := time.Now().UTC()
+		record.status.Status = StatusCommitted
+		if !commitStatus.Successful {
+			record.status.Status = StatusInvalid
+		}
+		record.status.BlockNumber = commitStatus.BlockNumber
+		record.status.ValidationCode = commitStatus.Code.String()
+		record.status.CommittedAt = &committedAt
+	}
+	record.final = time.Now()
+	status, callback := record.status, record.callback
+	tracker.mu.Unlock()
+
+	tracker.observePhase(chaincode, function, PhaseCommit, start)
+	if err == nil {
+		tracker.observeCommit(chaincode, function, commitStatus.Code)
+	}
+
+	if callback != "" {
+		tracker.post(callback, status)
+	}
+}
+
+// post posts the status to the callback URL, and tries again with a growing delay while it fails
+func (tracker *Tracker) post(callback string, status Status) {
+	body, err := json.Marshal(status)
+	if err != nil {
+		log.Printf("failed to encode the status of transaction %s: %v", status.TransactionID, err)
+		return
+	}
+
+	delay := time.Second
+	for attempt := 1; ; attempt++ {
+		err = tracker.postOnce(callback, body)
+		if err == nil {
+			return
+		}
+		if attempt == callbackAttempts {
+			log.Printf("giving up the callback of transaction %s: %v", status.TransactionID, err)
+			return
+		}
+
+		select {
+		case <-tracker.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (tracker *Tracker) postOnce(callback string, body []byte) error {
+	request, err := http.NewRequestWithContext(tracker.ctx, http.MethodPost, callback, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := tracker.options.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("callback %s answered %s", callback, response.Status)
+	}
+	return nil
+}
+
+// Get returns the status of the transaction if it was submitted asynchronously by the owner, the subject of the
+// user who submitted it or empty when requests are not authenticated
+func (tracker *Tracker) Get(transactionID string, owner string) (Status, bool) {
+	if tracker == nil {
+		return Status{}, false
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	record, ok := tracker.records[transactionID]
+	if !ok || record.owner != owner {
+		return Status{}, false
+	}
+	return record.status, true
+}
+
+// RespondStatus responds with the status of the transaction, or with 404 when it is not known or was submitted
+// by another user
+func (tracker *Tracker) RespondStatus(c *gin.Context, transactionID string) {
+	owner := ""
+	if principal, ok := auth.PrincipalFrom(c); ok {
+		owner = principal.Subject
+	}
+
+	status, ok := tracker.Get(transactionID, owner)
+	if !ok {
+		apierror.Respond(c, apierror.NotFound("transaction "+transactionID+" was not submitted asynchronously or is no longer tracked"))
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
+// Close stops following the commits and waits for the callbacks in flight to give up. Transactions that were
+// not committed yet still commit, but their status is no longer recorded.
+func (tracker *Tracker) Close() {
+	if tracker == nil {
+		return
+	}
+	tracker.cancel()
+	tracker.wg.Wait()
+}
+
+// Accepted is returned by Submit instead of the result of a transaction that was only ordered, because the
+// client asked not to wait for its commit. It is not a failure, apierror.Respond answers it with 202.
+type Accepted struct {
+	Status Status
+}
+
+func (accepted *Accepted) Error() string {
+	return "transaction " + accepted.Status.TransactionID + " was ordered without waiting for its commit"
+}
+
+// Respond responds with 202 and the status of the transaction, which is followed at /tx/{id}
+func (accepted *Accepted) Respond(c *gin.Context) {
+	c.Header("Preference-Applied", "respond-async")
+	c.Header("Location", "/tx/"+url.PathEscape(accepted.Status.TransactionID))
+	c.AbortWithStatusJSON(http.StatusAccepted, accepted.Status)
+}
@@ -0,0 +1,308 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/nalle631/fabric-network/application/shared/apierror"
+	"google.golang.org/grpc"
+)
+
+// fakeCommit is the commit of an ordered transaction, which commits when the test releases it
+type fakeCommit struct {
+	id      string
+	release chan struct{}
+	status  *client.Status
+	err     error
+}
+
+func newFakeCommit(id string, code peer.TxValidationCode, err error) *fakeCommit {
+	return &fakeCommit{
+		id:      id,
+		release: make(chan struct{}),
+		status:  &client.Status{TransactionID: id, Code: code, Successful: code == peer.TxValidationCode_VALID, BlockNumber: 7},
+		err:     err,
+	}
+}
+
+func (commit *fakeCommit) TransactionID() string {
+	return commit.id
+}
+
+func (commit *fakeCommit) StatusWithContext(ctx context.Context, _ ...grpc.CallOption) (*client.Status, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-commit.release:
+	}
+	if commit.err != nil {
+		return nil, commit.err
+	}
+	return commit.status, nil
+}
+
+// waitFinal waits until the commit of the transaction is known
+func waitFinal(t *testing.T, tracker *Tracker, id string, owner string) Status {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		status, ok := tracker.Get(id, owner)
+		if !ok {
+			t.Fatalf("transaction %s is not tracked", id)
+		}
+		if status.Final() {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the commit of transaction %s was not recorded", id)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTrackFollowsTheCommitAndPostsItToTheCallback(t *testing.T) {
+	callbacks := make(chan Status, 1)
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first attempt fails, so the callback is tried again
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var status Status
+		if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+			t.Error(err)
+		}
+		callbacks <- status
+	}))
+	defer server.Close()
+
+	// the test server listens on the loopback address, which the default client refuses to post to
+	tracker := NewTracker(Options{HTTPClient: server.Client()})
+	defer tracker.Close()
+
+	commit := newFakeCommit("tx1", peer.TxValidationCode_VALID, nil)
+	accepted := tracker.track(commit, "customer", "CreateSLA", []byte(`{"ID":"sla1"}`), preference{callback: server.URL, owner: "alice"})
+	if accepted.Status != StatusOrdered || accepted.TransactionID != "tx1" || string(accepted.Result) != `{"ID":"sla1"}` {
+		t.Fatalf("accepted = %+v", accepted)
+	}
+
+	if status, ok := tracker.Get("tx1", "alice"); !ok || status.Final() {
+		t.Fatalf("status before the commit = %+v, %v", status, ok)
+	}
+	if _, ok := tracker.Get("tx1", "bob"); ok {
+		t.Fatal("another user must not see the transaction")
+	}
+
+	close(commit.release)
+	status := waitFinal(t, tracker, "tx1", "alice")
+	if status.Status != StatusCommitted || status.BlockNumber != 7 || status.ValidationCode != "VALID" || status.CommittedAt == nil {
+		t.Fatalf("status = %+v", status)
+	}
+
+	select {
+	case posted := <-callbacks:
+		if posted.TransactionID != "tx1" || posted.Status != StatusCommitted {
+			t.Fatalf("callback = %+v", posted)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the callback was not posted")
+	}
+}
+
+func TestTrackRecordsInvalidAndUnknownCommits(t *testing.T) {
+	tracker := NewTracker(Options{})
+	defer tracker.Close()
+
+	conflict := newFakeCommit("tx1", peer.TxValidationCode_MVCC_READ_CONFLICT, nil)
+	failed := newFakeCommit("tx2", peer.TxValidationCode_VALID, errors.New("connection refused"))
+	tracker.track(conflict, "gc", "TakeJob", []byte("not json"), preference{})
+	tracker.track(failed, "gc", "TakeJob", nil, preference{})
+	close(conflict.release)
+	close(failed.release)
+
+	status := waitFinal(t, tracker, "tx1", "")
+	if status.Status != StatusInvalid || status.ValidationCode != "MVCC_READ_CONFLICT" || string(status.Result) != `"not json"` {
+		t.Fatalf("status of the conflicting transaction = %+v", status)
+	}
+	status = waitFinal(t, tracker, "tx2", "")
+	if status.Status != StatusUnknown || status.Error == "" || status.BlockNumber != 0 {
+		t.Fatalf("status of the transaction without commit status = %+v", status)
+	}
+}
+
+func TestCloseStopsFollowing(t *testing.T) {
+	tracker := NewTracker(Options{})
+	tracker.track(newFakeCommit("tx1", peer.TxValidationCode_VALID, nil), "gc", "TakeJob", nil, preference{})
+
+	tracker.Close()
+	if status, _ := tracker.Get("tx1", ""); status.Status != StatusUnknown {
+		t.Fatalf("status after closing = %+v", status)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tracker := NewTracker(Options{})
+	defer tracker.Close()
+
+	router := gin.New()
+	router.Use(tracker.Middleware())
+	router.POST("/sla", func(c *gin.Context) {
+		async, ok := c.Request.Context().Value(preferenceKey{}).(preference)
+		if !ok {
+			c.String(http.StatusOK, "sync")
+			return
+		}
+		c.String(http.StatusOK, "async "+async.callback)
+	})
+
+	tests := []struct {
+		name     string
+		prefer   string
+		callback string
+		status   int
+		body     string
+	}{
+		{"no preference", "", "", http.StatusOK, "sync"},
+		{"other preference", "return=minimal", "", http.StatusOK, "sync"},
+		{"respond-async", "respond-async", "", http.StatusOK, "async "},
+		{"among others", "return=minimal, Respond-Async; wait=5", "", http.StatusOK, "async "},
+		{"callback", "respond-async", "https://client.example.com/hooks/tx", http.StatusOK, "async https://client.example.com/hooks/tx"},
+		{"relative callback", "respond-async", "/hooks/tx", http.StatusBadRequest, ""},
+		{"callback without http", "respond-async", "file:///etc/passwd", http.StatusBadRequest, ""},
+		{"callback to the loopback address", "respond-async", "http://127.0.0.1:5001/tx", http.StatusBadRequest, ""},
+		{"callback to the metadata service", "respond-async", "http://169.254.169.254/latest/meta-data", http.StatusBadRequest, ""},
+		{"callback to a private IPv6 address", "respond-async", "http://[fd00::1]/hooks", http.StatusBadRequest, ""},
+	}
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodPost, "/sla", nil)
+		if test.prefer != "" {
+			request.Header.Set("Prefer", test.prefer)
+		}
+		if test.callback != "" {
+			request.Header.Set(CallbackHeader, test.callback)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.status || (test.body != "" && recorder.Body.String() != test.body) {
+			t.Errorf("%s: %d %q, want %d %q", test.name, recorder.Code, recorder.Body.String(), test.status, test.body)
+		}
+	}
+}
+
+func TestAcceptedRespondsWith202(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodPost, "/job/take", nil)
+
+	accepted := &Accepted{Status: Status{TransactionID: "tx1", Chaincode: "gc", Function: "TakeJob", Status: StatusOrdered}}
+	apierror.Respond(c, fmt.Errorf("failed to submit transaction: %w", accepted))
+
+	if recorder.Code != http.StatusAccepted || recorder.Header().Get("Location") != "/tx/tx1" {
+		t.Fatalf("status = %d, headers = %v", recorder.Code, recorder.Header())
+	}
+	var status Status
+	if err := json.Unmarshal(recorder.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.TransactionID != "tx1" || status.Status != StatusOrdered {
+		t.Fatalf("body = %+v", status)
+	}
+}
+
+// recordingObserver records the phases and validation codes it is told about
+type recordingObserver struct {
+	mu     sync.Mutex
+	phases []string
+	codes  []peer.TxValidationCode
+}
+
+func (observer *recordingObserver) ObservePhase(chaincode string, function string, phase string, _ time.Duration) {
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	observer.phases = append(observer.phases, chaincode+"."+function+" "+phase)
+}
+
+func (observer *recordingObserver) ObserveCommit(_ string, _ string, code peer.TxValidationCode) {
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	observer.codes = append(observer.codes, code)
+}
+
+func TestFollowObservesTheCommit(t *testing.T) {
+	observer := &recordingObserver{}
+	tracker := NewTracker(Options{Observer: observer})
+	defer tracker.Close()
+
+	commit := newFakeCommit("tx1", peer.TxValidationCode_MVCC_READ_CONFLICT, nil)
+	tracker.track(commit, "gc", "TakeJob", nil, preference{})
+	close(commit.release)
+	waitFinal(t, tracker, "tx1", "")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		observer.mu.Lock()
+		phases, codes := observer.phases, observer.codes
+		observer.mu.Unlock()
+		if len(codes) == 1 {
+			if len(phases) != 1 || phases[0] != "gc.TakeJob commit" || codes[0] != peer.TxValidationCode_MVCC_READ_CONFLICT {
+				t.Fatalf("observed %v and %v", phases, codes)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the commit was not observed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCommitErrorIsACommitError(t *testing.T) {
+	err := fmt.Errorf("failed to submit transaction: %w", NewCommitError("tx1", peer.TxValidationCode_MVCC_READ_CONFLICT))
+
+	if code, ok := Retryable(err); !ok || code != peer.TxValidationCode_MVCC_READ_CONFLICT {
+		t.Fatalf("Retryable() = %v, %v", code, ok)
+	}
+	response := apierror.From(err)
+	if response.Status != http.StatusConflict || response.TransactionID != "tx1" {
+		t.Fatalf("response = %+v", response)
+	}
+	if want := "transaction tx1 failed to commit with status code 11 (MVCC_READ_CONFLICT)"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("message = %q", err.Error())
+	}
+}
+
+func TestSynchronousIgnoresRespondAsync(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tracker := NewTracker(Options{})
+	defer tracker.Close()
+
+	router := gin.New()
+	router.Use(tracker.Middleware())
+	router.POST("/settlements/run", func(c *gin.Context) {
+		if _, ok := c.Request.Context().Value(preferenceKey{}).(preference); !ok {
+			t.Error("the request did not ask for respond-async")
+		}
+		if _, ok := Synchronous(c.Request.Context()).Value(preferenceKey{}).(preference); ok {
+			t.Error("the transactions of a synchronous context are submitted asynchronously")
+		}
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/settlements/run", nil)
+	request.Header.Set("Prefer", "respond-async")
+	router.ServeHTTP(httptest.NewRecorder(), request)
+}
@@ -0,0 +1,234 @@
+// Package wallet stores the enrolled Fabric identities the REST applications transact as on behalf of their users.
+// Identities are kept in the JSON format of the wallets of the Fabric SDKs, one file per label, so a wallet
+// written by another Fabric application or by fabric-ca-client can be used as is.
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+	"github.com/nalle631/fabric-network/application/shared/fabric"
+)
+
+const (
+	identityType = "X.509"
+	fileSuffix   = ".id"
+)
+
+// ErrNotFound is returned for a label that has no identity in the wallet
+var ErrNotFound = errors.New("identity not found in wallet")
+
+// Identity is an enrolled X.509 identity with its private key
+type Identity struct {
+	Credentials Credentials `json:"credentials"`
+	MSPID       string      `json:"mspId"`
+	Type        string      `json:"type"`
+	Version     int         `json:"version"`
+}
+
+// Credentials are the PEM encoded certificate and private key of an identity
+type Credentials struct {
+	Certificate string `json:"certificate"`
+	PrivateKey  string `json:"privateKey"`
+}
+
+// NewIdentity creates an identity from the PEM encoded certificate and private key
+func NewIdentity(mspID string, certificatePEM []byte, privateKeyPEM []byte) (*Identity, error) {
+	id := &Identity{
+		Credentials: Credentials{Certificate: string(certificatePEM), PrivateKey: string(privateKeyPEM)},
+		MSPID:       mspID,
+		Type:        identityType,
+		Version:     1,
+	}
+
+	_, _, err := id.Load()
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+// FromMSP creates an identity from the signing certificate and key of an MSP directory, as written by fabric-ca-client enroll
+func FromMSP(mspID string, mspDir string) (*Identity, error) {
+	certificatePEM, err := readFirst(filepath.Join(mspDir, "signcerts"))
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeyPEM, err := readFirst(filepath.Join(mspDir, "keystore"))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIdentity(mspID, certificatePEM, privateKeyPEM)
+}
+
+func readFirst(dir string) ([]byte, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s is empty", dir)
+	}
+	return os.ReadFile(filepath.Join(dir, files[0].Name()))
+}
+
+// Load returns the client identity and signing function of the identity
+func (id *Identity) Load() (identity.Identity, identity.Sign, error) {
+	if id.Type != identityType {
+		return nil, nil, fmt.Errorf("unsupported identity type %s", id.Type)
+	}
+
+	certificate, err := identity.CertificateFromPEM([]byte(id.Credentials.Certificate))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+
+	x509Identity, err := identity.NewX509Identity(id.MSPID, certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, err := identity.PrivateKeyFromPEM([]byte(id.Credentials.PrivateKey))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	sign, err := identity.NewPrivateKeySign(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return x509Identity, sign, nil
+}
+
+// Store holds identities by label
+type Store interface {
+	Get(label string) (*Identity, error)
+	Put(label string, id *Identity) error
+	Remove(label string) error
+	List() ([]string, error)
+}
+
+// Loader returns the loader of the identity with the label for fabric.Gateway.ForIdentity
+func Loader(store Store, label string) fabric.IdentityLoader {
+	return func() (identity.Identity, identity.Sign, error) {
+		id, err := store.Get(label)
+		if err != nil {
+			return nil, nil, err
+		}
+		return id.Load()
+	}
+}
+
+// FileStore keeps every identity in a file named after its label in a directory
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore opens the wallet in the directory, which is created if it does not exist
+func NewFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create wallet directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (store *FileStore) path(label string) (string, error) {
+	if label == "" || label == "." || label == ".." || strings.ContainsAny(label, `/\`) {
+		return "", fmt.Errorf("invalid identity label %q", label)
+	}
+	return filepath.Join(store.dir, label+fileSuffix), nil
+}
+
+// Get returns the identity with the label, or ErrNotFound
+func (store *FileStore) Get(label string) (*Identity, error) {
+	path, err := store.path(label)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, label)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var id Identity
+	err = json.Unmarshal(content, &id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity %s: %w", label, err)
+	}
+	return &id, nil
+}
+
+// Put writes the identity under the label, replacing any identity with the same label
+func (store *FileStore) Put(label string, id *Identity) error {
+	path, err := store.path(label)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+
+	// write next to the identity and rename, so a reader never sees half an identity
+	tmp, err := os.CreateTemp(store.dir, label+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Remove deletes the identity with the label, removing an identity that does not exist is not an error
+func (store *FileStore) Remove(label string) error {
+	path, err := store.path(label)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// List returns the labels of every identity in the wallet
+func (store *FileStore) List() ([]string, error) {
+	files, err := os.ReadDir(store.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []string{}
+	for _, file := range files {
+		if !file.IsDir() && strings.HasSuffix(file.Name(), fileSuffix) {
+			labels = append(labels, strings.TrimSuffix(file.Name(), fileSuffix))
+		}
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
@@ -0,0 +1,135 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newCredentials(t *testing.T) ([]byte, []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyDER})
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "wallet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificatePEM, privateKeyPEM := newCredentials(t)
+	id, err := NewIdentity("Org1MSP", certificatePEM, privateKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Put("alice", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.Put("bob", id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := store.Get("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stored, id) {
+		t.Fatalf("Get() = %+v, want %+v", stored, id)
+	}
+
+	x509Identity, sign, err := stored.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x509Identity.MspID() != "Org1MSP" {
+		t.Fatalf("MspID() = %s", x509Identity.MspID())
+	}
+	if _, err := sign(make([]byte, 32)); err != nil {
+		t.Fatalf("sign() = %v", err)
+	}
+
+	labels, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(labels, []string{"alice", "bob"}) {
+		t.Fatalf("List() = %v", labels)
+	}
+
+	err = store.Remove("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.Get("alice")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() of a removed identity = %v, want ErrNotFound", err)
+	}
+	if err := store.Remove("alice"); err != nil {
+		t.Fatalf("Remove() of a missing identity = %v", err)
+	}
+
+	for _, label := range []string{"", "..", "../alice", `a\b`} {
+		if _, err := store.Get(label); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q) = %v, want an invalid label error", label, err)
+		}
+	}
+}
+
+func TestFromMSP(t *testing.T) {
+	certificatePEM, privateKeyPEM := newCredentials(t)
+	mspDir := t.TempDir()
+	for dir, content := range map[string][]byte{"signcerts": certificatePEM, "keystore": privateKeyPEM} {
+		err := os.MkdirAll(filepath.Join(mspDir, dir), 0o700)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(mspDir, dir, "file.pem"), content, 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	id, err := FromMSP("Org2MSP", mspDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.MSPID != "Org2MSP" || id.Credentials.Certificate != string(certificatePEM) {
+		t.Fatalf("FromMSP() = %+v", id)
+	}
+
+	_, err = NewIdentity("Org1MSP", certificatePEM, []byte("not a key"))
+	if err == nil {
+		t.Fatal("NewIdentity() with an invalid key returned no error")
+	}
+}
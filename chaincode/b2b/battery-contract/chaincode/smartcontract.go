@@ -13,6 +13,8 @@ import (
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	"github.com/nalle631/arrowheadfunctions"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
 )
 
 const (
@@ -45,14 +47,14 @@ type OffLedgerResponse struct {
 // Insert struct field in alphabetic order => to achieve determinism across languages
 // golang keeps the order when marshal to json but doesn't order automatically
 type Job struct {
-	Type          string    `json:"Type"`
-	Status        string    `json:"Status"`
-	JobPay        int       `json:"JobPay"`
-	InspectionPay int       `json:"InspectionPay"`
-	Deadline      time.Time `json:"Deadline,omitempty"`
-	ID            string    `json:"ID"`
-	Mower         string    `json:"Mower"`
-	Address       string    `json:"Address"`
+	Type          string        `json:"Type"`
+	Status        string        `json:"Status"`
+	JobPay        decimal.Money `json:"JobPay"`
+	InspectionPay decimal.Money `json:"InspectionPay"`
+	Deadline      time.Time     `json:"Deadline,omitempty"`
+	ID            string        `json:"ID"`
+	Mower         string        `json:"Mower"`
+	Address       string        `json:"Address"`
 }
 
 func (s *SmartContract) Create(ctx contractapi.TransactionContextInterface, technichianID string, jobID string, mower string, address string, deadline string) (*Job, error) {
@@ -80,6 +82,42 @@ func (s *SmartContract) Create(ctx contractapi.TransactionContextInterface, tech
 		fmt.Println("Job does not exist")
 		return nil, fmt.Errorf("Job %s does not exist off ledger", jobID)
 	}
+
+	return storeJob(ctx, jobID, mower, address, deadline)
+}
+
+// jobChaincode is the name the job contract is deployed with. It publishes the job offers of the service owner and
+// creates the jobs of the offers technicians take in the service chaincodes.
+const jobChaincode = "gc"
+
+// CreateFromOffer creates a job for a job offer published on the ledger by the service owner.
+// The offer is created from an SLA breach on the C2B channel, so the job does not exist in the external system.
+// Only the job contract may call it, after it has checked that the offer is open and taken it.
+func (s *SmartContract) CreateFromOffer(ctx contractapi.TransactionContextInterface, technichianID string, jobID string, mower string, address string, deadline string) (*Job, error) {
+	relayed, err := access.RelayedBy(ctx.GetStub(), jobChaincode)
+	if err != nil {
+		return nil, err
+	}
+	if !relayed {
+		return nil, fmt.Errorf("jobs can only be created from offers taken in the %s chaincode", jobChaincode)
+	}
+
+	jobExistsOnLedger, err := s.JobExistsOnLedger(ctx, jobID)
+	if err != nil {
+		fmt.Println("Error when checking if job exists on ledger: ", err)
+		return nil, err
+	}
+
+	if jobExistsOnLedger {
+		fmt.Println("Job already exists on ledger")
+		return nil, fmt.Errorf("Job %s already exists on ledger", jobID)
+	}
+
+	return storeJob(ctx, jobID, mower, address, deadline)
+}
+
+// storeJob puts a new ongoing job with the given deadline to the world state
+func storeJob(ctx contractapi.TransactionContextInterface, jobID string, mower string, address string, deadline string) (*Job, error) {
 	timeDeadline, err := time.Parse("2006-01-02 15:04:05", deadline)
 	if err != nil {
 		fmt.Println("Error parsing deadline: ", err)
@@ -88,8 +126,8 @@ func (s *SmartContract) Create(ctx contractapi.TransactionContextInterface, tech
 	job := Job{
 		Type:          "battery-change",
 		Status:        "Ongoing",
-		JobPay:        200,
-		InspectionPay: 50,
+		JobPay:        decimal.NewMoney(decimal.FromInt(200), decimal.DefaultCurrency),
+		InspectionPay: decimal.NewMoney(decimal.FromInt(50), decimal.DefaultCurrency),
 		ID:            jobID,
 		Deadline:      timeDeadline,
 		Mower:         mower,
@@ -0,0 +1,220 @@
+package gc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+)
+
+const (
+	// jobOfferObjectType prefixes the composite keys job offers are stored under, so they never clash with
+	// the general contracts that are keyed by MSP ID
+	jobOfferObjectType = "offer"
+
+	offerOpen  = "Open"
+	offerTaken = "Taken"
+)
+
+// serviceChaincodes are the names of the service chaincodes a job offer can be taken in
+var serviceChaincodes = map[string]bool{"razor": true, "trapped": true, "battery": true, "bumpy": true}
+
+// serviceLevels are the service levels of the SLAs a job offer can be created for
+var serviceLevels = map[string]bool{"standard": true, "gold": true, "platinum": true}
+
+// JobOffer is a job published on the ledger by the service owner, created from a breached SLA on the C2B channel.
+// Service providers take it with TakeJob like any other job.
+type JobOffer struct {
+	ID           string    `json:"ID"`
+	ServiceType  string    `json:"ServiceType"`
+	ServiceLevel string    `json:"ServiceLevel"`
+	Mower        string    `json:"Mower"`
+	Address      string    `json:"Address"`
+	Deadline     time.Time `json:"Deadline"`
+	SLAID        string    `json:"SLAID"`
+	Reason       string    `json:"Reason"`
+	Status       string    `json:"Status"`
+	TechnicianID string    `json:"TechnicianID"`
+}
+
+// CreateJobOffer publishes a job offer for a breached SLA. Only the service owner can publish offers.
+// The service type is the name of the service chaincode the job is created in, one of razor, trapped, battery and
+// bumpy, the service level is standard, gold or platinum and the deadline is in RFC 3339 format.
+func (s *SmartContract) CreateJobOffer(ctx contractapi.TransactionContextInterface, id string, serviceType string, serviceLevel string, mower string, address string, deadline string, slaID string, reason string) (*JobOffer, error) {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, err
+	}
+	if !serviceOwner {
+		return nil, fmt.Errorf("only the service owner can create job offers")
+	}
+	if !serviceChaincodes[serviceType] {
+		return nil, fmt.Errorf("unknown service type %s", serviceType)
+	}
+	if !serviceLevels[serviceLevel] {
+		return nil, fmt.Errorf("invalid service level: %s", serviceLevel)
+	}
+
+	exists, err := s.JobOfferExists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, fmt.Errorf("the job offer %s already exists", id)
+	}
+
+	timeDeadline, err := time.Parse(time.RFC3339, deadline)
+	if err != nil {
+		fmt.Println("Error parsing deadline: ", err)
+		return nil, err
+	}
+
+	offer := JobOffer{
+		ID:           id,
+		ServiceType:  serviceType,
+		ServiceLevel: serviceLevel,
+		Mower:        mower,
+		Address:      address,
+		Deadline:     timeDeadline,
+		SLAID:        slaID,
+		Reason:       reason,
+		Status:       offerOpen,
+	}
+
+	err = putJobOffer(ctx, &offer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &offer, nil
+}
+
+// ReadJobOffer returns the job offer stored in the world state with given id
+func (s *SmartContract) ReadJobOffer(ctx contractapi.TransactionContextInterface, id string) (*JobOffer, error) {
+	offer, err := readJobOffer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if offer == nil {
+		return nil, fmt.Errorf("the job offer %s does not exist", id)
+	}
+
+	return offer, nil
+}
+
+// JobOfferExists returns true when a job offer with given id exists in world state
+func (s *SmartContract) JobOfferExists(ctx contractapi.TransactionContextInterface, id string) (bool, error) {
+	offer, err := readJobOffer(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return offer != nil, nil
+}
+
+// GetOpenJobOffers returns every job offer that has not been taken yet
+func (s *SmartContract) GetOpenJobOffers(ctx contractapi.TransactionContextInterface) ([]*JobOffer, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(jobOfferObjectType, []string{})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var offers []*JobOffer
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var offer JobOffer
+		err = json.Unmarshal(queryResponse.Value, &offer)
+		if err != nil {
+			return nil, err
+		}
+		if offer.Status == offerOpen {
+			offers = append(offers, &offer)
+		}
+	}
+
+	return offers, nil
+}
+
+// takeJobOffer creates the job of an open job offer in its service chaincode and adds it to the general contract
+// of the technician. Unlike jobs from the external system, the job is not checked off ledger.
+func takeJobOffer(ctx contractapi.TransactionContextInterface, offer *JobOffer, technichianID string) error {
+	if offer.Status != offerOpen {
+		return fmt.Errorf("the job offer %s has already been taken", offer.ID)
+	}
+	if !serviceChaincodes[offer.ServiceType] {
+		return fmt.Errorf("the job offer %s has the unknown service type %s", offer.ID, offer.ServiceType)
+	}
+
+	deadline := offer.Deadline.UTC().Format("2006-01-02 15:04:05")
+	invokeArgs := [][]byte{[]byte("CreateFromOffer"), []byte(technichianID), []byte(offer.ID), []byte(offer.Mower), []byte(offer.Address), []byte(deadline)}
+	response := ctx.GetStub().InvokeChaincode(offer.ServiceType, invokeArgs, ctx.GetStub().GetChannelID())
+	fmt.Println("response status: ", response.Status)
+	if response.Status != shim.OK {
+		return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+	}
+
+	var createdJob Job
+	err := json.Unmarshal(response.Payload, &createdJob)
+	if err != nil {
+		fmt.Println("Failed to unmarshal, ", err)
+		return err
+	}
+
+	err = addJob(ctx, &createdJob)
+	if err != nil {
+		return err
+	}
+
+	offer.Status = offerTaken
+	offer.TechnicianID = technichianID
+	return putJobOffer(ctx, offer)
+}
+
+// readJobOffer returns the job offer with given id, or nil if there is none
+func readJobOffer(ctx contractapi.TransactionContextInterface, id string) (*JobOffer, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(jobOfferObjectType, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	offerJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from world state: %v", err)
+	}
+	if offerJSON == nil {
+		return nil, nil
+	}
+
+	var offer JobOffer
+	err = json.Unmarshal(offerJSON, &offer)
+	if err != nil {
+		return nil, err
+	}
+	return &offer, nil
+}
+
+func putJobOffer(ctx contractapi.TransactionContextInterface, offer *JobOffer) error {
+	key, err := ctx.GetStub().CreateCompositeKey(jobOfferObjectType, []string{offer.ID})
+	if err != nil {
+		return err
+	}
+
+	offerJSON, err := json.Marshal(offer)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetStub().PutState(key, offerJSON)
+	if err != nil {
+		return fmt.Errorf("failed to put to world state. %v", err)
+	}
+	return nil
+}
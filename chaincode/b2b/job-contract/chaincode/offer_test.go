@@ -0,0 +1,56 @@
+package gc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+)
+
+// rejectingServiceChaincode stands in for a service chaincode that refuses to create every job
+type rejectingServiceChaincode struct{}
+
+func (rejectingServiceChaincode) Init(shim.ChaincodeStubInterface) peer.Response {
+	return shim.Success(nil)
+}
+
+func (rejectingServiceChaincode) Invoke(shim.ChaincodeStubInterface) peer.Response {
+	return shim.Error("the mower mower-1 is not serviced by this provider")
+}
+
+func TestCreateJobOfferOnlyAcceptsKnownServices(t *testing.T) {
+	network := chaincodetest.NewNetwork("mychannel")
+	jobChaincode, err := contractapi.NewChaincode(&SmartContract{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	network.Deploy("gc", jobChaincode)
+	network.Deploy("razor", rejectingServiceChaincode{})
+	technician := chaincodetest.NewIdentity(t, "TechMSP", "technician", nil)
+	serviceOwner := chaincodetest.NewIdentity(t, "Org1MSP", "service-owner", map[string]string{access.RoleAttribute: access.ServiceOwnerRole})
+
+	createOffer := func(id string, serviceType string, serviceLevel string) error {
+		_, err := network.Submit(serviceOwner, "gc", "CreateJobOffer", id, serviceType, serviceLevel, "mower-1", "Storgatan 1", "2026-06-01T12:00:00Z", "sla-1", "grass-length")
+		return err
+	}
+	for _, offer := range [][]string{{"gc", "gold"}, {"customer", "gold"}, {"", "gold"}, {"razor", "diamond"}, {"razor", ""}} {
+		if err := createOffer("offer-1", offer[0], offer[1]); err == nil {
+			t.Errorf("an offer for the %q service at level %q was created", offer[0], offer[1])
+		}
+	}
+	if err := createOffer("offer-1", "razor", "gold"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := network.Submit(technician, "gc", "CreateGeneralContract"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = network.Submit(technician, "gc", "TakeJob", "offer-1", "TechMSP")
+	if err == nil || !strings.HasSuffix(err.Error(), "Got error: the mower mower-1 is not serviced by this provider") {
+		t.Errorf("taking the offer the razor chaincode refuses failed with %v", err)
+	}
+}
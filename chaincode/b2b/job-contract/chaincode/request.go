@@ -0,0 +1,41 @@
+package gc
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/idempotency"
+)
+
+// TakeJobIdempotent is TakeJob for a request with an idempotency key. A retry with the same key returns the
+// record of the first request, with its transaction ID, instead of failing because the job already exists.
+func (s *SmartContract) TakeJobIdempotent(ctx contractapi.TransactionContextInterface, requestKey string, jobID string, technichianID string) (*idempotency.Record, error) {
+	return runIdempotent(ctx, requestKey, "TakeJob", []string{jobID, technichianID}, func() (interface{}, error) {
+		return nil, s.TakeJob(ctx, jobID, technichianID)
+	})
+}
+
+// JobDoneCorrectErrorIdempotent is JobDoneCorrectError for a request with an idempotency key. A retry with the
+// same key returns the record of the first request instead of failing because the job is already done.
+func (s *SmartContract) JobDoneCorrectErrorIdempotent(ctx contractapi.TransactionContextInterface, requestKey string, jobID string) (*idempotency.Record, error) {
+	return runIdempotent(ctx, requestKey, "JobDoneCorrectError", []string{jobID}, func() (interface{}, error) {
+		return nil, s.JobDoneCorrectError(ctx, jobID)
+	})
+}
+
+// JobDoneWrongErrorIdempotent is JobDoneWrongError for a request with an idempotency key. A retry with the
+// same key returns the record of the first request instead of failing because the job is already done.
+func (s *SmartContract) JobDoneWrongErrorIdempotent(ctx contractapi.TransactionContextInterface, requestKey string, jobID string) (*idempotency.Record, error) {
+	return runIdempotent(ctx, requestKey, "JobDoneWrongError", []string{jobID}, func() (interface{}, error) {
+		return nil, s.JobDoneWrongError(ctx, jobID)
+	})
+}
+
+// runIdempotent runs the transaction once per idempotency key of the calling identity
+func runIdempotent(ctx contractapi.TransactionContextInterface, requestKey string, function string, args []string, transaction func() (interface{}, error)) (*idempotency.Record, error) {
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client identity: %v", err)
+	}
+	return idempotency.Run(ctx.GetStub(), clientID, requestKey, function, args, transaction)
+}
@@ -0,0 +1,343 @@
+package gc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	// settlementObjectType prefixes the composite keys settlements are stored under, keyed by technician ID and period
+	settlementObjectType = "settlement"
+	// settlementPeriodLayout is the layout of the month a settlement is for
+	settlementPeriodLayout = "2006-01"
+
+	// a settlement is closed when the period is closed, pending while the payout is being made
+	// and settled once the token transaction of the payout is recorded
+	settlementClosed  = "Closed"
+	settlementPending = "Pending"
+	settlementSettled = "Settled"
+)
+
+// PayoutWallet is the account in the token network a technician is paid to, held by one of the owner nodes
+type PayoutWallet struct {
+	Node    string `json:"Node"`
+	Account string `json:"Account"`
+}
+
+// Settlement is the balance a technician earned in a closed period and how it was paid out.
+// The settlement service pays it with the token-sdk issuer and records the token transaction ID,
+// which is also written to the copy in the general contract of the technician.
+type Settlement struct {
+	ID           string        `json:"ID"`
+	TechnicianID string        `json:"TechnicianID"`
+	Period       string        `json:"Period"`
+	Amount       decimal.Money `json:"Amount"`
+	Status       string        `json:"Status"`
+	ClosedAt     time.Time     `json:"ClosedAt"`
+	PayoutWallet *PayoutWallet `json:"PayoutWallet,omitempty" metadata:",optional"`
+	TokenTxID    string        `json:"TokenTxID"`
+	SettledAt    time.Time     `json:"SettledAt"`
+}
+
+// SetPayoutWallet sets the token account the calling technician organisation is paid to
+func (s *SmartContract) SetPayoutWallet(ctx contractapi.TransactionContextInterface, node string, account string) (*GeneralContract, error) {
+	if node == "" || account == "" {
+		return nil, fmt.Errorf("the payout wallet needs both a node and an account")
+	}
+
+	technicianID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return nil, err
+	}
+
+	gc, err := s.ReadGeneralContract(ctx, technicianID)
+	if err != nil {
+		return nil, err
+	}
+
+	gc.PayoutWallet = &PayoutWallet{Node: node, Account: account}
+	err = putGeneralContract(ctx, gc)
+	if err != nil {
+		return nil, err
+	}
+
+	return gc, nil
+}
+
+// ClosePeriod closes the month given as YYYY-MM for the technician. The monthly balance becomes the amount of a new
+// settlement and the balance starts over at zero. Only the service owner can close periods, and only once per month.
+func (s *SmartContract) ClosePeriod(ctx contractapi.TransactionContextInterface, technicianID string, period string) (*Settlement, error) {
+	err := requireServiceOwner(ctx, "close periods")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = time.Parse(settlementPeriodLayout, period)
+	if err != nil {
+		return nil, fmt.Errorf("invalid period %s, expected YYYY-MM", period)
+	}
+
+	existing, err := readSettlement(ctx, technicianID, period)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("the period %s has already been closed for %s", period, technicianID)
+	}
+
+	gc, err := s.ReadGeneralContract(ctx, technicianID)
+	if err != nil {
+		return nil, err
+	}
+
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+
+	currency := gc.MonthlyBalance.Currency
+	if currency == "" {
+		currency = decimal.DefaultCurrency
+	}
+	settlement := Settlement{
+		ID:           technicianID + "-" + period,
+		TechnicianID: technicianID,
+		Period:       period,
+		Amount:       decimal.NewMoney(gc.MonthlyBalance.Amount, currency),
+		Status:       settlementClosed,
+		ClosedAt:     txTimestamp.AsTime(),
+	}
+	gc.MonthlyBalance = decimal.ZeroMoney(currency)
+
+	err = putSettlement(ctx, gc, &settlement)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settlement, nil
+}
+
+// GetClosedSettlements returns every settlement of every technician that has not been paid out yet
+func (s *SmartContract) GetClosedSettlements(ctx contractapi.TransactionContextInterface) ([]*Settlement, error) {
+	err := requireServiceOwner(ctx, "read the closed settlements")
+	if err != nil {
+		return nil, err
+	}
+
+	return querySettlements(ctx, []string{}, settlementClosed)
+}
+
+// GetSettlements returns every settlement of the technician. Technicians can only read their own settlements.
+func (s *SmartContract) GetSettlements(ctx contractapi.TransactionContextInterface, technicianID string) ([]*Settlement, error) {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, err
+	}
+	if !serviceOwner {
+		mspID, err := ctx.GetClientIdentity().GetMSPID()
+		if err != nil {
+			return nil, err
+		}
+		if mspID != technicianID {
+			return nil, fmt.Errorf("%s cannot read the settlements of %s", mspID, technicianID)
+		}
+	}
+
+	return querySettlements(ctx, []string{technicianID}, "")
+}
+
+// StartSettlement marks a closed settlement as pending before it is paid out and records the payout wallet
+// of the technician on it. A pending settlement is never paid out again, so a payout interrupted before
+// RecordSettlement has to be checked against the token network by hand.
+func (s *SmartContract) StartSettlement(ctx contractapi.TransactionContextInterface, technicianID string, period string) (*Settlement, error) {
+	err := requireServiceOwner(ctx, "settle periods")
+	if err != nil {
+		return nil, err
+	}
+
+	settlement, err := readSettlement(ctx, technicianID, period)
+	if err != nil {
+		return nil, err
+	}
+	if settlement == nil {
+		return nil, fmt.Errorf("the period %s has not been closed for %s", period, technicianID)
+	}
+	if settlement.Status != settlementClosed {
+		return nil, fmt.Errorf("the settlement %s is %s", settlement.ID, settlement.Status)
+	}
+
+	gc, err := s.ReadGeneralContract(ctx, technicianID)
+	if err != nil {
+		return nil, err
+	}
+	if gc.PayoutWallet == nil {
+		return nil, fmt.Errorf("%s has no payout wallet", technicianID)
+	}
+
+	settlement.Status = settlementPending
+	settlement.PayoutWallet = gc.PayoutWallet
+
+	err = putSettlement(ctx, gc, settlement)
+	if err != nil {
+		return nil, err
+	}
+
+	return settlement, nil
+}
+
+// RecordSettlement records the token transaction a pending settlement was paid out with,
+// both on the settlement and on the general contract of the technician
+func (s *SmartContract) RecordSettlement(ctx contractapi.TransactionContextInterface, technicianID string, period string, tokenTxID string) (*Settlement, error) {
+	err := requireServiceOwner(ctx, "settle periods")
+	if err != nil {
+		return nil, err
+	}
+	if tokenTxID == "" {
+		return nil, fmt.Errorf("the token transaction ID cannot be empty")
+	}
+
+	settlement, err := readSettlement(ctx, technicianID, period)
+	if err != nil {
+		return nil, err
+	}
+	if settlement == nil {
+		return nil, fmt.Errorf("the period %s has not been closed for %s", period, technicianID)
+	}
+	if settlement.Status != settlementPending {
+		return nil, fmt.Errorf("the settlement %s is %s, only pending settlements can be recorded", settlement.ID, settlement.Status)
+	}
+
+	gc, err := s.ReadGeneralContract(ctx, technicianID)
+	if err != nil {
+		return nil, err
+	}
+
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+
+	settlement.Status = settlementSettled
+	settlement.TokenTxID = tokenTxID
+	settlement.SettledAt = txTimestamp.AsTime()
+
+	err = putSettlement(ctx, gc, settlement)
+	if err != nil {
+		return nil, err
+	}
+
+	return settlement, nil
+}
+
+// requireServiceOwner returns an error unless the caller has been enrolled with the service owner role
+func requireServiceOwner(ctx contractapi.TransactionContextInterface, action string) error {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return err
+	}
+	if !serviceOwner {
+		return fmt.Errorf("only the service owner can %s", action)
+	}
+	return nil
+}
+
+// querySettlements returns the settlements under the partial key with the given status, or with any status if it is empty
+func querySettlements(ctx contractapi.TransactionContextInterface, keys []string, status string) ([]*Settlement, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(settlementObjectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	settlements := []*Settlement{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var settlement Settlement
+		err = json.Unmarshal(queryResponse.Value, &settlement)
+		if err != nil {
+			return nil, err
+		}
+		if status == "" || settlement.Status == status {
+			settlements = append(settlements, &settlement)
+		}
+	}
+
+	return settlements, nil
+}
+
+// readSettlement returns the settlement of the technician for the period, or nil if the period has not been closed
+func readSettlement(ctx contractapi.TransactionContextInterface, technicianID string, period string) (*Settlement, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(settlementObjectType, []string{technicianID, period})
+	if err != nil {
+		return nil, err
+	}
+
+	settlementJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from world state: %v", err)
+	}
+	if settlementJSON == nil {
+		return nil, nil
+	}
+
+	var settlement Settlement
+	err = json.Unmarshal(settlementJSON, &settlement)
+	if err != nil {
+		return nil, err
+	}
+	return &settlement, nil
+}
+
+// putSettlement writes the settlement to the world state and replaces its copy in the general contract of the technician
+func putSettlement(ctx contractapi.TransactionContextInterface, gc *GeneralContract, settlement *Settlement) error {
+	key, err := ctx.GetStub().CreateCompositeKey(settlementObjectType, []string{settlement.TechnicianID, settlement.Period})
+	if err != nil {
+		return err
+	}
+
+	settlementJSON, err := json.Marshal(settlement)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetStub().PutState(key, settlementJSON)
+	if err != nil {
+		return fmt.Errorf("failed to put to world state. %v", err)
+	}
+
+	replaced := false
+	for i := range gc.Settlements {
+		if gc.Settlements[i].ID == settlement.ID {
+			gc.Settlements[i] = *settlement
+			replaced = true
+		}
+	}
+	if !replaced {
+		gc.Settlements = append(gc.Settlements, *settlement)
+	}
+
+	return putGeneralContract(ctx, gc)
+}
+
+// putGeneralContract writes the general contract to the world state under the ID of the technician
+func putGeneralContract(ctx contractapi.TransactionContextInterface, gc *GeneralContract) error {
+	gcJSON, err := json.Marshal(gc)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetStub().PutState(gc.TechnicianID, gcJSON)
+	if err != nil {
+		return fmt.Errorf("failed to put to world state. %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package gc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// settlementTest is a technician channel where the technician organisation TechMSP has earned 150 SEK this month
+type settlementTest struct {
+	t            *testing.T
+	network      *chaincodetest.Network
+	technician   *chaincodetest.Identity
+	serviceOwner *chaincodetest.Identity
+}
+
+func newSettlementTest(t *testing.T) *settlementTest {
+	t.Helper()
+
+	test := &settlementTest{
+		t:            t,
+		network:      chaincodetest.NewNetwork("mychannel"),
+		technician:   chaincodetest.NewIdentity(t, "TechMSP", "technician", nil),
+		serviceOwner: chaincodetest.NewIdentity(t, "Org1MSP", "service-owner", map[string]string{access.RoleAttribute: access.ServiceOwnerRole}),
+	}
+
+	jobChaincode, err := contractapi.NewChaincode(&SmartContract{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub := test.network.Deploy("gc", jobChaincode)
+	if _, err := test.network.Submit(test.technician, "gc", "CreateGeneralContract"); err != nil {
+		t.Fatal(err)
+	}
+
+	stub.MockTransactionStart("earnings")
+	defer stub.MockTransactionEnd("earnings")
+	var gc GeneralContract
+	err = json.Unmarshal(stub.State["TechMSP"], &gc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gc.MonthlyBalance = decimal.NewMoney(decimal.FromInt(150), decimal.DefaultCurrency)
+	gcJSON, err := json.Marshal(gc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stub.PutState("TechMSP", gcJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return test
+}
+
+// settle submits a settlement transaction as the identity and returns the settlement, or nil when it fails
+func (test *settlementTest) settle(identity *chaincodetest.Identity, function string, args ...string) *Settlement {
+	test.t.Helper()
+
+	settlementJSON, err := test.network.Submit(identity, "gc", function, args...)
+	if err != nil {
+		return nil
+	}
+	var settlement Settlement
+	err = json.Unmarshal(settlementJSON, &settlement)
+	if err != nil {
+		test.t.Fatal(err)
+	}
+	return &settlement
+}
+
+func (test *settlementTest) generalContract() *GeneralContract {
+	test.t.Helper()
+
+	gcJSON, err := test.network.Submit(test.technician, "gc", "ReadGeneralContract", "TechMSP")
+	if err != nil {
+		test.t.Fatal(err)
+	}
+	var gc GeneralContract
+	err = json.Unmarshal(gcJSON, &gc)
+	if err != nil {
+		test.t.Fatal(err)
+	}
+	return &gc
+}
+
+func TestSettlementStateMachine(t *testing.T) {
+	test := newSettlementTest(t)
+
+	if test.settle(test.technician, "ClosePeriod", "TechMSP", "2026-05") != nil {
+		t.Error("the technician closed its own period")
+	}
+	if test.settle(test.serviceOwner, "ClosePeriod", "TechMSP", "2026-13") != nil {
+		t.Error("a period that is not a month was closed")
+	}
+	if test.settle(test.serviceOwner, "StartSettlement", "TechMSP", "2026-05") != nil {
+		t.Error("a period that has not been closed was settled")
+	}
+
+	closed := test.settle(test.serviceOwner, "ClosePeriod", "TechMSP", "2026-05")
+	if closed == nil || closed.Status != settlementClosed || closed.Amount != decimal.NewMoney("150.00", decimal.DefaultCurrency) {
+		t.Fatalf("the closed settlement is %+v", closed)
+	}
+	if gc := test.generalContract(); !gc.MonthlyBalance.IsZero() || len(gc.Settlements) != 1 {
+		t.Errorf("after closing the period the general contract has the balance %s and %d settlements", gc.MonthlyBalance, len(gc.Settlements))
+	}
+	if test.settle(test.serviceOwner, "ClosePeriod", "TechMSP", "2026-05") != nil {
+		t.Error("a period was closed twice")
+	}
+	if test.settle(test.serviceOwner, "RecordSettlement", "TechMSP", "2026-05", "token-tx-1") != nil {
+		t.Error("a settlement was recorded before it was started")
+	}
+
+	if test.settle(test.serviceOwner, "StartSettlement", "TechMSP", "2026-05") != nil {
+		t.Error("a settlement was started without a payout wallet")
+	}
+	if _, err := test.network.Submit(test.technician, "gc", "SetPayoutWallet", "owner1", "tech-account"); err != nil {
+		t.Fatal(err)
+	}
+	if test.settle(test.technician, "StartSettlement", "TechMSP", "2026-05") != nil {
+		t.Error("the technician started its own settlement")
+	}
+	pending := test.settle(test.serviceOwner, "StartSettlement", "TechMSP", "2026-05")
+	if pending == nil || pending.Status != settlementPending || pending.PayoutWallet == nil || pending.PayoutWallet.Account != "tech-account" {
+		t.Fatalf("the pending settlement is %+v", pending)
+	}
+	if test.settle(test.serviceOwner, "StartSettlement", "TechMSP", "2026-05") != nil {
+		t.Error("a pending settlement was started again, so it could be paid out twice")
+	}
+
+	if test.settle(test.serviceOwner, "RecordSettlement", "TechMSP", "2026-05", "") != nil {
+		t.Error("a settlement was recorded without a token transaction")
+	}
+	settled := test.settle(test.serviceOwner, "RecordSettlement", "TechMSP", "2026-05", "token-tx-1")
+	if settled == nil || settled.Status != settlementSettled || settled.TokenTxID != "token-tx-1" {
+		t.Fatalf("the settled settlement is %+v", settled)
+	}
+	if test.settle(test.serviceOwner, "RecordSettlement", "TechMSP", "2026-05", "token-tx-2") != nil {
+		t.Error("a settled settlement was recorded again")
+	}
+
+	gc := test.generalContract()
+	if len(gc.Settlements) != 1 || gc.Settlements[0].Status != settlementSettled || gc.Settlements[0].TokenTxID != "token-tx-1" {
+		t.Errorf("the settlements in the general contract are %+v", gc.Settlements)
+	}
+	closedJSON, err := test.network.Submit(test.serviceOwner, "gc", "GetClosedSettlements")
+	if err != nil || string(closedJSON) != "[]" {
+		t.Errorf("the closed settlements after settling are %s, %v", closedJSON, err)
+	}
+}
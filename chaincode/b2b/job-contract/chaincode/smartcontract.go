@@ -16,6 +16,7 @@ import (
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
 )
 
 const (
@@ -36,21 +37,23 @@ type SmartContract struct {
 // Insert struct field in alphabetic order => to achieve determinism across languages
 // golang keeps the order when marshal to json but doesn't order automatically
 type Job struct {
-	Type          string    `json:"Type"`
-	Status        string    `json:"Status"`
-	JobPay        int       `json:"JobPay"`
-	InspectionPay int       `json:"InspectionPay"`
-	Deadline      time.Time `json:"Deadline,omitempty"`
-	ID            string    `json:"ID"`
-	Mower         string    `json:"Mower"`
-	Address       string    `json:"Address"`
+	Type          string        `json:"Type"`
+	Status        string        `json:"Status"`
+	JobPay        decimal.Money `json:"JobPay"`
+	InspectionPay decimal.Money `json:"InspectionPay"`
+	Deadline      time.Time     `json:"Deadline,omitempty"`
+	ID            string        `json:"ID"`
+	Mower         string        `json:"Mower"`
+	Address       string        `json:"Address"`
 }
 
 type GeneralContract struct {
-	TechnicianID   string   `json:"TechnicianID"`
-	MonthlyBalance int      `json:"MonthlyBalance"`
-	Jobs           []Job    `json:"Jobs"`
-	JobAuthority   []string `json:"JobAuthority"`
+	TechnicianID   string        `json:"TechnicianID"`
+	MonthlyBalance decimal.Money `json:"MonthlyBalance"`
+	Jobs           []Job         `json:"Jobs"`
+	JobAuthority   []string      `json:"JobAuthority"`
+	PayoutWallet   *PayoutWallet `json:"PayoutWallet,omitempty" metadata:",optional"`
+	Settlements    []Settlement  `json:"Settlements,omitempty" metadata:",optional"`
 }
 
 type OffLedgerResponse struct {
@@ -113,7 +116,7 @@ func (s *SmartContract) CreateGeneralContract(ctx contractapi.TransactionContext
 
 	gc := GeneralContract{
 		TechnicianID:   gcID,
-		MonthlyBalance: 0,
+		MonthlyBalance: decimal.ZeroMoney(decimal.DefaultCurrency),
 		Jobs:           []Job{},
 		JobAuthority:   []string{},
 	}
@@ -147,6 +150,15 @@ func (s *SmartContract) TakeJob(ctx contractapi.TransactionContextInterface, job
 		return fmt.Errorf("Job %s already exists on ledger", jobID)
 	}
 
+	// jobs created from SLA breaches are offered on the ledger instead of in the external system
+	offer, err := readJobOffer(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if offer != nil {
+		return takeJobOffer(ctx, offer, technichianID)
+	}
+
 	// Remember to remove jobtype when integrated with jespers system
 	jobInfo, err := s.JobExistsOffLedger(jobID, technichianID)
 	if err != nil {
@@ -193,7 +205,7 @@ func (s *SmartContract) TakeJob(ctx contractapi.TransactionContextInterface, job
 	response := ctx.GetStub().InvokeChaincode(jobInfo.EventType, invokeArgs, ctx.GetStub().GetChannelID())
 	fmt.Println("response status: ", response.Status)
 	if response.Status != shim.OK {
-		fmt.Println("failed to invoke chaincode. Got error: %s", response.Payload)
+		fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Payload)
 		return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Payload)
 	}
 	var createdJob Job
@@ -239,7 +251,14 @@ func (s *SmartContract) JobDoneCorrectError(ctx contractapi.TransactionContextIn
 		return err
 	}
 
-	gc.MonthlyBalance = gc.MonthlyBalance + job.JobPay + job.InspectionPay
+	pay, err := job.JobPay.Add(job.InspectionPay)
+	if err != nil {
+		return err
+	}
+	gc.MonthlyBalance, err = gc.MonthlyBalance.Add(pay)
+	if err != nil {
+		return err
+	}
 	job.Status = "Done"
 	err = updateJobStatus(job, gc, "Done")
 
@@ -308,7 +327,10 @@ func (s *SmartContract) JobDoneWrongError(ctx contractapi.TransactionContextInte
 		return err
 	}
 
-	gc.MonthlyBalance = gc.MonthlyBalance + job.InspectionPay
+	gc.MonthlyBalance, err = gc.MonthlyBalance.Add(job.InspectionPay)
+	if err != nil {
+		return err
+	}
 	job.Status = "Done"
 	err = updateJobStatus(job, gc, "Done")
 
@@ -367,7 +389,7 @@ func (s *SmartContract) ReadJob(ctx contractapi.TransactionContextInterface, job
 		return nil, fmt.Errorf("failed to read from world state: %v", err)
 	}
 	if generalContractJSON == nil {
-		return nil, fmt.Errorf("the is no general contract for %s", technicianID)
+		return nil, fmt.Errorf("there is no general contract for %s", technicianID)
 	}
 
 	var gc GeneralContract
@@ -391,7 +413,7 @@ func (s *SmartContract) ReadGeneralContract(ctx contractapi.TransactionContextIn
 		return nil, fmt.Errorf("failed to read from world state: %v", err)
 	}
 	if generalContractJSON == nil {
-		return nil, fmt.Errorf("the is no general contract for %s", technicianID)
+		return nil, fmt.Errorf("there is no general contract for %s", technicianID)
 	}
 
 	var gc GeneralContract
@@ -0,0 +1,40 @@
+package razor
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+)
+
+// jobChaincodeStub stands in for the job contract, which creates the job of an offer a technician takes
+type jobChaincodeStub struct{}
+
+func (jobChaincodeStub) Init(shim.ChaincodeStubInterface) peer.Response {
+	return shim.Success(nil)
+}
+
+func (jobChaincodeStub) Invoke(stub shim.ChaincodeStubInterface) peer.Response {
+	return stub.InvokeChaincode("razor", stub.GetArgs(), "")
+}
+
+func TestCreateFromOfferOnlyForTheJobContract(t *testing.T) {
+	network := chaincodetest.NewNetwork("mychannel")
+	razorChaincode, err := contractapi.NewChaincode(&SmartContract{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	network.Deploy("razor", razorChaincode)
+	network.Deploy(jobChaincode, jobChaincodeStub{})
+	technician := chaincodetest.NewIdentity(t, "Org1MSP", "technician", nil)
+
+	args := []string{"technician", "offer-1", "mower-1", "Storgatan 1", "2026-11-01 12:00:00"}
+	if _, err := network.Submit(technician, "razor", "CreateFromOffer", args...); err == nil {
+		t.Error("a job was created from an offer without the job contract")
+	}
+	if _, err := network.Submit(technician, jobChaincode, "CreateFromOffer", args...); err != nil {
+		t.Errorf("the job contract could not create the job of an offer: %v", err)
+	}
+}
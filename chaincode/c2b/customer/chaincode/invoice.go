@@ -0,0 +1,248 @@
+package customer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	// invoiceObjectType prefixes the composite keys invoices are stored under, keyed by customer ID and period
+	invoiceObjectType = "invoice"
+	// invoicePeriodLayout is the layout of the month an invoice is for
+	invoicePeriodLayout = "2006-01"
+
+	invoiceOpen = "Open"
+)
+
+// InvoiceLine is the amount billed for a single SLA in the invoiced month
+type InvoiceLine struct {
+	SLAID        string        `json:"SLAID"`
+	PropertyID   string        `json:"PropertyID"`
+	ServiceType  string        `json:"ServiceType"`
+	ServiceLevel string        `json:"ServiceLevel"`
+	BilledDays   int           `json:"BilledDays"`
+	PausedDays   int           `json:"PausedDays"`
+	Seasons      []string      `json:"Seasons"`
+	Amount       decimal.Money `json:"Amount"`
+}
+
+// Invoice bills every SLA of a customer for a single month
+type Invoice struct {
+	ID         string        `json:"ID"`
+	CustomerID string        `json:"CustomerID"`
+	Period     string        `json:"Period"`
+	Lines      []InvoiceLine `json:"Lines"`
+	Total      decimal.Money `json:"Total"`
+	Status     string        `json:"Status"`
+	IssuedAt   time.Time     `json:"IssuedAt"`
+	Payment    *Payment      `json:"Payment,omitempty" metadata:",optional"`
+}
+
+// monthlyPrice is the price of an SLA for a month as found in the price schedule of the mower chaincode
+type monthlyPrice struct {
+	Month      int           `json:"Month"`
+	Price      decimal.Money `json:"Price"`
+	BilledDays int           `json:"BilledDays"`
+	PausedDays int           `json:"PausedDays"`
+	Seasons    []string      `json:"Seasons"`
+}
+
+// priceSchedule is the price of an SLA for every month of a year as returned by the mower chaincode
+type priceSchedule struct {
+	Year   int            `json:"Year"`
+	Months []monthlyPrice `json:"Months"`
+}
+
+// CreateInvoice bills the customer for the month given as YYYY-MM. Every SLA is billed with the price of that month
+// in its price schedule, so seasons and SLAs starting during the month are taken into account.
+// Only the service owner can create invoices and a month can only be invoiced once.
+func (s *SmartContract) CreateInvoice(ctx contractapi.TransactionContextInterface, customerID string, period string) (*Invoice, error) {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, err
+	}
+	if !serviceOwner {
+		return nil, fmt.Errorf("only the service owner can create invoices")
+	}
+
+	month, err := time.Parse(invoicePeriodLayout, period)
+	if err != nil {
+		return nil, fmt.Errorf("invalid period %s, expected YYYY-MM", period)
+	}
+
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := readInvoice(ctx, customerID, period)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("the customer %s has already been invoiced for %s", customerID, period)
+	}
+
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+
+	invoice := Invoice{
+		ID:         customerID + "-" + period,
+		CustomerID: customerID,
+		Period:     period,
+		Lines:      []InvoiceLine{},
+		Total:      decimal.ZeroMoney(decimal.DefaultCurrency),
+		Status:     invoiceOpen,
+		IssuedAt:   txTimestamp.AsTime(),
+	}
+
+	for _, sla := range customer.SLAs {
+		schedule, err := readPriceSchedule(ctx, sla.ID, month.Year())
+		if err != nil {
+			return nil, err
+		}
+
+		price := schedule.Months[month.Month()-1]
+		invoice.Lines = append(invoice.Lines, InvoiceLine{
+			SLAID:        sla.ID,
+			PropertyID:   sla.PropertyID,
+			ServiceType:  sla.ServiceType,
+			ServiceLevel: sla.ServiceLevel,
+			BilledDays:   price.BilledDays,
+			PausedDays:   price.PausedDays,
+			Seasons:      price.Seasons,
+			Amount:       price.Price,
+		})
+		invoice.Total, err = invoice.Total.Add(price.Price)
+		if err != nil {
+			return nil, fmt.Errorf("cannot invoice SLA %s: %v", sla.ID, err)
+		}
+	}
+
+	err = putInvoice(ctx, &invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+// ReadInvoice returns the invoice of the customer for the month given as YYYY-MM
+func (s *SmartContract) ReadInvoice(ctx contractapi.TransactionContextInterface, customerID string, period string) (*Invoice, error) {
+	// reading the customer checks that the caller may access it
+	_, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := readInvoice(ctx, customerID, period)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, fmt.Errorf("the customer %s has not been invoiced for %s", customerID, period)
+	}
+
+	return invoice, nil
+}
+
+// GetInvoices returns every invoice of the customer
+func (s *SmartContract) GetInvoices(ctx contractapi.TransactionContextInterface, customerID string) ([]*Invoice, error) {
+	_, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(invoiceObjectType, []string{customerID})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	invoices := []*Invoice{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var invoice Invoice
+		err = json.Unmarshal(queryResponse.Value, &invoice)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, &invoice)
+	}
+
+	return invoices, nil
+}
+
+// readPriceSchedule reads the price schedule of the SLA for the year from the mower chaincode
+func readPriceSchedule(ctx contractapi.TransactionContextInterface, slaID string, year int) (*priceSchedule, error) {
+	invokeArgs := [][]byte{[]byte("GetPriceSchedule"), []byte(slaID), []byte(strconv.Itoa(year))}
+	response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
+	if response.Status != shim.OK {
+		return nil, fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+	}
+
+	var schedule priceSchedule
+	err := json.Unmarshal(response.Payload, &schedule)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedule.Months) != 12 {
+		return nil, fmt.Errorf("the price schedule of SLA %s has %d months", slaID, len(schedule.Months))
+	}
+
+	return &schedule, nil
+}
+
+// readInvoice returns the invoice of the customer for the period, or nil if there is none
+func readInvoice(ctx contractapi.TransactionContextInterface, customerID string, period string) (*Invoice, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(invoiceObjectType, []string{customerID, period})
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from world state: %v", err)
+	}
+	if invoiceJSON == nil {
+		return nil, nil
+	}
+
+	var invoice Invoice
+	err = json.Unmarshal(invoiceJSON, &invoice)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func putInvoice(ctx contractapi.TransactionContextInterface, invoice *Invoice) error {
+	key, err := ctx.GetStub().CreateCompositeKey(invoiceObjectType, []string{invoice.CustomerID, invoice.Period})
+	if err != nil {
+		return err
+	}
+
+	invoiceJSON, err := json.Marshal(invoice)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetStub().PutState(key, invoiceJSON)
+	if err != nil {
+		return fmt.Errorf("failed to put to world state. %v", err)
+	}
+	return nil
+}
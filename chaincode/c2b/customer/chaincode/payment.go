@@ -0,0 +1,167 @@
+package customer
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	// tokenChaincodeName is the name the token-erc-20 chaincode is deployed under on the customer channel.
+	// The token is initialized with 2 decimals, so one token is one cent of the default currency.
+	tokenChaincodeName = "token_erc20"
+
+	invoicePaid = "Paid"
+)
+
+// Payment records how an invoice was paid with the ERC-20 token
+type Payment struct {
+	Amount        decimal.Money `json:"Amount"`
+	Tokens        int64         `json:"Tokens"`
+	From          string        `json:"From"`
+	To            string        `json:"To"`
+	TokenContract string        `json:"TokenContract"`
+	TxID          string        `json:"TxID"`
+	PaidAt        time.Time     `json:"PaidAt"`
+}
+
+// SetPaymentAccount makes the calling identity the token account the customer's invoices are paid from.
+// The customer has to approve the service owner as spender of at least the invoiced amount with the Approve
+// transaction of the token chaincode before a payment can be collected.
+func (s *SmartContract) SetPaymentAccount(ctx contractapi.TransactionContextInterface, customerID string) (string, error) {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return "", err
+	}
+	if serviceOwner {
+		return "", fmt.Errorf("the payment account of customer %s must be set by the customer", customerID)
+	}
+
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return "", err
+	}
+
+	// the same ID the token chaincode uses as the account of the caller
+	account, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return "", fmt.Errorf("failed to get client identity: %v", err)
+	}
+
+	customer.PaymentAccount = account
+	err = putCustomer(ctx, customer)
+	if err != nil {
+		return "", err
+	}
+
+	return account, nil
+}
+
+// CollectPayment pays the customer's invoice for the month given as YYYY-MM by transferring its total
+// from the customer's payment account to the calling service owner with TransferFrom of the token chaincode.
+// The payment and the transaction it was made in are recorded on the invoice.
+// Nothing is transferred unless the customer has approved the service owner for the whole amount.
+func (s *SmartContract) CollectPayment(ctx contractapi.TransactionContextInterface, customerID string, period string) (*Invoice, error) {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, err
+	}
+	if !serviceOwner {
+		return nil, fmt.Errorf("only the service owner can collect payments")
+	}
+
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := readInvoice(ctx, customerID, period)
+	if err != nil {
+		return nil, err
+	}
+	if invoice == nil {
+		return nil, fmt.Errorf("the customer %s has not been invoiced for %s", customerID, period)
+	}
+	if invoice.Status == invoicePaid {
+		return nil, fmt.Errorf("the invoice %s has already been paid", invoice.ID)
+	}
+	if invoice.Total.Currency != decimal.DefaultCurrency {
+		return nil, fmt.Errorf("the invoice %s is in %s, only %s can be paid with the token", invoice.ID, invoice.Total.Currency, decimal.DefaultCurrency)
+	}
+
+	from := customer.PaymentAccount
+	if from == "" {
+		from = customer.Owner
+	}
+	if from == "" {
+		return nil, fmt.Errorf("the customer %s has no payment account", customerID)
+	}
+
+	to, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client identity: %v", err)
+	}
+
+	tokens, err := invoice.Total.Amount.Units()
+	if err != nil {
+		return nil, fmt.Errorf("invalid total of invoice %s: %v", invoice.ID, err)
+	}
+	if tokens > 0 {
+		allowance, err := readAllowance(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		if allowance < tokens {
+			return nil, fmt.Errorf("insufficient allowance: the customer %s has approved %d tokens, the invoice %s needs %d", customerID, allowance, invoice.ID, tokens)
+		}
+
+		invokeArgs := [][]byte{[]byte("TransferFrom"), []byte(from), []byte(to), []byte(strconv.FormatInt(tokens, 10))}
+		response := ctx.GetStub().InvokeChaincode(tokenChaincodeName, invokeArgs, ctx.GetStub().GetChannelID())
+		if response.Status != shim.OK {
+			return nil, fmt.Errorf("failed to transfer %d tokens for invoice %s: %s", tokens, invoice.ID, response.Message)
+		}
+	}
+
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+
+	invoice.Status = invoicePaid
+	invoice.Payment = &Payment{
+		Amount:        invoice.Total,
+		Tokens:        tokens,
+		From:          from,
+		To:            to,
+		TokenContract: tokenChaincodeName,
+		TxID:          ctx.GetStub().GetTxID(),
+		PaidAt:        txTimestamp.AsTime(),
+	}
+
+	err = putInvoice(ctx, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// readAllowance returns the number of tokens the spender may still transfer from the owner's account
+func readAllowance(ctx contractapi.TransactionContextInterface, owner string, spender string) (int64, error) {
+	invokeArgs := [][]byte{[]byte("Allowance"), []byte(owner), []byte(spender)}
+	response := ctx.GetStub().InvokeChaincode(tokenChaincodeName, invokeArgs, ctx.GetStub().GetChannelID())
+	if response.Status != shim.OK {
+		return 0, fmt.Errorf("failed to read allowance from the token chaincode: %s", response.Message)
+	}
+
+	allowance, err := strconv.ParseInt(string(response.Payload), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid allowance %q returned by the token chaincode", response.Payload)
+	}
+	return allowance, nil
+}
@@ -0,0 +1,119 @@
+package customer
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+)
+
+// tokenChaincodeStub stands in for the token-erc-20 chaincode. The allowances are keyed by owner and spender and
+// TransferFrom moves tokens from the owner to the recipient as the spender, like the token chaincode.
+type tokenChaincodeStub struct {
+	allowances map[[2]string]int64
+	balances   map[string]int64
+}
+
+func (stub *tokenChaincodeStub) Init(shim.ChaincodeStubInterface) peer.Response {
+	return shim.Success(nil)
+}
+
+func (stub *tokenChaincodeStub) Invoke(chaincodeStub shim.ChaincodeStubInterface) peer.Response {
+	args := chaincodeStub.GetStringArgs()
+	switch args[0] {
+	case "Allowance":
+		return shim.Success([]byte(strconv.FormatInt(stub.allowances[[2]string{args[1], args[2]}], 10)))
+	case "TransferFrom":
+		spender, err := cid.GetID(chaincodeStub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		from, to := args[1], args[2]
+		value, err := strconv.ParseInt(args[3], 10, 64)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if stub.allowances[[2]string{from, spender}] < value {
+			return shim.Error("the spender does not have enough allowance for transfer")
+		}
+		if stub.balances[from] < value {
+			return shim.Error("client account has insufficient funds")
+		}
+		stub.allowances[[2]string{from, spender}] -= value
+		stub.balances[from] -= value
+		stub.balances[to] += value
+		return shim.Success(nil)
+	}
+	return shim.Error("unknown function " + args[0])
+}
+
+func TestCollectPayment(t *testing.T) {
+	network := chaincodetest.NewNetwork("customer")
+	customerChaincode, err := contractapi.NewChaincode(&SmartContract{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	network.Deploy("customer", customerChaincode)
+	network.Deploy("mower", &mowerChaincodeStub{slas: make(map[string]SLA), monthlyPrice: "123.45"})
+	token := &tokenChaincodeStub{allowances: make(map[[2]string]int64), balances: make(map[string]int64)}
+	network.Deploy(tokenChaincodeName, token)
+	alice := chaincodetest.NewIdentity(t, "Org1MSP", "alice", nil)
+	serviceOwner := chaincodetest.NewIdentity(t, "Org1MSP", "service-owner", map[string]string{access.RoleAttribute: access.ServiceOwnerRole})
+
+	if _, err := network.Submit(alice, "customer", "CreateCustomer", "brf-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := network.Submit(alice, "customer", "CreateServiceSLA", "brf-1", "sla-1", "", mowingServiceType, "gold", `{"TargetGrassLength":"5"}`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := network.Submit(serviceOwner, "customer", "CreateInvoice", "brf-1", "2026-05"); err != nil {
+		t.Fatal(err)
+	}
+	token.balances[alice.ID()] = 20000
+	allowance := [2]string{alice.ID(), serviceOwner.ID()}
+
+	if _, err := network.Submit(alice, "customer", "CollectPayment", "brf-1", "2026-05"); err == nil {
+		t.Error("alice collected the payment of her own invoice")
+	}
+	if _, err := network.Submit(serviceOwner, "customer", "CollectPayment", "brf-1", "2026-05"); err == nil {
+		t.Error("a payment was collected without an allowance")
+	}
+	token.allowances[allowance] = 12344
+	if _, err := network.Submit(serviceOwner, "customer", "CollectPayment", "brf-1", "2026-05"); err == nil {
+		t.Error("a payment was collected with an allowance a token short of the invoice")
+	}
+	if token.balances[alice.ID()] != 20000 || token.allowances[allowance] != 12344 {
+		t.Fatalf("tokens were transferred for an unpaid invoice, alice has %d left and allows %d", token.balances[alice.ID()], token.allowances[allowance])
+	}
+
+	token.allowances[allowance] = 12345
+	invoiceJSON, err := network.Submit(serviceOwner, "customer", "CollectPayment", "brf-1", "2026-05")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invoice Invoice
+	err = json.Unmarshal(invoiceJSON, &invoice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invoice.Status != invoicePaid || invoice.Payment == nil || invoice.Payment.Tokens != 12345 || invoice.Payment.From != alice.ID() || invoice.Payment.To != serviceOwner.ID() {
+		t.Errorf("the paid invoice is %+v with payment %+v", invoice, invoice.Payment)
+	}
+	if token.balances[alice.ID()] != 20000-12345 || token.balances[serviceOwner.ID()] != 12345 || token.allowances[allowance] != 0 {
+		t.Errorf("after the payment alice has %d tokens, the service owner %d and the allowance is %d", token.balances[alice.ID()], token.balances[serviceOwner.ID()], token.allowances[allowance])
+	}
+
+	token.allowances[allowance] = 12345
+	if _, err := network.Submit(serviceOwner, "customer", "CollectPayment", "brf-1", "2026-05"); err == nil {
+		t.Error("an invoice was paid twice")
+	}
+	if _, err := network.Submit(serviceOwner, "customer", "CollectPayment", "brf-1", "2026-06"); err == nil {
+		t.Error("a month that was not invoiced was paid")
+	}
+}
@@ -0,0 +1,274 @@
+package customer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// Property is a garden owned by a customer. A customer can own several properties, for example a housing
+// association, and every SLA of the customer can be attached to one of them.
+type Property struct {
+	ID      string          `json:"ID"`
+	Address string          `json:"Address"`
+	LotSize decimal.Decimal `json:"LotSize"`
+	Mower   string          `json:"Mower"`
+}
+
+// AddProperty adds a new property with given address, lot size in square meters as a decimal and assigned mower to the customer
+func (s *SmartContract) AddProperty(ctx contractapi.TransactionContextInterface, customerID string, id string, address string, lotSize string, mower string) (*Property, error) {
+	size, err := decimal.Parse(lotSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lot size: %v", err)
+	}
+
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if findProperty(customer, id) >= 0 {
+		return nil, fmt.Errorf("the property %s already exists for customer %s", id, customerID)
+	}
+
+	property := Property{
+		ID:      id,
+		Address: address,
+		LotSize: size,
+		Mower:   mower,
+	}
+	err = validateProperty(&property)
+	if err != nil {
+		return nil, err
+	}
+
+	customer.Properties = append(customer.Properties, property)
+	err = putCustomer(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property, nil
+}
+
+// UpdateProperty replaces the address, lot size and assigned mower of a property of the customer
+func (s *SmartContract) UpdateProperty(ctx contractapi.TransactionContextInterface, customerID string, id string, address string, lotSize string, mower string) (*Property, error) {
+	size, err := decimal.Parse(lotSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lot size: %v", err)
+	}
+
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	i := findProperty(customer, id)
+	if i < 0 {
+		return nil, fmt.Errorf("could not find property with ID %s", id)
+	}
+
+	property := Property{
+		ID:      id,
+		Address: address,
+		LotSize: size,
+		Mower:   mower,
+	}
+	err = validateProperty(&property)
+	if err != nil {
+		return nil, err
+	}
+
+	customer.Properties[i] = property
+	err = putCustomer(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property, nil
+}
+
+// RemoveProperty removes a property from the customer. A property that still has SLAs attached cannot be removed.
+func (s *SmartContract) RemoveProperty(ctx contractapi.TransactionContextInterface, customerID string, id string) error {
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	i := findProperty(customer, id)
+	if i < 0 {
+		return fmt.Errorf("could not find property with ID %s", id)
+	}
+
+	for _, sla := range customer.SLAs {
+		if sla.PropertyID == id {
+			return fmt.Errorf("the property %s still has SLA %s attached", id, sla.ID)
+		}
+	}
+
+	customer.Properties = append(customer.Properties[:i], customer.Properties[i+1:]...)
+	return putCustomer(ctx, customer)
+}
+
+// ReadProperty returns a property of the customer
+func (s *SmartContract) ReadProperty(ctx contractapi.TransactionContextInterface, customerID string, id string) (*Property, error) {
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	i := findProperty(customer, id)
+	if i < 0 {
+		return nil, fmt.Errorf("could not find property with ID %s", id)
+	}
+
+	return &customer.Properties[i], nil
+}
+
+// ReadMowerProperty returns a property of the customer to the mower assigned to it or the service owner.
+// The mower chaincode reads the property an SLA is attached to with it when a mower reports on the SLA.
+func (s *SmartContract) ReadMowerProperty(ctx contractapi.TransactionContextInterface, customerID string, id string) (*Property, error) {
+	customerJSON, err := ctx.GetStub().GetState(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from world state: %v", err)
+	}
+	if customerJSON == nil {
+		return nil, fmt.Errorf("the asset %s does not exist", customerID)
+	}
+
+	var customer Customer
+	err = json.Unmarshal(customerJSON, &customer)
+	if err != nil {
+		return nil, err
+	}
+
+	i := findProperty(&customer, id)
+	if i < 0 {
+		return nil, fmt.Errorf("could not find property with ID %s", id)
+	}
+	property := customer.Properties[i]
+
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, err
+	}
+	if serviceOwner {
+		return &property, nil
+	}
+
+	mowerID, found, err := access.CallerMowerID(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, err
+	}
+	if !found || property.Mower == "" || mowerID != property.Mower {
+		return nil, fmt.Errorf("the caller is not the mower of property %s", id)
+	}
+
+	return &property, nil
+}
+
+// GetProperties returns every property of the customer
+func (s *SmartContract) GetProperties(ctx contractapi.TransactionContextInterface, customerID string) ([]Property, error) {
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return customer.Properties, nil
+}
+
+// GetPropertySLAs returns the SLAs of the customer attached to the property
+func (s *SmartContract) GetPropertySLAs(ctx contractapi.TransactionContextInterface, customerID string, propertyID string) ([]SLA, error) {
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if findProperty(customer, propertyID) < 0 {
+		return nil, fmt.Errorf("could not find property with ID %s", propertyID)
+	}
+
+	slas := []SLA{}
+	for _, sla := range customer.SLAs {
+		if sla.PropertyID == propertyID {
+			slas = append(slas, sla)
+		}
+	}
+
+	return slas, nil
+}
+
+// AssignSLAToProperty attaches an SLA of the customer to one of the customer's properties,
+// for example an SLA created before the customer had properties.
+func (s *SmartContract) AssignSLAToProperty(ctx contractapi.TransactionContextInterface, customerID string, slaID string, propertyID string) error {
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	if findProperty(customer, propertyID) < 0 {
+		return fmt.Errorf("could not find property with ID %s", propertyID)
+	}
+
+	invokeArgs := [][]byte{[]byte("AssignProperty"), []byte(slaID), []byte(propertyID)}
+	for i, sla := range customer.SLAs {
+		if sla.ID == slaID {
+			response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
+			fmt.Println("response status: ", response.Status)
+			if response.Status != shim.OK {
+				fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+			}
+
+			var newSLA SLA
+			err = json.Unmarshal(response.Payload, &newSLA)
+			if err != nil {
+				return err
+			}
+			customer.SLAs[i] = newSLA
+			return putCustomer(ctx, customer)
+		}
+	}
+	return fmt.Errorf("could not find sla with ID %s", slaID)
+}
+
+// findProperty returns the index of the property with given id in the customer's properties, or -1 if there is none
+func findProperty(customer *Customer, id string) int {
+	for i, property := range customer.Properties {
+		if property.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func validateProperty(property *Property) error {
+	if property.ID == "" {
+		return fmt.Errorf("the property ID cannot be empty")
+	}
+	if property.Address == "" {
+		return fmt.Errorf("the property %s needs an address", property.ID)
+	}
+	if property.LotSize.Sign() <= 0 {
+		return fmt.Errorf("the lot size of property %s must be greater than 0", property.ID)
+	}
+	return nil
+}
+
+// putCustomer writes the customer to the world state
+func putCustomer(ctx contractapi.TransactionContextInterface, customer *Customer) error {
+	customerJSON, err := json.Marshal(customer)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetStub().PutState(customer.ID, customerJSON)
+	if err != nil {
+		return fmt.Errorf("failed to put to world state. %v", err)
+	}
+	return nil
+}
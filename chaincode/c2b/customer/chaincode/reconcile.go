@@ -0,0 +1,121 @@
+package customer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ReconcileReport lists the SLAs whose copy in the customer was repaired by ReconcileCustomer, and the SLAs stored
+// for the customer in the mower chaincode under another owner, which were left out
+type ReconcileReport struct {
+	CustomerID string   `json:"CustomerID"`
+	Added      []string `json:"Added"`
+	Updated    []string `json:"Updated"`
+	Removed    []string `json:"Removed"`
+	Foreign    []string `json:"Foreign"`
+}
+
+// ReconcileCustomer compares the customer's copies of its SLAs with the SLAs stored in the mower chaincode and repairs
+// the copies where they have drifted apart. The mower chaincode is the source of truth: copies that differ are replaced,
+// copies of SLAs that no longer exist are removed and SLAs of the customer that have no copy are added.
+// SLAs stored under another owner than the owner of the customer are reported as foreign and never adopted,
+// since only SLAs created for the customer by its owner belong to it.
+func (s *SmartContract) ReconcileCustomer(ctx contractapi.TransactionContextInterface, customerID string) (*ReconcileReport, error) {
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	invokeArgs := [][]byte{[]byte("GetSLAsByCustomer"), []byte(customerID)}
+	response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
+	if response.Status != shim.OK {
+		return nil, fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+	}
+
+	var stored []SLA
+	err = json.Unmarshal(response.Payload, &stored)
+	if err != nil {
+		return nil, err
+	}
+
+	storedByID := make(map[string]SLA)
+	for _, sla := range stored {
+		storedByID[sla.ID] = sla
+	}
+
+	report := ReconcileReport{
+		CustomerID: customerID,
+		Added:      []string{},
+		Updated:    []string{},
+		Removed:    []string{},
+		Foreign:    []string{},
+	}
+
+	for _, sla := range stored {
+		if sla.Owner != customer.Owner {
+			report.Foreign = append(report.Foreign, sla.ID)
+		}
+	}
+
+	reconciled := []SLA{}
+	copied := make(map[string]bool)
+	for _, sla := range customer.SLAs {
+		storedSLA, ok := storedByID[sla.ID]
+		if !ok {
+			report.Removed = append(report.Removed, sla.ID)
+			continue
+		}
+		copied[sla.ID] = true
+		if storedSLA.Owner != customer.Owner {
+			// a foreign SLA does not replace the copy, which is left as it is
+			reconciled = append(reconciled, sla)
+			continue
+		}
+
+		equal, err := sameSLA(sla, storedSLA)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			report.Updated = append(report.Updated, sla.ID)
+		}
+		reconciled = append(reconciled, storedSLA)
+	}
+
+	// the mower chaincode returns the SLAs ordered by ID, so SLAs are added in a deterministic order
+	for _, sla := range stored {
+		if !copied[sla.ID] && sla.Owner == customer.Owner {
+			report.Added = append(report.Added, sla.ID)
+			reconciled = append(reconciled, sla)
+		}
+	}
+
+	if len(report.Added)+len(report.Updated)+len(report.Removed) == 0 {
+		return &report, nil
+	}
+
+	fmt.Println("Reconciled customer: ", report)
+	customer.SLAs = reconciled
+	err = putCustomer(ctx, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// sameSLA returns true when both SLAs are serialized the same way
+func sameSLA(a SLA, b SLA) (bool, error) {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return string(aJSON) == string(bJSON), nil
+}
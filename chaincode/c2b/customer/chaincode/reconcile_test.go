@@ -0,0 +1,144 @@
+package customer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// mowerChaincodeStub stands in for the mower chaincode. It stores the SLAs the customer chaincode creates, which the
+// tests change to make the copies in the customers drift apart from them, and prices every SLA the same. It has no
+// diamond service level.
+type mowerChaincodeStub struct {
+	slas         map[string]SLA
+	monthlyPrice decimal.Decimal
+}
+
+func (stub *mowerChaincodeStub) Init(shim.ChaincodeStubInterface) peer.Response {
+	return shim.Success(nil)
+}
+
+func (stub *mowerChaincodeStub) Invoke(chaincodeStub shim.ChaincodeStubInterface) peer.Response {
+	args := chaincodeStub.GetStringArgs()
+	switch args[0] {
+	case "CreateServiceSLA":
+		if args[6] == "diamond" {
+			return shim.Error("invalid service level: diamond")
+		}
+		var parameters Parameters
+		err := json.Unmarshal([]byte(args[7]), &parameters)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		sla := SLA{ID: args[1], CustomerID: args[2], Owner: args[3], PropertyID: args[4], ServiceType: args[5], ServiceLevel: args[6], Parameters: parameters}
+		stub.slas[sla.ID] = sla
+		return stub.success(sla)
+	case "GetSLAsByCustomer":
+		slas := []SLA{}
+		for _, sla := range stub.slas {
+			if sla.CustomerID == args[1] {
+				slas = append(slas, sla)
+			}
+		}
+		sort.Slice(slas, func(i, j int) bool { return slas[i].ID < slas[j].ID })
+		return stub.success(slas)
+	case "GetPriceSchedule":
+		// every SLA costs the same in every month
+		year, err := strconv.Atoi(args[2])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		schedule := priceSchedule{Year: year}
+		for month := 1; month <= 12; month++ {
+			schedule.Months = append(schedule.Months, monthlyPrice{Month: month, Price: decimal.NewMoney(stub.monthlyPrice, decimal.DefaultCurrency), Seasons: []string{}})
+		}
+		return stub.success(schedule)
+	}
+	return shim.Error("unknown function " + args[0])
+}
+
+func (stub *mowerChaincodeStub) success(result interface{}) peer.Response {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(resultJSON)
+}
+
+func TestReconcileCustomer(t *testing.T) {
+	network := chaincodetest.NewNetwork("customer")
+	customerChaincode, err := contractapi.NewChaincode(&SmartContract{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	network.Deploy("customer", customerChaincode)
+	mower := &mowerChaincodeStub{slas: make(map[string]SLA)}
+	network.Deploy("mower", mower)
+	alice := chaincodetest.NewIdentity(t, "Org1MSP", "alice", nil)
+	mallory := chaincodetest.NewIdentity(t, "Org1MSP", "mallory", nil)
+
+	if _, err := network.Submit(alice, "customer", "CreateCustomer", "brf-1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"sla-1", "sla-2", "sla-3"} {
+		_, err := network.Submit(alice, "customer", "CreateServiceSLA", "brf-1", id, "", mowingServiceType, "gold", `{"TargetGrassLength":"5"}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changed := mower.slas["sla-1"]
+	changed.ServiceLevel = "platinum"
+	mower.slas["sla-1"] = changed
+	delete(mower.slas, "sla-2")
+	forged := mower.slas["sla-3"]
+	forged.Owner = mallory.ID()
+	mower.slas["sla-3"] = forged
+	mower.slas["sla-4"] = SLA{ID: "sla-4", CustomerID: "brf-1", Owner: alice.ID(), ServiceType: mowingServiceType, ServiceLevel: "standard", Parameters: Parameters{}}
+	mower.slas["sla-5"] = SLA{ID: "sla-5", CustomerID: "brf-1", Owner: mallory.ID(), ServiceType: mowingServiceType, ServiceLevel: "standard", Parameters: Parameters{}}
+
+	if _, err := network.Submit(mallory, "customer", "ReconcileCustomer", "brf-1"); err == nil {
+		t.Error("mallory reconciled alice's customer")
+	}
+
+	reportJSON, err := network.Submit(alice, "customer", "ReconcileCustomer", "brf-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report ReconcileReport
+	err = json.Unmarshal(reportJSON, &report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(report.Added, report.Updated, report.Removed, report.Foreign) != "[sla-4] [sla-1] [sla-2] [sla-3 sla-5]" {
+		t.Errorf("the report is %+v", report)
+	}
+
+	customerJSON, err := network.Submit(alice, "customer", "ReadCustomer", "brf-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var customer Customer
+	err = json.Unmarshal(customerJSON, &customer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	levels := map[string]string{}
+	for _, sla := range customer.SLAs {
+		if sla.Owner != alice.ID() {
+			t.Errorf("the customer adopted the SLA %s of another owner", sla.ID)
+		}
+		levels[sla.ID] = sla.ServiceLevel
+	}
+	if fmt.Sprint(levels) != "map[sla-1:platinum sla-3:gold sla-4:standard]" {
+		t.Errorf("the SLAs of the customer after reconciling are %v", levels)
+	}
+}
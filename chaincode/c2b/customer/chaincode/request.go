@@ -0,0 +1,41 @@
+package customer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/idempotency"
+)
+
+// CreateSLAIdempotent is CreateSLA for a request with an idempotency key. A retry with the same key returns the
+// SLA and transaction ID of the first request in the record instead of creating another SLA.
+func (s *SmartContract) CreateSLAIdempotent(ctx contractapi.TransactionContextInterface, requestKey string, customerID string, id string, serviceLevel string, targetgrasslength string, maxgrasslength string, mingrasslength string) (*idempotency.Record, error) {
+	args := []string{customerID, id, serviceLevel, targetgrasslength, maxgrasslength, mingrasslength}
+	return runIdempotent(ctx, requestKey, "CreateSLA", args, func() (interface{}, error) {
+		return s.CreateSLA(ctx, customerID, id, serviceLevel, targetgrasslength, maxgrasslength, mingrasslength)
+	})
+}
+
+// CreateServiceSLAIdempotent is CreateServiceSLA for a request with an idempotency key. A retry with the same key
+// returns the SLA and transaction ID of the first request in the record instead of creating another SLA.
+func (s *SmartContract) CreateServiceSLAIdempotent(ctx contractapi.TransactionContextInterface, requestKey string, customerID string, id string, propertyID string, serviceType string, serviceLevel string, parameters Parameters) (*idempotency.Record, error) {
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{customerID, id, propertyID, serviceType, serviceLevel, string(parametersJSON)}
+	return runIdempotent(ctx, requestKey, "CreateServiceSLA", args, func() (interface{}, error) {
+		return s.CreateServiceSLA(ctx, customerID, id, propertyID, serviceType, serviceLevel, parameters)
+	})
+}
+
+// runIdempotent runs the transaction once per idempotency key of the calling identity
+func runIdempotent(ctx contractapi.TransactionContextInterface, requestKey string, function string, args []string, transaction func() (interface{}, error)) (*idempotency.Record, error) {
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client identity: %v", err)
+	}
+	return idempotency.Run(ctx.GetStub(), clientID, requestKey, function, args, transaction)
+}
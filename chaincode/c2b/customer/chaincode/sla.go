@@ -0,0 +1,84 @@
+package customer
+
+import (
+	"fmt"
+
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	mowingServiceType = "mowing"
+
+	targetGrassLengthParameter = "TargetGrassLength"
+	maxGrassLengthParameter    = "MaxGrassLength"
+	minGrassLengthParameter    = "MinGrassLength"
+)
+
+// Parameters holds the service specific parameters of an SLA keyed by parameter name.
+// The mower chaincode validates them against the schema of the SLA's service type.
+type Parameters map[string]decimal.Decimal
+
+// Season is a window of the year in which an SLA is delivered with other parameters, or paused.
+// Start and End are inclusive and given as MM-DD. The mower chaincode validates the seasons of an SLA.
+type Season struct {
+	Name       string     `json:"Name"`
+	Start      string     `json:"Start"`
+	End        string     `json:"End"`
+	Paused     bool       `json:"Paused"`
+	Parameters Parameters `json:"Parameters,omitempty" metadata:",optional"`
+}
+
+// mowingParameters builds the parameter set of a mowing SLA from its grass lengths
+func mowingParameters(targetGrassLength decimal.Decimal, maxGrassLength decimal.Decimal, minGrassLength decimal.Decimal) Parameters {
+	return Parameters{
+		targetGrassLengthParameter: targetGrassLength,
+		maxGrassLengthParameter:    maxGrassLength,
+		minGrassLengthParameter:    minGrassLength,
+	}
+}
+
+// parseMowingParameters builds the parameter set of a mowing SLA from grass lengths given as decimal strings
+func parseMowingParameters(targetGrassLength string, maxGrassLength string, minGrassLength string) (Parameters, error) {
+	target, err := decimal.Parse(targetGrassLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target grass length: %v", err)
+	}
+	maxLength, err := decimal.Parse(maxGrassLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max grass length: %v", err)
+	}
+	minLength, err := decimal.Parse(minGrassLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid min grass length: %v", err)
+	}
+	return mowingParameters(target, maxLength, minLength), nil
+}
+
+// upgradeLegacySLA moves the grass lengths of an SLA copied before service types existed into its parameter set.
+// Such SLAs are always mowing SLAs.
+func upgradeLegacySLA(sla *SLA) {
+	if sla.ServiceType != "" {
+		return
+	}
+
+	sla.ServiceType = mowingServiceType
+	sla.Parameters = mowingParameters(sla.TargetGrassLength, sla.MaxGrassLength, sla.MinGrassLength)
+	sla.TargetGrassLength = ""
+	sla.MaxGrassLength = ""
+	sla.MinGrassLength = ""
+}
+
+// upgradeLegacyCustomer brings a customer stored by an earlier version of the chaincode up to date.
+// Customers stored before properties existed have none, and their SLAs are not attached to a property.
+func upgradeLegacyCustomer(customer *Customer) {
+	if customer.Properties == nil {
+		customer.Properties = []Property{}
+	}
+	if customer.SLAs == nil {
+		customer.SLAs = []SLA{}
+	}
+
+	for i := range customer.SLAs {
+		upgradeLegacySLA(&customer.SLAs[i])
+	}
+}
@@ -3,9 +3,12 @@ package customer
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
 )
 
 // SmartContract provides functions for managing an Asset
@@ -17,21 +20,60 @@ type SmartContract struct {
 // Insert struct field in alphabetic order => to achieve determinism across languages
 // golang keeps the order when marshal to json but doesn't order automatically
 type Customer struct {
-	ID   string `json:"ID"`
-	SLAs []SLA  `json:"SLAs"`
+	ID             string     `json:"ID"`
+	Owner          string     `json:"Owner"`
+	PaymentAccount string     `json:"PaymentAccount"`
+	Properties     []Property `json:"Properties"`
+	SLAs           []SLA      `json:"SLAs"`
 }
 
+// SLA is the customer's copy of an SLA stored in the mower chaincode
 type SLA struct {
-	AppraisedValue    int     `json:"AppraisedValue,omitempty"`
-	ServiceLevel      string  `json:"ServiceLevel"`
-	TargetGrassLength float32 `json:"TargetGrassLength"`
-	MaxGrassLength    float32 `json:"MaxGrassLength"`
-	MinGrassLength    float32 `json:"MinGrassLength"`
-	ID                string  `json:"ID"`
+	AppraisedValue decimal.Money `json:"AppraisedValue"`
+	ServiceType    string        `json:"ServiceType"`
+	ServiceLevel   string        `json:"ServiceLevel"`
+	Parameters     Parameters    `json:"Parameters"`
+	ID             string        `json:"ID"`
+	CustomerID     string        `json:"CustomerID"`
+	Owner          string        `json:"Owner"`
+	PropertyID     string        `json:"PropertyID"`
+	Seasons        []Season      `json:"Seasons,omitempty" metadata:",optional"`
+	StartDate      time.Time     `json:"StartDate"`
+
+	// Grass lengths of mowing SLAs copied before service types existed, encoded as floats.
+	// They are moved into Parameters when the customer is read.
+	TargetGrassLength decimal.Decimal `json:"TargetGrassLength,omitempty" metadata:",optional"`
+	MaxGrassLength    decimal.Decimal `json:"MaxGrassLength,omitempty" metadata:",optional"`
+	MinGrassLength    decimal.Decimal `json:"MinGrassLength,omitempty" metadata:",optional"`
 }
 
-// CreateAsset issues a new asset to the world state with given details.
+// CreateCustomer issues a new customer bound to the calling identity.
+// Callers enrolled with a customerId attribute can only create the customer named by that attribute,
+// all other callers become the owner of the customer through their X.509 ID.
 func (s *SmartContract) CreateCustomer(ctx contractapi.TransactionContextInterface, id string) error {
+	boundID, bound, err := access.CallerCustomerID(ctx.GetClientIdentity())
+	if err != nil {
+		return err
+	}
+	if bound && id != boundID {
+		return fmt.Errorf("the caller is bound to customer %s and cannot create customer %s", boundID, id)
+	}
+
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return err
+	}
+
+	// The service owner creates customers on behalf of identities bound by the customerId attribute,
+	// so it never becomes the owner itself.
+	var owner string
+	if !serviceOwner {
+		owner, err = ctx.GetClientIdentity().GetID()
+		if err != nil {
+			return fmt.Errorf("failed to get client identity: %v", err)
+		}
+	}
+
 	exists, err := s.CustomerExist(ctx, id)
 	if err != nil {
 		return err
@@ -41,8 +83,10 @@ func (s *SmartContract) CreateCustomer(ctx contractapi.TransactionContextInterfa
 	}
 
 	newCustomer := Customer{
-		ID:   id,
-		SLAs: []SLA{},
+		ID:         id,
+		Owner:      owner,
+		Properties: []Property{},
+		SLAs:       []SLA{},
 	}
 
 	customerJSON, err := json.Marshal(newCustomer)
@@ -53,8 +97,22 @@ func (s *SmartContract) CreateCustomer(ctx contractapi.TransactionContextInterfa
 	return ctx.GetStub().PutState(id, customerJSON)
 }
 
-func (s *SmartContract) CreateSLA(ctx contractapi.TransactionContextInterface, customerID, id string, serviceLevel string, targetgrasslength float32, maxgrasslength float32, mingrasslength float32) (*SLA, error) {
+// CreateSLA creates a mowing SLA with the given grass lengths in cm as decimals, e.g. "5.5", for the customer.
+func (s *SmartContract) CreateSLA(ctx contractapi.TransactionContextInterface, customerID, id string, serviceLevel string, targetgrasslength string, maxgrasslength string, mingrasslength string) (*SLA, error) {
 	fmt.Println("In CreateSLA in customer contract")
+
+	parameters, err := parseMowingParameters(targetgrasslength, maxgrasslength, mingrasslength)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateServiceSLA(ctx, customerID, id, "", mowingServiceType, serviceLevel, parameters)
+}
+
+// CreateServiceSLA creates an SLA of any service type for the customer in the mower chaincode and adds it to the customer.
+// The SLA is attached to the property with given ID, which must belong to the customer, unless the property ID is empty.
+func (s *SmartContract) CreateServiceSLA(ctx contractapi.TransactionContextInterface, customerID string, id string, propertyID string, serviceType string, serviceLevel string, parameters Parameters) (*SLA, error) {
+	fmt.Println("In CreateServiceSLA in customer contract")
 	exists, err := s.CustomerExist(ctx, customerID)
 	if err != nil {
 		fmt.Println("error when running CustomerExist")
@@ -70,21 +128,25 @@ func (s *SmartContract) CreateSLA(ctx contractapi.TransactionContextInterface, c
 		return nil, readSLAerror
 	}
 
+	if propertyID != "" && findProperty(customer, propertyID) < 0 {
+		return nil, fmt.Errorf("could not find property with ID %s", propertyID)
+	}
+
+	fmt.Println("servicetype: ", serviceType)
 	fmt.Println("servicelevel: ", serviceLevel)
-	targetgrasslength_string := fmt.Sprintf("%f", targetgrasslength)
-	fmt.Println("targetgrasslength: ", targetgrasslength_string)
-	maxgrasslength_string := fmt.Sprintf("%f", maxgrasslength)
-	fmt.Println("Maxgrasslength: ", maxgrasslength_string)
-	mingrasslength_string := fmt.Sprintf("%f", mingrasslength)
-	fmt.Println("Mingrasslength: ", mingrasslength_string)
-
-	invokeArgs := [][]byte{[]byte("CreateSLA"), []byte(id), []byte(serviceLevel), []byte(targetgrasslength_string), []byte(maxgrasslength_string), []byte(mingrasslength_string)}
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("parameters: ", string(parametersJSON))
+
+	invokeArgs := [][]byte{[]byte("CreateServiceSLA"), []byte(id), []byte(customer.ID), []byte(customer.Owner), []byte(propertyID), []byte(serviceType), []byte(serviceLevel), parametersJSON}
 	fmt.Println("Invoke args: ", invokeArgs)
 	response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
 	fmt.Println("response status: ", response.Status)
 	if response.Status != shim.OK {
-		fmt.Println("failed to invoke chaincode. Got error: %s", response.Payload)
-		return nil, fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Payload)
+		fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+		return nil, fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
 	}
 	var createdSLA SLA
 	err = json.Unmarshal(response.Payload, &createdSLA)
@@ -102,6 +164,7 @@ func (s *SmartContract) CreateSLA(ctx contractapi.TransactionContextInterface, c
 
 }
 
+// ReadCustomer returns the customer stored in the world state with given id, provided the caller may access it.
 func (s *SmartContract) ReadCustomer(ctx contractapi.TransactionContextInterface, id string) (*Customer, error) {
 	customerJSON, err := ctx.GetStub().GetState(id)
 	if err != nil {
@@ -117,11 +180,23 @@ func (s *SmartContract) ReadCustomer(ctx contractapi.TransactionContextInterface
 		return nil, err
 	}
 
+	err = access.AuthorizeCustomer(ctx.GetClientIdentity(), customer.ID, customer.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradeLegacyCustomer(&customer)
+
 	return &customer, nil
 }
 
-// UpdateAsset updates an existing asset in the world state with provided parameters.
-func (s *SmartContract) UpdateTargetGrassLength(ctx contractapi.TransactionContextInterface, customerID string, slaID string, targetgrasslength float32) error {
+// UpdateTargetGrassLength updates the target grass length in cm, given as a decimal, of one of the customer's mowing SLAs.
+func (s *SmartContract) UpdateTargetGrassLength(ctx contractapi.TransactionContextInterface, customerID string, slaID string, targetgrasslength string) error {
+	target, err := decimal.Parse(targetgrasslength)
+	if err != nil {
+		return fmt.Errorf("invalid target grass length: %v", err)
+	}
+
 	exists, err := s.CustomerExist(ctx, customerID)
 	if err != nil {
 		return err
@@ -132,20 +207,18 @@ func (s *SmartContract) UpdateTargetGrassLength(ctx contractapi.TransactionConte
 
 	// overwriting original asset with new asset
 	customer, readSLAerror := s.ReadCustomer(ctx, customerID)
-	if err != nil {
+	if readSLAerror != nil {
 		return readSLAerror
 	}
 
-	targetgrasslength_string := fmt.Sprintf("%f", targetgrasslength)
-
-	invokeArgs := [][]byte{[]byte("UpdateTargetGrassLength"), []byte(slaID), []byte(targetgrasslength_string)}
+	invokeArgs := [][]byte{[]byte("UpdateTargetGrassLength"), []byte(slaID), []byte(target.String())}
 	for i, sla := range customer.SLAs {
 		if sla.ID == slaID {
 			response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
 			fmt.Println("response status: ", response.Status)
 			if response.Status != shim.OK {
-				fmt.Println("failed to invoke chaincode. Got error: %s", response.Payload)
-				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Payload)
+				fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
 			}
 			var newSLA SLA
 			err = json.Unmarshal(response.Payload, &newSLA)
@@ -174,12 +247,12 @@ func (s *SmartContract) UpdateServiceLevel(ctx contractapi.TransactionContextInt
 		return err
 	}
 	if !exists {
-		fmt.Println("the customer %s does not exist", customerID)
+		fmt.Printf("the customer %s does not exist\n", customerID)
 		return fmt.Errorf("the customer %s does not exist", customerID)
 	}
 
 	customer, readSLAerror := s.ReadCustomer(ctx, customerID)
-	if err != nil {
+	if readSLAerror != nil {
 		return readSLAerror
 	}
 
@@ -189,8 +262,8 @@ func (s *SmartContract) UpdateServiceLevel(ctx contractapi.TransactionContextInt
 			response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
 			fmt.Println("response status: ", response.Status)
 			if response.Status != shim.OK {
-				fmt.Println("failed to invoke chaincode. Got error: %s", response.Payload)
-				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Payload)
+				fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
 			}
 
 			var newSLA SLA
@@ -213,7 +286,16 @@ func (s *SmartContract) UpdateServiceLevel(ctx contractapi.TransactionContextInt
 	return fmt.Errorf("could not update grasslength interval")
 }
 
-func (s *SmartContract) UpdateGrassLengthInterval(ctx contractapi.TransactionContextInterface, customerID string, slaID string, maxgrasslength float32, mingrasslength float32) error {
+func (s *SmartContract) UpdateGrassLengthInterval(ctx contractapi.TransactionContextInterface, customerID string, slaID string, maxgrasslength string, mingrasslength string) error {
+	maxLength, err := decimal.Parse(maxgrasslength)
+	if err != nil {
+		return fmt.Errorf("invalid max grass length: %v", err)
+	}
+	minLength, err := decimal.Parse(mingrasslength)
+	if err != nil {
+		return fmt.Errorf("invalid min grass length: %v", err)
+	}
+
 	exists, err := s.CustomerExist(ctx, customerID)
 	if err != nil {
 		return err
@@ -223,21 +305,18 @@ func (s *SmartContract) UpdateGrassLengthInterval(ctx contractapi.TransactionCon
 	}
 
 	customer, readSLAerror := s.ReadCustomer(ctx, customerID)
-	if err != nil {
+	if readSLAerror != nil {
 		return readSLAerror
 	}
 
-	maxgrasslength_string := fmt.Sprintf("%f", maxgrasslength)
-	mingrasslength_string := fmt.Sprintf("%f", mingrasslength)
-
-	invokeArgs := [][]byte{[]byte("UpdateGrassLengthInterval"), []byte(slaID), []byte(maxgrasslength_string), []byte(mingrasslength_string)}
+	invokeArgs := [][]byte{[]byte("UpdateGrassLengthInterval"), []byte(slaID), []byte(maxLength.String()), []byte(minLength.String())}
 	for i, sla := range customer.SLAs {
 		if sla.ID == slaID {
 			response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
 			fmt.Println("response status: ", response.Status)
 			if response.Status != shim.OK {
-				fmt.Println("failed to invoke chaincode. Got error: %s", response.Payload)
-				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Payload)
+				fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
 			}
 
 			var newSLA SLA
@@ -260,6 +339,124 @@ func (s *SmartContract) UpdateGrassLengthInterval(ctx contractapi.TransactionCon
 	return fmt.Errorf("could not update grasslength interval")
 }
 
+// UpdateSLA changes the service level and parameters of one of the customer's SLAs in a single transaction,
+// so the SLA is only evaluated once and the customer's copy is updated together with the mower chaincode.
+// An empty service level keeps the current one and only the given parameters are replaced.
+func (s *SmartContract) UpdateSLA(ctx contractapi.TransactionContextInterface, customerID string, slaID string, serviceLevel string, parameters Parameters) (*SLA, error) {
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	invokeArgs := [][]byte{[]byte("UpdateSLA"), []byte(slaID), []byte(serviceLevel), parametersJSON}
+	for i, sla := range customer.SLAs {
+		if sla.ID == slaID {
+			response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
+			fmt.Println("response status: ", response.Status)
+			if response.Status != shim.OK {
+				fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+				return nil, fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+			}
+
+			var newSLA SLA
+			err = json.Unmarshal(response.Payload, &newSLA)
+			if err != nil {
+				return nil, err
+			}
+			customer.SLAs[i] = newSLA
+			err = putCustomer(ctx, customer)
+			if err != nil {
+				return nil, err
+			}
+			return &newSLA, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find sla with ID %s", slaID)
+}
+
+// UpdateSLAParameters replaces the parameter set of one of the customer's SLAs, whatever its service type.
+func (s *SmartContract) UpdateSLAParameters(ctx contractapi.TransactionContextInterface, customerID string, slaID string, parameters Parameters) error {
+	exists, err := s.CustomerExist(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("the customer %s does not exist", customerID)
+	}
+
+	customer, readSLAerror := s.ReadCustomer(ctx, customerID)
+	if readSLAerror != nil {
+		return readSLAerror
+	}
+
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return err
+	}
+
+	invokeArgs := [][]byte{[]byte("UpdateParameters"), []byte(slaID), parametersJSON}
+	for i, sla := range customer.SLAs {
+		if sla.ID == slaID {
+			response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
+			fmt.Println("response status: ", response.Status)
+			if response.Status != shim.OK {
+				fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+			}
+
+			var newSLA SLA
+			err = json.Unmarshal(response.Payload, &newSLA)
+			if err != nil {
+				return err
+			}
+			customer.SLAs[i] = newSLA
+			jsonCustomer, err := json.Marshal(customer)
+			if err != nil {
+				return err
+			}
+			return ctx.GetStub().PutState(customerID, jsonCustomer)
+		}
+	}
+	return fmt.Errorf("could not find sla with ID %s", slaID)
+}
+
+// SetSLASeasons replaces the seasons of one of the customer's SLAs, for example to pause it during the winter.
+func (s *SmartContract) SetSLASeasons(ctx contractapi.TransactionContextInterface, customerID string, slaID string, seasons []Season) error {
+	customer, err := s.ReadCustomer(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	seasonsJSON, err := json.Marshal(seasons)
+	if err != nil {
+		return err
+	}
+
+	invokeArgs := [][]byte{[]byte("SetSeasons"), []byte(slaID), seasonsJSON}
+	for i, sla := range customer.SLAs {
+		if sla.ID == slaID {
+			response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
+			if response.Status != shim.OK {
+				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+			}
+
+			var newSLA SLA
+			err = json.Unmarshal(response.Payload, &newSLA)
+			if err != nil {
+				return err
+			}
+			customer.SLAs[i] = newSLA
+			return putCustomer(ctx, customer)
+		}
+	}
+	return fmt.Errorf("could not find sla with ID %s", slaID)
+}
+
 // DeleteAsset deletes an given asset from the world state.
 func (s *SmartContract) RemoveSLA(ctx contractapi.TransactionContextInterface, customerID string, slaID string) error {
 	exists, err := s.CustomerExist(ctx, customerID)
@@ -271,7 +468,7 @@ func (s *SmartContract) RemoveSLA(ctx contractapi.TransactionContextInterface, c
 	}
 
 	customer, readSLAerror := s.ReadCustomer(ctx, customerID)
-	if err != nil {
+	if readSLAerror != nil {
 		return readSLAerror
 	}
 
@@ -281,8 +478,8 @@ func (s *SmartContract) RemoveSLA(ctx contractapi.TransactionContextInterface, c
 			response := ctx.GetStub().InvokeChaincode("mower", invokeArgs, ctx.GetStub().GetChannelID())
 			fmt.Println("response status: ", response.Status)
 			if response.Status != shim.OK {
-				fmt.Println("failed to invoke chaincode. Got error: %s", response.Payload)
-				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Payload)
+				fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+				return fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
 			}
 			fmt.Println("CustomerSLAs before remove: ", customer.SLAs)
 			newSLAs := remove(customer.SLAs, i)
@@ -325,7 +522,7 @@ func (s *SmartContract) ReadSLA(ctx contractapi.TransactionContextInterface, cus
 	}
 
 	customer, readSLAerror := s.ReadCustomer(ctx, customerID)
-	if err != nil {
+	if readSLAerror != nil {
 		return nil, readSLAerror
 	}
 
@@ -348,7 +545,7 @@ func (s *SmartContract) GetAllSLA(ctx contractapi.TransactionContextInterface, c
 	}
 
 	customer, readSLAerror := s.ReadCustomer(ctx, customerID)
-	if err != nil {
+	if readSLAerror != nil {
 		return nil, readSLAerror
 	}
 	var allSLA []*SLA
@@ -363,3 +560,38 @@ func (s *SmartContract) GetAllSLA(ctx contractapi.TransactionContextInterface, c
 	fmt.Println("allSLAs: ", allSLA)
 	return allSLA, nil
 }
+
+// GetAllCustomers returns every customer in the world state. Only the service owner may call it.
+func (s *SmartContract) GetAllCustomers(ctx contractapi.TransactionContextInterface) ([]*Customer, error) {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, err
+	}
+	if !serviceOwner {
+		return nil, fmt.Errorf("only the service owner can read all customers")
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var customers []*Customer
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var customer Customer
+		err = json.Unmarshal(queryResponse.Value, &customer)
+		if err != nil {
+			return nil, err
+		}
+		upgradeLegacyCustomer(&customer)
+		customers = append(customers, &customer)
+	}
+
+	return customers, nil
+}
@@ -0,0 +1,28 @@
+package customer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+)
+
+func TestRelayedRejectionsKeepTheReasonOfTheMowerChaincode(t *testing.T) {
+	network := chaincodetest.NewNetwork("customer")
+	customerChaincode, err := contractapi.NewChaincode(&SmartContract{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	network.Deploy("customer", customerChaincode)
+	network.Deploy("mower", &mowerChaincodeStub{slas: make(map[string]SLA)})
+	alice := chaincodetest.NewIdentity(t, "Org1MSP", "alice", nil)
+
+	if _, err := network.Submit(alice, "customer", "CreateCustomer", "brf-1"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = network.Submit(alice, "customer", "CreateServiceSLA", "brf-1", "sla-1", "", mowingServiceType, "diamond", `{"TargetGrassLength":"5"}`)
+	if err == nil || !strings.HasSuffix(err.Error(), "Got error: invalid service level: diamond") {
+		t.Errorf("the rejection of the mower chaincode is %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package mower
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+)
+
+// customerChaincode is the name the customer chaincode is deployed with. It keeps the customers and relays the
+// changes they make to their SLAs to this chaincode.
+const customerChaincode = "customer"
+
+// customer is the part of a customer stored in the customer chaincode the SLAs of the customer are checked against
+type customer struct {
+	ID         string     `json:"ID"`
+	Owner      string     `json:"Owner"`
+	Properties []property `json:"Properties"`
+}
+
+// property is the part of a property of a customer stored in the customer chaincode the SLAs attached to it use
+type property struct {
+	ID      string `json:"ID"`
+	Address string `json:"Address"`
+	Mower   string `json:"Mower"`
+}
+
+// authorizeCustomer returns an error unless the caller may change the SLAs of the customer with given ID and owner.
+// Changes relayed by the customer chaincode carry the ID and owner of the stored customer, after it has checked the
+// caller, and it cannot be called back in their transaction. Other calls read the customer from the customer
+// chaincode, which only returns it to the identities that may access it, and the owner must be its stored owner,
+// so a caller cannot create or change SLAs of a customer by naming it. The customer is returned when it was read.
+func authorizeCustomer(ctx contractapi.TransactionContextInterface, customerID string, owner string) (*customer, error) {
+	relayed, err := access.RelayedBy(ctx.GetStub(), customerChaincode)
+	if err != nil {
+		return nil, err
+	}
+	if relayed {
+		return nil, access.AuthorizeCustomer(ctx.GetClientIdentity(), customerID, owner)
+	}
+
+	stored, err := readCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner != stored.Owner {
+		return nil, fmt.Errorf("the SLAs of customer %s must be owned by the owner of the customer", customerID)
+	}
+
+	return stored, nil
+}
+
+// authorizeCustomerID returns an error unless the caller may access the customer with given ID. Calls relayed by the
+// customer chaincode are trusted, since it has read the customer and checked the caller before relaying them.
+func authorizeCustomerID(ctx contractapi.TransactionContextInterface, customerID string) error {
+	relayed, err := access.RelayedBy(ctx.GetStub(), customerChaincode)
+	if err != nil {
+		return err
+	}
+	if relayed {
+		return nil
+	}
+
+	_, err = readCustomer(ctx, customerID)
+	return err
+}
+
+// readCustomer reads the customer with given ID from the customer chaincode and checks that the caller may access it
+func readCustomer(ctx contractapi.TransactionContextInterface, customerID string) (*customer, error) {
+	invokeArgs := [][]byte{[]byte("ReadCustomer"), []byte(customerID)}
+	response := ctx.GetStub().InvokeChaincode(customerChaincode, invokeArgs, ctx.GetStub().GetChannelID())
+	if response.Status != shim.OK {
+		fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+		return nil, fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+	}
+
+	var stored customer
+	err := json.Unmarshal(response.Payload, &stored)
+	if err != nil {
+		return nil, err
+	}
+
+	err = access.AuthorizeCustomer(ctx.GetClientIdentity(), stored.ID, stored.Owner)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// checkProperty returns an error unless the property with given ID belongs to the customer read from the customer
+// chaincode. The customer chaincode checks the properties of the calls it relays itself, for which no customer is read.
+func checkProperty(stored *customer, propertyID string) error {
+	if stored == nil {
+		return nil
+	}
+
+	for _, property := range stored.Properties {
+		if property.ID == propertyID {
+			return nil
+		}
+	}
+	return fmt.Errorf("could not find property with ID %s", propertyID)
+}
+
+// readMowerProperty reads the property with given ID of the customer from the customer chaincode, which only returns
+// it to the mower assigned to the property and the service owner
+func readMowerProperty(ctx contractapi.TransactionContextInterface, customerID string, propertyID string) (*property, error) {
+	invokeArgs := [][]byte{[]byte("ReadMowerProperty"), []byte(customerID), []byte(propertyID)}
+	response := ctx.GetStub().InvokeChaincode(customerChaincode, invokeArgs, ctx.GetStub().GetChannelID())
+	if response.Status != shim.OK {
+		fmt.Printf("failed to invoke chaincode. Got error: %s\n", response.Message)
+		return nil, fmt.Errorf("Failed to invoke chaincode. Got error: %s", response.Message)
+	}
+
+	var stored property
+	err := json.Unmarshal(response.Payload, &stored)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// readOwnedSLA reads the SLA with given id to change it, provided the caller may change the SLAs of its customer.
+// The customer is returned when it was read from the customer chaincode.
+func readOwnedSLA(ctx contractapi.TransactionContextInterface, id string) (*SLA, *customer, error) {
+	sla, err := getSLA(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stored, err := authorizeCustomer(ctx, sla.CustomerID, sla.Owner)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sla, stored, nil
+}
@@ -0,0 +1,277 @@
+package mower
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	// breachObjectType prefixes the composite keys breaches are stored under, keyed by SLA ID and reason
+	breachObjectType = "breach"
+	// breachEventName is the chaincode event emitted for every new breach, picked up by the breach relay
+	// in the c2b application which creates a job offer for the service providers
+	breachEventName = "SLABreached"
+
+	grassLengthBreach = "grass-length"
+	trappedBreach     = "trapped"
+	batteryBreach     = "battery"
+
+	breachOpen     = "Open"
+	breachResolved = "Resolved"
+)
+
+// breachServiceTypes maps the reason of a breach to the B2B service chaincode whose providers can fix it
+var breachServiceTypes = map[string]string{
+	// grass growing past the maximum length means the mower no longer cuts, so the razor needs changing
+	grassLengthBreach: "razor",
+	trappedBreach:     "trapped",
+	batteryBreach:     "battery",
+}
+
+// Breach describes a breached SLA that needs a service provider to fix the mower
+type Breach struct {
+	ID           string    `json:"ID"`
+	SLAID        string    `json:"SLAID"`
+	CustomerID   string    `json:"CustomerID"`
+	Reason       string    `json:"Reason"`
+	ServiceType  string    `json:"ServiceType"`
+	ServiceLevel string    `json:"ServiceLevel"`
+	Mower        string    `json:"Mower"`
+	Address      string    `json:"Address"`
+	Status       string    `json:"Status"`
+	ReportedAt   time.Time `json:"ReportedAt"`
+	Deadline     time.Time `json:"Deadline"`
+}
+
+// ReportGrassLength records the grass length measured by a mower. When the grass is longer than the maximum
+// grass length of the SLA a breach is recorded and returned, otherwise nothing is returned.
+// The grass length is given in cm as a decimal, e.g. "7.25".
+func (s *SmartContract) ReportGrassLength(ctx contractapi.TransactionContextInterface, slaID string, grassLength string) (*Breach, error) {
+	length, err := decimal.Parse(grassLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grass length: %v", err)
+	}
+
+	sla, reported, err := s.readReportedSLA(ctx, slaID)
+	if err != nil {
+		return nil, err
+	}
+	if sla.ServiceType != mowingServiceType {
+		return nil, fmt.Errorf("the SLA %s is a %s SLA and has no grass lengths", slaID, sla.ServiceType)
+	}
+
+	fmt.Println("Reported grass length: ", length)
+	if length.Cmp(sla.Parameters[maxGrassLengthParameter]) <= 0 {
+		return nil, nil
+	}
+
+	return recordBreach(ctx, sla, grassLengthBreach, reported.Mower, reported.Address)
+}
+
+// ReportFault records a fault reported by a mower, either trapped or battery, and returns the resulting breach.
+// Nothing is returned when an open breach for the same fault already exists.
+func (s *SmartContract) ReportFault(ctx contractapi.TransactionContextInterface, slaID string, fault string) (*Breach, error) {
+	if fault != trappedBreach && fault != batteryBreach {
+		return nil, fmt.Errorf("invalid fault: %s", fault)
+	}
+
+	sla, reported, err := s.readReportedSLA(ctx, slaID)
+	if err != nil {
+		return nil, err
+	}
+
+	return recordBreach(ctx, sla, fault, reported.Mower, reported.Address)
+}
+
+// ResolveBreach closes the open breach of the SLA so that new reports with the same reason create a new breach
+func (s *SmartContract) ResolveBreach(ctx contractapi.TransactionContextInterface, slaID string, reason string) error {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return err
+	}
+	if !serviceOwner {
+		return fmt.Errorf("only the service owner can resolve breaches")
+	}
+
+	breach, err := readBreach(ctx, slaID, reason)
+	if err != nil {
+		return err
+	}
+	if breach == nil {
+		return fmt.Errorf("there is no breach of SLA %s with reason %s", slaID, reason)
+	}
+
+	breach.Status = breachResolved
+	return putBreach(ctx, breach)
+}
+
+// GetBreaches returns every breach recorded for the SLA
+func (s *SmartContract) GetBreaches(ctx contractapi.TransactionContextInterface, slaID string) ([]*Breach, error) {
+	// reading the SLA checks that the caller owns it
+	_, err := s.ReadSLA(ctx, slaID)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(breachObjectType, []string{slaID})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	var breaches []*Breach
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var breach Breach
+		err = json.Unmarshal(queryResponse.Value, &breach)
+		if err != nil {
+			return nil, err
+		}
+		breaches = append(breaches, &breach)
+	}
+
+	return breaches, nil
+}
+
+// readReportedSLA reads the SLA a mower reports on and the property it is attached to. Only the mower assigned to
+// the property and the service owner can report, which the customer chaincode checks when it returns the property.
+func (s *SmartContract) readReportedSLA(ctx contractapi.TransactionContextInterface, slaID string) (*SLA, *property, error) {
+	mower, err := access.HasRole(ctx.GetClientIdentity(), access.MowerRole)
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !mower && !serviceOwner {
+		return nil, nil, fmt.Errorf("only mowers and the service owner can report on SLAs")
+	}
+
+	sla, err := getSLA(ctx, slaID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sla.PropertyID == "" {
+		return nil, nil, fmt.Errorf("the SLA %s is not attached to a property, so no mower can report on it", slaID)
+	}
+
+	reported, err := readMowerProperty(ctx, sla.CustomerID, sla.PropertyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sla, reported, nil
+}
+
+// recordBreach stores a new open breach of the SLA and emits it as a chaincode event.
+// The deadline of the breach follows from the service level, the same way as for jobs taken by service providers.
+func recordBreach(ctx contractapi.TransactionContextInterface, sla *SLA, reason string, mower string, address string) (*Breach, error) {
+	existing, err := readBreach(ctx, sla.ID, reason)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Status == breachOpen {
+		fmt.Println("Breach already open: ", existing.ID)
+		return nil, nil
+	}
+
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+	reportedAt := txTimestamp.AsTime()
+
+	var deadline time.Time
+	switch sla.ServiceLevel {
+	case "standard":
+		deadline = reportedAt.AddDate(0, 0, 7)
+	case "gold":
+		deadline = reportedAt.AddDate(0, 0, 5)
+	case "platinum":
+		deadline = reportedAt.AddDate(0, 0, 3)
+
+	default:
+		return nil, fmt.Errorf("invalid service level: %s", sla.ServiceLevel)
+	}
+
+	breach := Breach{
+		ID:           ctx.GetStub().GetTxID(),
+		SLAID:        sla.ID,
+		CustomerID:   sla.CustomerID,
+		Reason:       reason,
+		ServiceType:  breachServiceTypes[reason],
+		ServiceLevel: sla.ServiceLevel,
+		Mower:        mower,
+		Address:      address,
+		Status:       breachOpen,
+		ReportedAt:   reportedAt,
+		Deadline:     deadline,
+	}
+
+	err = putBreach(ctx, &breach)
+	if err != nil {
+		return nil, err
+	}
+
+	breachJSON, err := json.Marshal(breach)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.GetStub().SetEvent(breachEventName, breachJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set event: %v", err)
+	}
+
+	fmt.Println("Recorded breach: ", breach)
+	return &breach, nil
+}
+
+// readBreach returns the latest breach of the SLA with the given reason, or nil if there is none
+func readBreach(ctx contractapi.TransactionContextInterface, slaID string, reason string) (*Breach, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(breachObjectType, []string{slaID, reason})
+	if err != nil {
+		return nil, err
+	}
+
+	breachJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from world state: %v", err)
+	}
+	if breachJSON == nil {
+		return nil, nil
+	}
+
+	var breach Breach
+	err = json.Unmarshal(breachJSON, &breach)
+	if err != nil {
+		return nil, err
+	}
+	return &breach, nil
+}
+
+func putBreach(ctx contractapi.TransactionContextInterface, breach *Breach) error {
+	key, err := ctx.GetStub().CreateCompositeKey(breachObjectType, []string{breach.SLAID, breach.Reason})
+	if err != nil {
+		return err
+	}
+
+	breachJSON, err := json.Marshal(breach)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetStub().PutState(key, breachJSON)
+	if err != nil {
+		return fmt.Errorf("failed to put to world state. %v", err)
+	}
+	return nil
+}
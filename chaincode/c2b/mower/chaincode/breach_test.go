@@ -0,0 +1,50 @@
+package mower
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+)
+
+func TestReportsComeFromTheMowerOfTheProperty(t *testing.T) {
+	test := newSLATest(t)
+	_, err := test.network.Submit(test.alice, "mower", "CreateServiceSLA", "sla-1", "brf-1", test.alice.ID(), "garden", mowingServiceType, "gold", mowingParametersJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := test.createSLA(test.alice, "sla-2", "brf-1", test.alice.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	mower := chaincodetest.NewIdentity(t, "Org1MSP", "mower-1", map[string]string{access.RoleAttribute: access.MowerRole, access.MowerIDAttribute: "mower-1"})
+	otherMower := chaincodetest.NewIdentity(t, "Org1MSP", "mower-2", map[string]string{access.RoleAttribute: access.MowerRole, access.MowerIDAttribute: "mower-2"})
+
+	if _, err := test.network.Submit(otherMower, "mower", "ReportFault", "sla-1", trappedBreach); err == nil {
+		t.Error("a mower reported on the SLA of a property it is not assigned to")
+	}
+	if _, err := test.network.Submit(test.alice, "mower", "ReportFault", "sla-1", trappedBreach); err == nil {
+		t.Error("the customer reported on its SLA as a mower")
+	}
+	if _, err := test.network.Submit(mower, "mower", "ReportFault", "sla-2", trappedBreach); err == nil {
+		t.Error("a mower reported on an SLA without a property")
+	}
+
+	breachJSON, err := test.network.Submit(mower, "mower", "ReportGrassLength", "sla-1", "8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var breach Breach
+	err = json.Unmarshal(breachJSON, &breach)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if breach.Reason != grassLengthBreach || breach.Mower != "mower-1" || breach.Address != "Storgatan 1" {
+		t.Errorf("the breach of the grass length is %+v", breach)
+	}
+
+	if _, err := test.network.Submit(test.serviceOwner, "mower", "ReportFault", "sla-1", batteryBreach); err != nil {
+		t.Errorf("the service owner could not report a fault: %v", err)
+	}
+}
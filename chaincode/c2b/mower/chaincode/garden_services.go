@@ -0,0 +1,158 @@
+package mower
+
+const (
+	hedgeTrimmingServiceType  = "hedge-trimming"
+	leafCollectionServiceType = "leaf-collection"
+	snowClearingServiceType   = "snow-clearing"
+
+	hedgeLengthParameter         = "HedgeLength"
+	hedgeHeightParameter         = "HedgeHeight"
+	trimsPerYearParameter        = "TrimsPerYear"
+	areaParameter                = "Area"
+	collectionsPerMonthParameter = "CollectionsPerMonth"
+	maxSnowDepthParameter        = "MaxSnowDepth"
+)
+
+// hedgeTrimmingService trims the hedges of a property a number of times per year
+type hedgeTrimmingService struct{}
+
+func (hedgeTrimmingService) ServiceType() string {
+	return hedgeTrimmingServiceType
+}
+
+func (hedgeTrimmingService) Schema() ServiceSchema {
+	return ServiceSchema{
+		ServiceType: hedgeTrimmingServiceType,
+		Parameters: []ParameterSchema{
+			{Name: hedgeLengthParameter, Unit: "m", Min: "1.00", Max: "1000.00", Required: true},
+			{Name: hedgeHeightParameter, Unit: "m", Min: "0.50", Max: "5.00", Required: true},
+			{Name: trimsPerYearParameter, Unit: "trims", Min: "1.00", Max: "12.00", Required: true},
+		},
+	}
+}
+
+func (h hedgeTrimmingService) Validate(params Parameters) error {
+	return h.Schema().validate(params)
+}
+
+func (hedgeTrimmingService) Breakdown(serviceLevel string, params Parameters) ([]PriceComponent, error) {
+	baseCost, err := levelPrices{Standard: "30.00", Gold: "60.00", Platinum: "120.00"}.baseCost(serviceLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every trim costs 2 per square meter of hedge side, spread out over the months of the year
+	hedgeArea, err := params[hedgeLengthParameter].Mul(params[hedgeHeightParameter])
+	if err != nil {
+		return nil, err
+	}
+	hedgeSides, err := hedgeArea.MulInt(2)
+	if err != nil {
+		return nil, err
+	}
+	annualCost, err := hedgeSides.Mul(params[trimsPerYearParameter])
+	if err != nil {
+		return nil, err
+	}
+	trimCost, err := annualCost.DivInt(12)
+	if err != nil {
+		return nil, err
+	}
+
+	return []PriceComponent{
+		{Name: baseCostComponent, Amount: baseCost},
+		{Name: "TrimCost", Amount: trimCost},
+	}, nil
+}
+
+// leafCollectionService collects fallen leaves from a property a number of times per month
+type leafCollectionService struct{}
+
+func (leafCollectionService) ServiceType() string {
+	return leafCollectionServiceType
+}
+
+func (leafCollectionService) Schema() ServiceSchema {
+	return ServiceSchema{
+		ServiceType: leafCollectionServiceType,
+		Parameters: []ParameterSchema{
+			{Name: areaParameter, Unit: "m2", Min: "10.00", Max: "100000.00", Required: true},
+			{Name: collectionsPerMonthParameter, Unit: "collections", Min: "1.00", Max: "8.00", Required: true},
+		},
+	}
+}
+
+func (l leafCollectionService) Validate(params Parameters) error {
+	return l.Schema().validate(params)
+}
+
+func (leafCollectionService) Breakdown(serviceLevel string, params Parameters) ([]PriceComponent, error) {
+	baseCost, err := levelPrices{Standard: "20.00", Gold: "40.00", Platinum: "80.00"}.baseCost(serviceLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every collection costs 0.05 per square meter
+	costPerCollection, err := params[areaParameter].Mul("0.05")
+	if err != nil {
+		return nil, err
+	}
+	collectionCost, err := costPerCollection.Mul(params[collectionsPerMonthParameter])
+	if err != nil {
+		return nil, err
+	}
+
+	return []PriceComponent{
+		{Name: baseCostComponent, Amount: baseCost},
+		{Name: "CollectionCost", Amount: collectionCost},
+	}, nil
+}
+
+// snowClearingService clears the snow on a property before it gets deeper than the agreed depth
+type snowClearingService struct{}
+
+func (snowClearingService) ServiceType() string {
+	return snowClearingServiceType
+}
+
+func (snowClearingService) Schema() ServiceSchema {
+	return ServiceSchema{
+		ServiceType: snowClearingServiceType,
+		Parameters: []ParameterSchema{
+			{Name: areaParameter, Unit: "m2", Min: "10.00", Max: "100000.00", Required: true},
+			{Name: maxSnowDepthParameter, Unit: "cm", Min: "1.00", Max: "50.00", Required: true},
+		},
+	}
+}
+
+func (sc snowClearingService) Validate(params Parameters) error {
+	return sc.Schema().validate(params)
+}
+
+func (snowClearingService) Breakdown(serviceLevel string, params Parameters) ([]PriceComponent, error) {
+	baseCost, err := levelPrices{Standard: "40.00", Gold: "80.00", Platinum: "160.00"}.baseCost(serviceLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	// A lower snow depth means more frequent clearing, so the area cost grows with the inverse depth
+	// area * 0.02 * (1 + 10/depth) = area * 0.02 + area * 0.2 / depth
+	depthArea, err := params[areaParameter].Mul("0.20")
+	if err != nil {
+		return nil, err
+	}
+	depthCost, err := depthArea.Div(params[maxSnowDepthParameter])
+	if err != nil {
+		return nil, err
+	}
+	areaCost, err := params[areaParameter].Mul("0.02")
+	if err != nil {
+		return nil, err
+	}
+
+	return []PriceComponent{
+		{Name: baseCostComponent, Amount: baseCost},
+		{Name: "AreaCost", Amount: areaCost},
+		{Name: "DepthFactor", Amount: depthCost},
+	}, nil
+}
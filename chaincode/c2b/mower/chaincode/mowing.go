@@ -0,0 +1,122 @@
+package mower
+
+import (
+	"fmt"
+
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+const (
+	mowingServiceType = "mowing"
+
+	targetGrassLengthParameter = "TargetGrassLength"
+	maxGrassLengthParameter    = "MaxGrassLength"
+	minGrassLengthParameter    = "MinGrassLength"
+)
+
+// mowingService is the robotic lawn mowing service, priced on how short and how even the grass is kept
+type mowingService struct{}
+
+func (mowingService) ServiceType() string {
+	return mowingServiceType
+}
+
+func (mowingService) Schema() ServiceSchema {
+	return ServiceSchema{
+		ServiceType: mowingServiceType,
+		Parameters: []ParameterSchema{
+			{Name: targetGrassLengthParameter, Unit: "cm", Min: "1.00", Max: "30.00", Required: true},
+			{Name: maxGrassLengthParameter, Unit: "cm", Min: "1.00", Max: "30.00", Required: true},
+			{Name: minGrassLengthParameter, Unit: "cm", Min: "1.00", Max: "30.00", Required: true},
+		},
+	}
+}
+
+func (m mowingService) Validate(params Parameters) error {
+	err := m.Schema().validate(params)
+	if err != nil {
+		return err
+	}
+
+	if params[maxGrassLengthParameter].Cmp(params[minGrassLengthParameter]) <= 0 {
+		return fmt.Errorf("%s must be greater than %s", maxGrassLengthParameter, minGrassLengthParameter)
+	}
+
+	return nil
+}
+
+func (mowingService) Breakdown(serviceLevel string, params Parameters) ([]PriceComponent, error) {
+	baseCost, err := levelPrices{Standard: "50.00", Gold: "100.00", Platinum: "200.00"}.baseCost(serviceLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cost grows with the inverse of the spread (larger spread, lower cost)
+	// and with the inverse of the target length (shorter target, higher cost), weighted 0.7 and 0.3
+	spread, err := params[maxGrassLengthParameter].Sub(params[minGrassLengthParameter])
+	if err != nil {
+		return nil, fmt.Errorf("invalid grass length interval: %v", err)
+	}
+	spreadWeight, err := baseCost.Mul("0.70")
+	if err != nil {
+		return nil, err
+	}
+	spreadCost, err := spreadWeight.Div(spread)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grass length interval: %v", err)
+	}
+	targetWeight, err := baseCost.Mul("0.30")
+	if err != nil {
+		return nil, err
+	}
+	targetCost, err := targetWeight.Div(params[targetGrassLengthParameter])
+	if err != nil {
+		return nil, fmt.Errorf("invalid target grass length: %v", err)
+	}
+
+	return []PriceComponent{
+		{Name: baseCostComponent, Amount: baseCost},
+		{Name: "SpreadFactor", Amount: spreadCost},
+		{Name: "TargetFactor", Amount: targetCost},
+	}, nil
+}
+
+// mowingParameters builds the parameter set of a mowing SLA from its grass lengths
+func mowingParameters(targetGrassLength decimal.Decimal, maxGrassLength decimal.Decimal, minGrassLength decimal.Decimal) Parameters {
+	return Parameters{
+		targetGrassLengthParameter: targetGrassLength,
+		maxGrassLengthParameter:    maxGrassLength,
+		minGrassLengthParameter:    minGrassLength,
+	}
+}
+
+// parseMowingParameters builds the parameter set of a mowing SLA from grass lengths given as decimal strings
+func parseMowingParameters(targetGrassLength string, maxGrassLength string, minGrassLength string) (Parameters, error) {
+	target, err := decimal.Parse(targetGrassLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target grass length: %v", err)
+	}
+	maxLength, err := decimal.Parse(maxGrassLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max grass length: %v", err)
+	}
+	minLength, err := decimal.Parse(minGrassLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid min grass length: %v", err)
+	}
+	return mowingParameters(target, maxLength, minLength), nil
+}
+
+// upgradeLegacySLA moves the grass lengths of an SLA stored before service types existed into its parameter set.
+// Such SLAs are always mowing SLAs.
+func upgradeLegacySLA(sla *SLA) {
+	if sla.ServiceType != "" {
+		return
+	}
+
+	sla.ServiceType = mowingServiceType
+	sla.Parameters = mowingParameters(sla.TargetGrassLength, sla.MaxGrassLength, sla.MinGrassLength)
+	sla.TargetGrassLength = ""
+	sla.MaxGrassLength = ""
+	sla.MinGrassLength = ""
+}
@@ -0,0 +1,108 @@
+package mower
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// maxQuotes is the largest number of configurations that can be quoted in a single call
+const maxQuotes = 200
+
+// QuoteRequest is a single SLA configuration to quote. The reference is chosen by the caller to match quotes
+// to its configurations, and the service type defaults to mowing.
+type QuoteRequest struct {
+	Reference    string     `json:"Reference,omitempty" metadata:",optional"`
+	ServiceType  string     `json:"ServiceType,omitempty" metadata:",optional"`
+	ServiceLevel string     `json:"ServiceLevel"`
+	Parameters   Parameters `json:"Parameters"`
+	Seasons      []Season   `json:"Seasons,omitempty" metadata:",optional"`
+}
+
+// Quote is the price of an SLA configuration with the breakdown of its monthly cost outside of any season.
+// An invalid configuration is quoted with the error instead of failing the whole batch.
+type Quote struct {
+	Reference    string           `json:"Reference"`
+	ServiceType  string           `json:"ServiceType"`
+	ServiceLevel string           `json:"ServiceLevel"`
+	Parameters   Parameters       `json:"Parameters"`
+	MonthlyCost  decimal.Money    `json:"MonthlyCost"`
+	Breakdown    []PriceComponent `json:"Breakdown"`
+	AnnualTotal  decimal.Money    `json:"AnnualTotal"`
+	Error        string           `json:"Error,omitempty" metadata:",optional"`
+}
+
+// EvaluateSLABatch quotes many SLA configurations at once, for example every service level against several
+// grass length intervals. Every quote has the monthly cost with its breakdown and the annual total of the
+// price schedule for the current year, which takes the seasons of the configuration into account.
+func (s *SmartContract) EvaluateSLABatch(ctx contractapi.TransactionContextInterface, requests []QuoteRequest) ([]*Quote, error) {
+	if len(requests) > maxQuotes {
+		return nil, fmt.Errorf("at most %d configurations can be quoted at once, got %d", maxQuotes, len(requests))
+	}
+
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+	year := txTimestamp.AsTime().Year()
+
+	quotes := []*Quote{}
+	for _, request := range requests {
+		quote := quoteSLA(request, year)
+		quotes = append(quotes, quote)
+	}
+
+	return quotes, nil
+}
+
+// quoteSLA prices a single configuration, recording any error on the quote
+func quoteSLA(request QuoteRequest, year int) *Quote {
+	serviceType := request.ServiceType
+	if serviceType == "" {
+		serviceType = mowingServiceType
+	}
+
+	quote := &Quote{
+		Reference:    request.Reference,
+		ServiceType:  serviceType,
+		ServiceLevel: request.ServiceLevel,
+		Parameters:   request.Parameters,
+		Breakdown:    []PriceComponent{},
+	}
+
+	sla := SLA{
+		ServiceType:  serviceType,
+		ServiceLevel: request.ServiceLevel,
+		Parameters:   request.Parameters,
+		Seasons:      request.Seasons,
+	}
+	err := appraiseSLA(&sla)
+	if err != nil {
+		quote.Error = err.Error()
+		return quote
+	}
+
+	plugin, err := getServicePlugin(serviceType)
+	if err != nil {
+		quote.Error = err.Error()
+		return quote
+	}
+
+	breakdown, err := plugin.Breakdown(sla.ServiceLevel, sla.Parameters)
+	if err != nil {
+		quote.Error = err.Error()
+		return quote
+	}
+
+	schedule, err := priceSchedule(plugin, &sla, year)
+	if err != nil {
+		quote.Error = err.Error()
+		return quote
+	}
+
+	quote.MonthlyCost = sla.AppraisedValue
+	quote.Breakdown = breakdown
+	quote.AnnualTotal = schedule.AnnualTotal
+	return quote
+}
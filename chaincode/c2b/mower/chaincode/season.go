@@ -0,0 +1,208 @@
+package mower
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// seasonDateLayout is the layout of the first and last day of a season, month and day without a year
+const seasonDateLayout = "01-02"
+
+// Season is a window of the year in which an SLA is delivered with other parameters than the rest of the year,
+// for example a longer target grass length in autumn, or not at all when it is paused during the winter.
+// Start and End are inclusive and given as MM-DD. A season that ends before it starts runs over the new year.
+type Season struct {
+	Name       string     `json:"Name"`
+	Start      string     `json:"Start"`
+	End        string     `json:"End"`
+	Paused     bool       `json:"Paused"`
+	Parameters Parameters `json:"Parameters,omitempty" metadata:",optional"`
+}
+
+// MonthlyPrice is the price of an SLA for a single month of the year
+type MonthlyPrice struct {
+	Month      int           `json:"Month"`
+	Price      decimal.Money `json:"Price"`
+	BilledDays int           `json:"BilledDays"`
+	PausedDays int           `json:"PausedDays"`
+	Seasons    []string      `json:"Seasons"`
+}
+
+// PriceSchedule is the price of an SLA for every month of a year. The base price is the monthly cost outside of any season.
+// Every month is priced per day, so months split between seasons and the month the SLA starts in are pro-rated.
+type PriceSchedule struct {
+	Year        int            `json:"Year"`
+	BasePrice   decimal.Money  `json:"BasePrice"`
+	Months      []MonthlyPrice `json:"Months"`
+	AnnualTotal decimal.Money  `json:"AnnualTotal"`
+}
+
+// validateSeasons checks that the seasons are well formed, that they do not overlap and that the parameters
+// of every season are valid for the service type once they are merged with the parameters of the SLA
+func validateSeasons(plugin ServicePlugin, sla *SLA) error {
+	names := make(map[string]bool)
+	for _, season := range sla.Seasons {
+		if season.Name == "" {
+			return fmt.Errorf("every season needs a name")
+		}
+		if names[season.Name] {
+			return fmt.Errorf("the season %s is given more than once", season.Name)
+		}
+		names[season.Name] = true
+
+		_, err := time.Parse(seasonDateLayout, season.Start)
+		if err != nil {
+			return fmt.Errorf("invalid start %s of season %s, expected MM-DD", season.Start, season.Name)
+		}
+		_, err = time.Parse(seasonDateLayout, season.End)
+		if err != nil {
+			return fmt.Errorf("invalid end %s of season %s, expected MM-DD", season.End, season.Name)
+		}
+
+		if season.Paused {
+			continue
+		}
+		err = plugin.Validate(seasonParameters(sla.Parameters, season))
+		if err != nil {
+			return fmt.Errorf("invalid parameters for season %s: %v", season.Name, err)
+		}
+	}
+
+	// walk a leap year so that seasons starting or ending on February 29 are checked as well
+	for date := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC); date.Year() == 2024; date = date.AddDate(0, 0, 1) {
+		first := seasonAt(sla.Seasons, date)
+		if first < 0 {
+			continue
+		}
+		for i := first + 1; i < len(sla.Seasons); i++ {
+			if inSeason(sla.Seasons[i], date) {
+				return fmt.Errorf("the seasons %s and %s overlap on %s", sla.Seasons[first].Name, sla.Seasons[i].Name, date.Format(seasonDateLayout))
+			}
+		}
+	}
+
+	return nil
+}
+
+// priceSchedule prices the SLA for every month of the year. Days before the start date of the SLA and paused days are not billed.
+// The price of a month is the sum of the monthly cost in effect on each billed day divided by the number of days in the month,
+// rounded half away from zero to whole cents.
+func priceSchedule(plugin ServicePlugin, sla *SLA, year int) (*PriceSchedule, error) {
+	basePrice, err := evaluate(plugin, sla.ServiceLevel, sla.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	seasonPrices := make([]decimal.Decimal, len(sla.Seasons))
+	for i, season := range sla.Seasons {
+		if season.Paused {
+			continue
+		}
+		seasonPrices[i], err = evaluate(plugin, sla.ServiceLevel, seasonParameters(sla.Parameters, season))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start := sla.StartDate.UTC()
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	annualTotal := decimal.Zero
+	schedule := PriceSchedule{
+		Year:      year,
+		BasePrice: decimal.NewMoney(basePrice, decimal.DefaultCurrency),
+		Months:    []MonthlyPrice{},
+	}
+	for month := time.January; month <= time.December; month++ {
+		monthlyPrice := MonthlyPrice{
+			Month:   int(month),
+			Seasons: []string{},
+		}
+
+		daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		total := decimal.Zero
+		for day := 1; day <= daysInMonth; day++ {
+			date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+			if date.Before(startDay) {
+				continue
+			}
+
+			price := basePrice
+			i := seasonAt(sla.Seasons, date)
+			if i >= 0 {
+				season := sla.Seasons[i]
+				if !contains(monthlyPrice.Seasons, season.Name) {
+					monthlyPrice.Seasons = append(monthlyPrice.Seasons, season.Name)
+				}
+				if season.Paused {
+					monthlyPrice.PausedDays++
+					continue
+				}
+				price = seasonPrices[i]
+			}
+
+			monthlyPrice.BilledDays++
+			total, err = total.Add(price)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		price, err := total.DivInt(int64(daysInMonth))
+		if err != nil {
+			return nil, err
+		}
+		monthlyPrice.Price = decimal.NewMoney(price, decimal.DefaultCurrency)
+		annualTotal, err = annualTotal.Add(price)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Months = append(schedule.Months, monthlyPrice)
+	}
+	schedule.AnnualTotal = decimal.NewMoney(annualTotal, decimal.DefaultCurrency)
+
+	return &schedule, nil
+}
+
+// seasonParameters returns the parameters of the SLA with the parameters of the season taking precedence
+func seasonParameters(base Parameters, season Season) Parameters {
+	params := Parameters{}
+	for name, value := range base {
+		params[name] = value
+	}
+	for name, value := range season.Parameters {
+		params[name] = value
+	}
+	return params
+}
+
+// seasonAt returns the index of the first season the date falls in, or -1 if it is outside of every season
+func seasonAt(seasons []Season, date time.Time) int {
+	for i, season := range seasons {
+		if inSeason(season, date) {
+			return i
+		}
+	}
+	return -1
+}
+
+// inSeason returns true when the date falls in the season. The seasons must have been validated.
+func inSeason(season Season, date time.Time) bool {
+	day := date.Format(seasonDateLayout)
+	// MM-DD strings sort in calendar order
+	if season.Start <= season.End {
+		return season.Start <= day && day <= season.End
+	}
+	return day >= season.Start || day <= season.End
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package mower
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
+)
+
+// Parameters holds the service specific parameters of an SLA keyed by parameter name
+type Parameters map[string]decimal.Decimal
+
+// ParameterSchema describes a single parameter accepted by a service type
+type ParameterSchema struct {
+	Name     string          `json:"Name"`
+	Unit     string          `json:"Unit"`
+	Min      decimal.Decimal `json:"Min"`
+	Max      decimal.Decimal `json:"Max"`
+	Required bool            `json:"Required"`
+}
+
+// ServiceSchema describes the parameter set of a service type
+type ServiceSchema struct {
+	ServiceType string            `json:"ServiceType"`
+	Parameters  []ParameterSchema `json:"Parameters"`
+}
+
+// ServicePlugin validates and prices the SLAs of a single service type.
+// Every service that can be sold to a customer implements it and is added to servicePlugins.
+type ServicePlugin interface {
+	// ServiceType returns the name the service is stored under in an SLA
+	ServiceType() string
+	// Schema returns the parameters the service accepts
+	Schema() ServiceSchema
+	// Validate checks the parameters against the schema and any rules between parameters
+	Validate(params Parameters) error
+	// Breakdown returns the components of the monthly cost of the service in the default currency
+	// for the given service level and parameters, the base cost of the service level first
+	Breakdown(serviceLevel string, params Parameters) ([]PriceComponent, error)
+}
+
+// PriceComponent is a named part of the monthly cost of a service, so a customer can see why a quote costs what it does
+type PriceComponent struct {
+	Name   string          `json:"Name"`
+	Amount decimal.Decimal `json:"Amount"`
+}
+
+// servicePlugins holds every service type that can be sold, keyed by service type
+var servicePlugins = map[string]ServicePlugin{
+	mowingServiceType:         mowingService{},
+	hedgeTrimmingServiceType:  hedgeTrimmingService{},
+	leafCollectionServiceType: leafCollectionService{},
+	snowClearingServiceType:   snowClearingService{},
+}
+
+// getServicePlugin returns the plugin for the service type
+func getServicePlugin(serviceType string) (ServicePlugin, error) {
+	plugin, ok := servicePlugins[serviceType]
+	if !ok {
+		return nil, fmt.Errorf("invalid service type: %s", serviceType)
+	}
+	return plugin, nil
+}
+
+// serviceTypes returns the registered service types in alphabetical order
+func serviceTypes() []string {
+	var types []string
+	for serviceType := range servicePlugins {
+		types = append(types, serviceType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// validate checks that every required parameter is present, that no unknown parameters are given
+// and that every value is within the bounds of the schema
+func (schema ServiceSchema) validate(params Parameters) error {
+	known := make(map[string]bool)
+	for _, parameter := range schema.Parameters {
+		known[parameter.Name] = true
+
+		value, ok := params[parameter.Name]
+		if !ok {
+			if parameter.Required {
+				return fmt.Errorf("missing parameter %s for service type %s", parameter.Name, schema.ServiceType)
+			}
+			continue
+		}
+		if _, err := value.Units(); err != nil {
+			return fmt.Errorf("invalid parameter %s: %v", parameter.Name, err)
+		}
+		if value.Cmp(parameter.Min) < 0 || value.Cmp(parameter.Max) > 0 {
+			return fmt.Errorf("parameter %s must be between %v and %v %s, got %v", parameter.Name, parameter.Min, parameter.Max, parameter.Unit, value)
+		}
+	}
+
+	for name := range params {
+		if !known[name] {
+			return fmt.Errorf("unknown parameter %s for service type %s", name, schema.ServiceType)
+		}
+	}
+
+	return nil
+}
+
+// evaluate returns the monthly cost of the service, the sum of its price components
+func evaluate(plugin ServicePlugin, serviceLevel string, params Parameters) (decimal.Decimal, error) {
+	components, err := plugin.Breakdown(serviceLevel, params)
+	if err != nil {
+		return "", err
+	}
+
+	total := decimal.Zero
+	for _, component := range components {
+		total, err = total.Add(component.Amount)
+		if err != nil {
+			return "", err
+		}
+	}
+	return total, nil
+}
+
+// baseCostComponent is the name of the price component every service starts from, the base cost of the service level
+const baseCostComponent = "BaseCost"
+
+// levelPrices holds the base monthly cost of a service for every service level
+type levelPrices struct {
+	Standard decimal.Decimal
+	Gold     decimal.Decimal
+	Platinum decimal.Decimal
+}
+
+// baseCost returns the base monthly cost for the service level
+func (prices levelPrices) baseCost(serviceLevel string) (decimal.Decimal, error) {
+	switch serviceLevel {
+	case "standard":
+		return prices.Standard, nil
+	case "gold":
+		return prices.Gold, nil
+	case "platinum":
+		return prices.Platinum, nil
+
+	default:
+		return "", fmt.Errorf("invalid service level: %s", serviceLevel)
+	}
+}
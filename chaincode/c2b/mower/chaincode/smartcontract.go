@@ -3,8 +3,11 @@ package mower
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/decimal"
 )
 
 // SmartContract provides functions for managing an Asset
@@ -12,22 +15,64 @@ type SmartContract struct {
 	contractapi.Contract
 }
 
-// Asset describes basic details of what makes up a simple asset
+// customerSLAIndex prefixes the composite keys that index the SLAs by customer ID and SLA ID
+const customerSLAIndex = "customer~sla"
+
+// SLA is the envelope every service agreement is stored in. The service type selects the
+// ServicePlugin that validates the parameters and evaluates the monthly cost.
 // Insert struct field in alphabetic order => to achieve determinism across languages
 // golang keeps the order when marshal to json but doesn't order automatically
 type SLA struct {
-	AppraisedValue    int     `json:"AppraisedValue,omitempty"`
-	ServiceLevel      string  `json:"ServiceLevel"`
-	TargetGrassLength float32 `json:"TargetGrassLength"`
-	MaxGrassLength    float32 `json:"MaxGrassLength"`
-	MinGrassLength    float32 `json:"MinGrassLength"`
-	ID                string  `json:"ID"`
+	AppraisedValue decimal.Money `json:"AppraisedValue"`
+	ServiceType    string        `json:"ServiceType"`
+	ServiceLevel   string        `json:"ServiceLevel"`
+	Parameters     Parameters    `json:"Parameters"`
+	ID             string        `json:"ID"`
+	CustomerID     string        `json:"CustomerID"`
+	Owner          string        `json:"Owner"`
+	PropertyID     string        `json:"PropertyID"`
+	Seasons        []Season      `json:"Seasons,omitempty" metadata:",optional"`
+	StartDate      time.Time     `json:"StartDate"`
+
+	// Grass lengths of mowing SLAs stored before service types existed, encoded as floats.
+	// They are moved into Parameters when such an SLA is read.
+	TargetGrassLength decimal.Decimal `json:"TargetGrassLength,omitempty" metadata:",optional"`
+	MaxGrassLength    decimal.Decimal `json:"MaxGrassLength,omitempty" metadata:",optional"`
+	MinGrassLength    decimal.Decimal `json:"MinGrassLength,omitempty" metadata:",optional"`
 }
 
-// CreateAsset issues a new asset to the world state with given details.
-func (s *SmartContract) CreateSLA(ctx contractapi.TransactionContextInterface, id string, serviceLevel string, targetgrasslength float32, maxgrasslength float32, mingrasslength float32) (*SLA, error) {
+// CreateSLA issues a new mowing SLA for the customer to the world state with given grass lengths in cm as decimals, e.g. "5.5".
+// The caller must be the service owner or the identity the customer is bound to.
+func (s *SmartContract) CreateSLA(ctx contractapi.TransactionContextInterface, id string, customerID string, owner string, serviceLevel string, targetgrasslength string, maxgrasslength string, mingrasslength string) (*SLA, error) {
 	fmt.Println("In CreateSLA in mower contract")
 
+	parameters, err := parseMowingParameters(targetgrasslength, maxgrasslength, mingrasslength)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateServiceSLA(ctx, id, customerID, owner, "", mowingServiceType, serviceLevel, parameters)
+}
+
+// CreateServiceSLA issues a new SLA of any service type for the customer to the world state.
+// The parameters are validated against the schema of the service type before the SLA is evaluated.
+// The property ID names the property of the customer the SLA is attached to and may be empty, otherwise the property
+// must belong to the customer.
+// The owner must be the owner of the customer in the customer chaincode, and the caller must be allowed to access it.
+func (s *SmartContract) CreateServiceSLA(ctx contractapi.TransactionContextInterface, id string, customerID string, owner string, propertyID string, serviceType string, serviceLevel string, parameters Parameters) (*SLA, error) {
+	fmt.Println("In CreateServiceSLA in mower contract")
+
+	stored, err := authorizeCustomer(ctx, customerID, owner)
+	if err != nil {
+		return nil, err
+	}
+	if propertyID != "" {
+		err = checkProperty(stored, propertyID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	exists, err := s.SLAExists(ctx, id)
 	if err != nil {
 		fmt.Println("sla aready exists")
@@ -37,35 +82,35 @@ func (s *SmartContract) CreateSLA(ctx contractapi.TransactionContextInterface, i
 		return nil, fmt.Errorf("the SLA %s already exists", id)
 	}
 
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
+
 	newSLA := SLA{
-		AppraisedValue:    0,
-		ID:                id,
-		ServiceLevel:      serviceLevel,
-		TargetGrassLength: targetgrasslength,
-		MaxGrassLength:    maxgrasslength,
-		MinGrassLength:    mingrasslength,
+		ID:           id,
+		ServiceType:  serviceType,
+		ServiceLevel: serviceLevel,
+		Parameters:   parameters,
+		CustomerID:   customerID,
+		Owner:        owner,
+		PropertyID:   propertyID,
+		StartDate:    txTimestamp.AsTime(),
 	}
 
 	fmt.Println("SLA before evaluation: ", newSLA)
 
-	slaValue, err := s.EvaluateSLA(ctx, newSLA.ServiceLevel, newSLA.TargetGrassLength, newSLA.MaxGrassLength, newSLA.MinGrassLength)
-	fmt.Println("slaValue: ", slaValue)
+	err = appraiseSLA(&newSLA)
 	if err != nil {
 		fmt.Println("error evaluating SLA: ", err)
 		return nil, err
 	}
 
-	newSLA.AppraisedValue = slaValue
 	fmt.Println("SLA after evaluation: ", newSLA)
-	slaJSON, err := json.Marshal(newSLA)
+	err = putSLA(ctx, &newSLA)
 	if err != nil {
-		fmt.Println("Error marshalling SLA: ")
 		return nil, err
 	}
-	ctx.GetStub().PutState(id, slaJSON)
-	if err != nil {
-		return nil, fmt.Errorf("failed to put to world state. %v", err)
-	}
 
 	return &newSLA, nil
 }
@@ -79,94 +124,129 @@ func (s *SmartContract) ChangeServiceLevel(ctx contractapi.TransactionContextInt
 	}
 	if !exists {
 		fmt.Println("SLA not found")
-		return nil, err
+		return nil, fmt.Errorf("the SLA %s does not exist", slaID)
 	}
 
-	sla, err := s.ReadSLA(ctx, slaID)
+	sla, _, err := readOwnedSLA(ctx, slaID)
 	if err != nil {
 		fmt.Println("Error reading sla")
 		return nil, err
 	}
 
-	switch newServiceLevel {
-	case "standard":
-		sla.ServiceLevel = "standard"
-	case "gold":
-		fmt.Println("In gold mode")
-		sla.ServiceLevel = "gold"
-	case "platinum":
-		sla.ServiceLevel = "platinum"
+	sla.ServiceLevel = newServiceLevel
 
-	default:
-		fmt.Println("In default mode")
-		return nil, fmt.Errorf("invalid service level")
-	}
-
-	newAppraisedValue, err := s.EvaluateSLA(ctx, sla.ServiceLevel, sla.TargetGrassLength, sla.MaxGrassLength, sla.MinGrassLength)
+	err = appraiseSLA(sla)
 	if err != nil {
 		fmt.Println("error evaluating SLA")
 		return nil, err
 	}
 
-	sla.AppraisedValue = newAppraisedValue
-
-	slaJSON, err := json.Marshal(sla)
+	err = putSLA(ctx, sla)
 	if err != nil {
 		return nil, err
 	}
-	err = ctx.GetStub().PutState(slaID, slaJSON)
+	return sla, nil
+}
+
+// EvaluateSLA returns the price schedule of a mowing SLA with the given grass lengths for the current year
+func (s *SmartContract) EvaluateSLA(ctx contractapi.TransactionContextInterface, serviceLevel string, targetGrassLength string, maxGrassLength string, minGrassLength string) (*PriceSchedule, error) {
+	parameters, err := parseMowingParameters(targetGrassLength, maxGrassLength, minGrassLength)
 	if err != nil {
 		return nil, err
 	}
-	return sla, nil
+
+	return s.EvaluateServiceSLA(ctx, mowingServiceType, serviceLevel, parameters, []Season{})
 }
 
-func (s *SmartContract) EvaluateSLA(ctx contractapi.TransactionContextInterface, serviceLevel string, targetGrassLength float32, maxGrassLength float32, minGrassLength float32) (int, error) {
+// EvaluateServiceSLA returns the price schedule of an SLA of any service type with the given seasons for the current year
+func (s *SmartContract) EvaluateServiceSLA(ctx contractapi.TransactionContextInterface, serviceType string, serviceLevel string, parameters Parameters, seasons []Season) (*PriceSchedule, error) {
+	sla := SLA{
+		ServiceType:  serviceType,
+		ServiceLevel: serviceLevel,
+		Parameters:   parameters,
+		Seasons:      seasons,
+	}
 
-	spread := maxGrassLength - minGrassLength
+	err := appraiseSLA(&sla)
+	if err != nil {
+		return nil, err
+	}
 
-	fmt.Println("spread: ", spread)
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction timestamp: %v", err)
+	}
 
-	// Invert the spread for cost calculation (larger spread, lower cost)
-	inverseSpread := 1.0 / spread
+	plugin, err := getServicePlugin(serviceType)
+	if err != nil {
+		return nil, err
+	}
+	return priceSchedule(plugin, &sla, txTimestamp.AsTime().Year())
+}
 
-	// Cost factor based on target length (shorter target, higher cost)
-	targetFactor := 1.0 / targetGrassLength
+// SetSeasons replaces the seasons of an SLA. Every season is validated against the service type of the SLA.
+func (s *SmartContract) SetSeasons(ctx contractapi.TransactionContextInterface, id string, seasons []Season) (*SLA, error) {
+	sla, _, err := readOwnedSLA(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	fmt.Println("Target factor: ", targetFactor)
+	sla.Seasons = seasons
 
-	// Combine factors with a weighting factor (adjust weight as needed)
-	costFactor := (inverseSpread * 0.7) + (targetFactor * 0.3)
+	err = appraiseSLA(sla)
+	if err != nil {
+		return nil, err
+	}
 
-	fmt.Println("Cost factor: ", costFactor)
+	err = putSLA(ctx, sla)
+	if err != nil {
+		return nil, err
+	}
 
-	// Base cost (adjust as needed)
-	var baseCost float32
-	switch serviceLevel {
-	case "standard":
-		baseCost = 50
-	case "gold":
-		baseCost = 100
-	case "platinum":
-		baseCost = 200
+	return sla, nil
+}
 
-	default:
-		return 0, fmt.Errorf("invalid service level: %s", serviceLevel)
+// GetPriceSchedule returns the price of the SLA for every month of the year, used to invoice the customer
+func (s *SmartContract) GetPriceSchedule(ctx contractapi.TransactionContextInterface, id string, year int) (*PriceSchedule, error) {
+	sla, err := s.ReadSLA(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	// Calculate final cost
-	monthlyCost := baseCost * (costFactor + 1)
+	plugin, err := getServicePlugin(sla.ServiceType)
+	if err != nil {
+		return nil, err
+	}
+	return priceSchedule(plugin, sla, year)
+}
 
-	fmt.Println("Monthly cost: ", monthlyCost)
+// GetServiceSchemas returns the parameter schema of every service type that can be sold
+func (s *SmartContract) GetServiceSchemas(ctx contractapi.TransactionContextInterface) ([]ServiceSchema, error) {
+	var schemas []ServiceSchema
+	for _, serviceType := range serviceTypes() {
+		schemas = append(schemas, servicePlugins[serviceType].Schema())
+	}
+	return schemas, nil
+}
 
-	fmt.Println("Monthly cost integer: ", int(monthlyCost))
+// ReadSLA returns the SLA stored in the world state with given id, provided the caller may access it.
+func (s *SmartContract) ReadSLA(ctx contractapi.TransactionContextInterface, id string) (*SLA, error) {
+	asset, err := getSLA(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	return int(monthlyCost), nil
+	err = access.AuthorizeCustomer(ctx.GetClientIdentity(), asset.CustomerID, asset.Owner)
+	if err != nil {
+		return nil, err
+	}
 
+	fmt.Println("SLA: ", asset)
+	return asset, nil
 }
 
-// ReadAsset returns the asset stored in the world state with given id.
-func (s *SmartContract) ReadSLA(ctx contractapi.TransactionContextInterface, id string) (*SLA, error) {
+// getSLA reads the SLA with given id from the world state without checking who the caller is
+func getSLA(ctx contractapi.TransactionContextInterface, id string) (*SLA, error) {
 	assetJSON, err := ctx.GetStub().GetState(id)
 	if err != nil {
 		fmt.Println("Error getting world state")
@@ -183,47 +263,71 @@ func (s *SmartContract) ReadSLA(ctx contractapi.TransactionContextInterface, id
 		fmt.Println("error unmarshalling SLA")
 		return nil, err
 	}
+	upgradeLegacySLA(&asset)
 
-	fmt.Println("SLA: ", asset)
 	return &asset, nil
 }
 
-// UpdateAsset updates an existing asset in the world state with provided parameters.
-func (s *SmartContract) UpdateTargetGrassLength(ctx contractapi.TransactionContextInterface, id string, targetgrasslength float32) (*SLA, error) {
-	exists, err := s.SLAExists(ctx, id)
+// UpdateTargetGrassLength updates the target grass length of a mowing SLA and evaluates it again.
+func (s *SmartContract) UpdateTargetGrassLength(ctx contractapi.TransactionContextInterface, id string, targetgrasslength string) (*SLA, error) {
+	target, err := decimal.Parse(targetgrasslength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target grass length: %v", err)
+	}
+
+	sla, err := s.readMowingSLA(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if !exists {
-		return nil, fmt.Errorf("the asset %s does not exist", id)
+
+	sla.Parameters[targetGrassLengthParameter] = target
+
+	err = appraiseSLA(sla)
+	if err != nil {
+		return nil, err
 	}
 
-	// overwriting original asset with new asset
-	sla, readSLAerror := s.ReadSLA(ctx, id)
+	err = putSLA(ctx, sla)
 	if err != nil {
-		return nil, readSLAerror
+		return nil, err
 	}
+	return sla, nil
+}
 
-	sla.TargetGrassLength = targetgrasslength
+// UpdateGrassLengthInterval updates the allowed grass lengths of a mowing SLA and evaluates it again.
+func (s *SmartContract) UpdateGrassLengthInterval(ctx contractapi.TransactionContextInterface, id string, maxgrasslength string, mingrasslength string) (*SLA, error) {
+	maxLength, err := decimal.Parse(maxgrasslength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max grass length: %v", err)
+	}
+	minLength, err := decimal.Parse(mingrasslength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid min grass length: %v", err)
+	}
 
-	newValue, err := s.EvaluateSLA(ctx, sla.ServiceLevel, sla.TargetGrassLength, sla.MaxGrassLength, sla.MinGrassLength)
+	sla, err := s.readMowingSLA(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	sla.AppraisedValue = newValue
-	assetJSON, err := json.Marshal(sla)
+	sla.Parameters[maxGrassLengthParameter] = maxLength
+	sla.Parameters[minGrassLengthParameter] = minLength
+
+	err = appraiseSLA(sla)
 	if err != nil {
 		return nil, err
 	}
-	err = ctx.GetStub().PutState(id, assetJSON)
+
+	err = putSLA(ctx, sla)
 	if err != nil {
-		return nil, fmt.Errorf("failed to put to world state. %v", err)
+		return nil, err
 	}
+
 	return sla, nil
 }
 
-func (s *SmartContract) UpdateGrassLengthInterval(ctx contractapi.TransactionContextInterface, id string, maxgrasslength float32, mingrasslength float32) (*SLA, error) {
+// UpdateParameters replaces the parameter set of an SLA of any service type and evaluates it again.
+func (s *SmartContract) UpdateParameters(ctx contractapi.TransactionContextInterface, id string, parameters Parameters) (*SLA, error) {
 	exists, err := s.SLAExists(ctx, id)
 	if err != nil {
 		return nil, err
@@ -232,28 +336,73 @@ func (s *SmartContract) UpdateGrassLengthInterval(ctx contractapi.TransactionCon
 		return nil, fmt.Errorf("the asset %s does not exist", id)
 	}
 
-	// overwriting original asset with new asset
-	sla, readSLAerror := s.ReadSLA(ctx, id)
+	sla, _, err := readOwnedSLA(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sla.Parameters = parameters
+
+	err = appraiseSLA(sla)
+	if err != nil {
+		return nil, err
+	}
+
+	err = putSLA(ctx, sla)
+	if err != nil {
+		return nil, err
+	}
+
+	return sla, nil
+}
+
+// UpdateSLA changes the service level and parameters of an SLA at once and evaluates it again.
+// An empty service level keeps the current one and only the given parameters are replaced, the others are kept.
+func (s *SmartContract) UpdateSLA(ctx contractapi.TransactionContextInterface, id string, serviceLevel string, parameters Parameters) (*SLA, error) {
+	sla, _, err := readOwnedSLA(ctx, id)
 	if err != nil {
-		return nil, readSLAerror
+		return nil, err
 	}
 
-	sla.MaxGrassLength = maxgrasslength
-	sla.MinGrassLength = mingrasslength
+	if serviceLevel != "" {
+		sla.ServiceLevel = serviceLevel
+	}
+	if sla.Parameters == nil {
+		sla.Parameters = Parameters{}
+	}
+	for name, value := range parameters {
+		sla.Parameters[name] = value
+	}
 
-	newValue, err := s.EvaluateSLA(ctx, sla.ServiceLevel, sla.TargetGrassLength, sla.MaxGrassLength, sla.MinGrassLength)
+	err = appraiseSLA(sla)
 	if err != nil {
 		return nil, err
 	}
 
-	sla.AppraisedValue = newValue
-	assetJSON, err := json.Marshal(sla)
+	err = putSLA(ctx, sla)
+	if err != nil {
+		return nil, err
+	}
+
+	return sla, nil
+}
+
+// AssignProperty attaches the SLA to another property of the customer, which must belong to the customer
+func (s *SmartContract) AssignProperty(ctx contractapi.TransactionContextInterface, id string, propertyID string) (*SLA, error) {
+	sla, stored, err := readOwnedSLA(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	err = checkProperty(stored, propertyID)
 	if err != nil {
 		return nil, err
 	}
-	err = ctx.GetStub().PutState(id, assetJSON)
+
+	sla.PropertyID = propertyID
+
+	err = putSLA(ctx, sla)
 	if err != nil {
-		return nil, fmt.Errorf("failed to put to world state. %v", err)
+		return nil, err
 	}
 
 	return sla, nil
@@ -269,6 +418,21 @@ func (s *SmartContract) DeleteSLA(ctx contractapi.TransactionContextInterface, i
 		return fmt.Errorf("the asset %s does not exist", id)
 	}
 
+	// reading the SLA checks that the caller owns it
+	sla, _, err := readOwnedSLA(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	indexKey, err := ctx.GetStub().CreateCompositeKey(customerSLAIndex, []string{sla.CustomerID, sla.ID})
+	if err != nil {
+		return err
+	}
+	err = ctx.GetStub().DelState(indexKey)
+	if err != nil {
+		return fmt.Errorf("failed to delete from world state. %v", err)
+	}
+
 	return ctx.GetStub().DelState(id)
 }
 
@@ -282,8 +446,16 @@ func (s *SmartContract) SLAExists(ctx contractapi.TransactionContextInterface, i
 	return slaJSON != nil, nil
 }
 
-// GetAllAssets returns all assets found in world state
+// GetAllSLA returns all SLAs found in world state. Only the service owner may call it.
 func (s *SmartContract) GetAllSLA(ctx contractapi.TransactionContextInterface) ([]*SLA, error) {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return nil, err
+	}
+	if !serviceOwner {
+		return nil, fmt.Errorf("only the service owner can read all SLAs")
+	}
+
 	// range query with empty string for startKey and endKey does an
 	// open-ended query of all assets in the chaincode namespace.
 	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
@@ -304,8 +476,161 @@ func (s *SmartContract) GetAllSLA(ctx contractapi.TransactionContextInterface) (
 		if err != nil {
 			return nil, err
 		}
+		upgradeLegacySLA(&asset)
 		slas = append(slas, &asset)
 	}
 
 	return slas, nil
 }
+
+// GetSLAsByCustomer returns every SLA of the customer found in world state, provided the caller may access the customer.
+// The SLAs are read through the customer~sla index, ordered by ID.
+func (s *SmartContract) GetSLAsByCustomer(ctx contractapi.TransactionContextInterface, customerID string) ([]*SLA, error) {
+	err := authorizeCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(customerSLAIndex, []string{customerID})
+	if err != nil {
+		return nil, err
+	}
+	defer resultsIterator.Close()
+
+	slas := []*SLA{}
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, keyParts, err := ctx.GetStub().SplitCompositeKey(queryResponse.Key)
+		if err != nil {
+			return nil, err
+		}
+		sla, err := getSLA(ctx, keyParts[1])
+		if err != nil {
+			return nil, err
+		}
+		slas = append(slas, sla)
+	}
+
+	return slas, nil
+}
+
+// IndexSLAs adds the SLAs stored before the customer~sla index existed to it and returns how many SLAs were indexed.
+// Only the service owner may call it, once after the chaincode has been upgraded.
+func (s *SmartContract) IndexSLAs(ctx contractapi.TransactionContextInterface) (int, error) {
+	serviceOwner, err := access.IsServiceOwner(ctx.GetClientIdentity())
+	if err != nil {
+		return 0, err
+	}
+	if !serviceOwner {
+		return 0, fmt.Errorf("only the service owner can index the SLAs")
+	}
+
+	// the range query skips composite keys, so it only returns the SLAs themselves
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		return 0, err
+	}
+	defer resultsIterator.Close()
+
+	indexed := 0
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return 0, err
+		}
+
+		var asset SLA
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			return 0, err
+		}
+		err = putSLAIndex(ctx, &asset)
+		if err != nil {
+			return 0, err
+		}
+		indexed++
+	}
+
+	return indexed, nil
+}
+
+// readMowingSLA reads an SLA and checks that it is a mowing SLA, since only those have grass lengths
+func (s *SmartContract) readMowingSLA(ctx contractapi.TransactionContextInterface, id string) (*SLA, error) {
+	exists, err := s.SLAExists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("the asset %s does not exist", id)
+	}
+
+	sla, _, err := readOwnedSLA(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sla.ServiceType != mowingServiceType {
+		return nil, fmt.Errorf("the SLA %s is a %s SLA and has no grass lengths", id, sla.ServiceType)
+	}
+
+	return sla, nil
+}
+
+// appraiseSLA validates the parameters and seasons of the SLA against its service type and sets the monthly cost outside of any season
+func appraiseSLA(sla *SLA) error {
+	plugin, err := getServicePlugin(sla.ServiceType)
+	if err != nil {
+		return err
+	}
+
+	err = plugin.Validate(sla.Parameters)
+	if err != nil {
+		return err
+	}
+
+	err = validateSeasons(plugin, sla)
+	if err != nil {
+		return err
+	}
+
+	value, err := evaluate(plugin, sla.ServiceLevel, sla.Parameters)
+	if err != nil {
+		return err
+	}
+
+	sla.AppraisedValue = decimal.NewMoney(value, decimal.DefaultCurrency)
+	return nil
+}
+
+// putSLA writes the SLA to the world state and indexes it under its customer
+func putSLA(ctx contractapi.TransactionContextInterface, sla *SLA) error {
+	slaJSON, err := json.Marshal(sla)
+	if err != nil {
+		fmt.Println("Error marshalling SLA: ")
+		return err
+	}
+
+	err = ctx.GetStub().PutState(sla.ID, slaJSON)
+	if err != nil {
+		return fmt.Errorf("failed to put to world state. %v", err)
+	}
+	return putSLAIndex(ctx, sla)
+}
+
+// putSLAIndex indexes the SLA under its customer, so that the SLAs of a customer can be read without reading every SLA
+func putSLAIndex(ctx contractapi.TransactionContextInterface, sla *SLA) error {
+	indexKey, err := ctx.GetStub().CreateCompositeKey(customerSLAIndex, []string{sla.CustomerID, sla.ID})
+	if err != nil {
+		return err
+	}
+
+	// the index key holds everything, the value only has to be non-empty for the entry to be stored
+	err = ctx.GetStub().PutState(indexKey, []byte{0x00})
+	if err != nil {
+		return fmt.Errorf("failed to put to world state. %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package mower
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/nalle631/fabric-network/chaincode/shared/access"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+)
+
+const mowingParametersJSON = `{"TargetGrassLength":"5","MaxGrassLength":"7","MinGrassLength":"3"}`
+
+// customerChaincodeStub stands in for the customer chaincode. ReadCustomer returns the customers to the identities
+// that may access them, ReadMowerProperty returns their properties to the mowers assigned to them and Relay sends its
+// arguments on to the mower chaincode, like the SLA transactions of the customer chaincode do after they have checked
+// the caller.
+type customerChaincodeStub struct {
+	customers map[string]customer
+}
+
+func (stub customerChaincodeStub) Init(shim.ChaincodeStubInterface) peer.Response {
+	return shim.Success(nil)
+}
+
+func (stub customerChaincodeStub) Invoke(chaincodeStub shim.ChaincodeStubInterface) peer.Response {
+	args := chaincodeStub.GetArgs()
+	switch string(args[0]) {
+	case "ReadCustomer":
+		stored, ok := stub.customers[string(args[1])]
+		if !ok {
+			return shim.Error("the asset " + string(args[1]) + " does not exist")
+		}
+		identity, err := cid.New(chaincodeStub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		err = access.AuthorizeCustomer(identity, stored.ID, stored.Owner)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		customerJSON, err := json.Marshal(stored)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(customerJSON)
+	case "ReadMowerProperty":
+		stored, ok := stub.customers[string(args[1])]
+		if !ok {
+			return shim.Error("the asset " + string(args[1]) + " does not exist")
+		}
+		identity, err := cid.New(chaincodeStub)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		serviceOwner, err := access.IsServiceOwner(identity)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		mowerID, _, err := access.CallerMowerID(identity)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		for _, property := range stored.Properties {
+			if property.ID == string(args[2]) && (serviceOwner || property.Mower == mowerID) {
+				propertyJSON, err := json.Marshal(property)
+				if err != nil {
+					return shim.Error(err.Error())
+				}
+				return shim.Success(propertyJSON)
+			}
+		}
+		return shim.Error("the caller is not the mower of property " + string(args[2]))
+	case "Relay":
+		return chaincodeStub.InvokeChaincode("mower", args[1:], "")
+	}
+	return shim.Error("unknown function " + string(args[0]))
+}
+
+// slaTest is a customer channel with a customer brf-1 owned by alice, who has a property with mower-1
+type slaTest struct {
+	network      *chaincodetest.Network
+	mower        *chaincodetest.Stub
+	alice        *chaincodetest.Identity
+	mallory      *chaincodetest.Identity
+	serviceOwner *chaincodetest.Identity
+}
+
+func newSLATest(t *testing.T) *slaTest {
+	t.Helper()
+
+	test := &slaTest{
+		network:      chaincodetest.NewNetwork("customer"),
+		alice:        chaincodetest.NewIdentity(t, "Org1MSP", "alice", nil),
+		mallory:      chaincodetest.NewIdentity(t, "Org1MSP", "mallory", nil),
+		serviceOwner: chaincodetest.NewIdentity(t, "Org1MSP", "service-owner", map[string]string{access.RoleAttribute: access.ServiceOwnerRole}),
+	}
+
+	mowerChaincode, err := contractapi.NewChaincode(&SmartContract{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.mower = test.network.Deploy("mower", mowerChaincode)
+	test.network.Deploy(customerChaincode, customerChaincodeStub{customers: map[string]customer{
+		"brf-1": {ID: "brf-1", Owner: test.alice.ID(), Properties: []property{{ID: "garden", Address: "Storgatan 1", Mower: "mower-1"}}},
+		"brf-2": {ID: "brf-2", Owner: test.mallory.ID()},
+	}})
+	return test
+}
+
+// createSLA creates a mowing SLA for the customer directly in the mower chaincode as the identity
+func (test *slaTest) createSLA(identity *chaincodetest.Identity, id string, customerID string, owner string) error {
+	_, err := test.network.Submit(identity, "mower", "CreateServiceSLA", id, customerID, owner, "", mowingServiceType, "gold", mowingParametersJSON)
+	return err
+}
+
+func TestCreateServiceSLARejectsAForgedOwner(t *testing.T) {
+	test := newSLATest(t)
+
+	if err := test.createSLA(test.mallory, "sla-1", "brf-1", test.mallory.ID()); err == nil {
+		t.Error("mallory created an SLA of alice's customer by naming herself the owner")
+	}
+	if err := test.createSLA(test.alice, "sla-1", "brf-1", test.mallory.ID()); err == nil {
+		t.Error("alice created an SLA of her customer owned by mallory")
+	}
+	if err := test.createSLA(test.alice, "sla-1", "brf-2", test.alice.ID()); err == nil {
+		t.Error("alice created an SLA of mallory's customer")
+	}
+
+	if err := test.createSLA(test.alice, "sla-1", "brf-1", test.alice.ID()); err != nil {
+		t.Fatalf("alice could not create an SLA of her customer: %v", err)
+	}
+	slaJSON, err := test.network.Submit(test.serviceOwner, "mower", "ReadSLA", "sla-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sla SLA
+	err = json.Unmarshal(slaJSON, &sla)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sla.CustomerID != "brf-1" || sla.Owner != test.alice.ID() {
+		t.Errorf("the SLA of alice is %+v", sla)
+	}
+
+	// the customer chaincode relays SLAs with the stored owner after it has checked the caller
+	_, err = test.network.Submit(test.serviceOwner, customerChaincode, "Relay", "CreateServiceSLA", "sla-2", "brf-1", test.alice.ID(), "", mowingServiceType, "gold", mowingParametersJSON)
+	if err != nil {
+		t.Errorf("a relayed SLA was not created: %v", err)
+	}
+}
+
+func TestChangesOfAnSLANeedItsCustomer(t *testing.T) {
+	test := newSLATest(t)
+	if err := test.createSLA(test.alice, "sla-1", "brf-1", test.alice.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := [][]string{
+		{"UpdateSLA", "sla-1", "platinum", "{}"},
+		{"UpdateParameters", "sla-1", mowingParametersJSON},
+		{"SetSeasons", "sla-1", `[{"Name":"winter","Start":"11-01","End":"03-31","Paused":true}]`},
+		{"ChangeServiceLevel", "sla-1", "standard"},
+		{"UpdateTargetGrassLength", "sla-1", "6"},
+		{"AssignProperty", "sla-1", "garden"},
+		{"DeleteSLA", "sla-1"},
+	}
+	for _, change := range changes {
+		if _, err := test.network.Submit(test.mallory, "mower", change[0], change[1:]...); err == nil {
+			t.Errorf("mallory could call %s on alice's SLA", change[0])
+		}
+	}
+	for _, change := range changes {
+		if _, err := test.network.Submit(test.alice, "mower", change[0], change[1:]...); err != nil {
+			t.Errorf("alice could not call %s on her SLA: %v", change[0], err)
+		}
+	}
+}
+
+func TestGetSLAsByCustomer(t *testing.T) {
+	test := newSLATest(t)
+	for _, id := range []string{"sla-2", "sla-1"} {
+		if err := test.createSLA(test.alice, id, "brf-1", test.alice.ID()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := test.createSLA(test.mallory, "sla-3", "brf-2", test.mallory.ID()); err != nil {
+		t.Fatal(err)
+	}
+
+	// an SLA stored before the customer~sla index existed is only found once it has been indexed
+	test.mower.MockTransactionStart("legacy")
+	err := test.mower.PutState("sla-0", []byte(`{"ID":"sla-0","CustomerID":"brf-1","ServiceType":"mowing","ServiceLevel":"gold","Parameters":{}}`))
+	test.mower.MockTransactionEnd("legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slaIDs := func() []string {
+		t.Helper()
+		slasJSON, err := test.network.Submit(test.alice, "mower", "GetSLAsByCustomer", "brf-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var slas []SLA
+		err = json.Unmarshal(slasJSON, &slas)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{}
+		for _, sla := range slas {
+			ids = append(ids, sla.ID)
+		}
+		return ids
+	}
+
+	if ids := fmt.Sprint(slaIDs()); ids != "[sla-1 sla-2]" {
+		t.Errorf("the SLAs of brf-1 are %s", ids)
+	}
+	if _, err := test.network.Submit(test.mallory, "mower", "GetSLAsByCustomer", "brf-1"); err == nil {
+		t.Error("mallory read the SLAs of alice's customer")
+	}
+
+	if _, err := test.network.Submit(test.alice, "mower", "IndexSLAs"); err == nil {
+		t.Error("alice indexed the SLAs")
+	}
+	if _, err := test.network.Submit(test.serviceOwner, "mower", "IndexSLAs"); err != nil {
+		t.Fatal(err)
+	}
+	if ids := fmt.Sprint(slaIDs()); ids != "[sla-0 sla-1 sla-2]" {
+		t.Errorf("the SLAs of brf-1 after indexing are %s", ids)
+	}
+
+	if _, err := test.network.Submit(test.alice, "mower", "DeleteSLA", "sla-2"); err != nil {
+		t.Fatal(err)
+	}
+	if ids := fmt.Sprint(slaIDs()); ids != "[sla-0 sla-1]" {
+		t.Errorf("the SLAs of brf-1 after deleting sla-2 are %s", ids)
+	}
+}
+
+func TestPropertiesOfAnSLABelongToItsCustomer(t *testing.T) {
+	test := newSLATest(t)
+
+	_, err := test.network.Submit(test.alice, "mower", "CreateServiceSLA", "sla-1", "brf-1", test.alice.ID(), "cellar", mowingServiceType, "gold", mowingParametersJSON)
+	if err == nil {
+		t.Error("alice created an SLA for a property her customer does not have")
+	}
+	if err := test.createSLA(test.alice, "sla-1", "brf-1", test.alice.ID()); err != nil {
+		t.Fatal(err)
+	}
+	for _, propertyID := range []string{"cellar", ""} {
+		if _, err := test.network.Submit(test.alice, "mower", "AssignProperty", "sla-1", propertyID); err == nil {
+			t.Errorf("alice attached her SLA to the property %q her customer does not have", propertyID)
+		}
+	}
+	if _, err := test.network.Submit(test.alice, "mower", "AssignProperty", "sla-1", "garden"); err != nil {
+		t.Errorf("alice could not attach her SLA to her property: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+// Package access holds the access rules the chaincodes share. Identities are enrolled with CA attributes that give
+// them a role, such as the service owner or a mower, or bind them to a customer, and a customer that is not bound
+// through an attribute is owned by the X.509 ID of the identity that created it.
+package access
+
+import (
+	"fmt"
+)
+
+const (
+	// RoleAttribute is the CA attribute that carries the role of an enrolled identity
+	RoleAttribute = "role"
+	// CustomerIDAttribute is the CA attribute that binds an enrolled identity to a customer ID
+	CustomerIDAttribute = "customerId"
+	// MowerIDAttribute is the CA attribute that names the mower an identity with the mower role is enrolled for
+	MowerIDAttribute = "mowerId"
+
+	// ServiceOwnerRole is the role value given to the service owner, who may access every customer and publishes job offers
+	ServiceOwnerRole = "serviceowner"
+	// MowerRole is the role value given to the mowers, which report the state of the lawns they look after
+	MowerRole = "mower"
+)
+
+// Identity is the part of the client identity of a transaction the rules are checked against.
+// The client identity of the transaction context of a chaincode implements it.
+type Identity interface {
+	GetID() (string, error)
+	GetAttributeValue(attrName string) (value string, found bool, err error)
+}
+
+// IsServiceOwner returns true when the caller has been enrolled with the service owner role
+func IsServiceOwner(identity Identity) (bool, error) {
+	return HasRole(identity, ServiceOwnerRole)
+}
+
+// HasRole returns true when the caller has been enrolled with the given role
+func HasRole(identity Identity, expectedRole string) (bool, error) {
+	role, found, err := identity.GetAttributeValue(RoleAttribute)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s attribute: %v", RoleAttribute, err)
+	}
+
+	return found && role == expectedRole, nil
+}
+
+// CallerCustomerID returns the customer ID the caller has been bound to through the customerId CA attribute
+func CallerCustomerID(identity Identity) (string, bool, error) {
+	customerID, found, err := identity.GetAttributeValue(CustomerIDAttribute)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s attribute: %v", CustomerIDAttribute, err)
+	}
+
+	return customerID, found && customerID != "", nil
+}
+
+// CallerMowerID returns the ID of the mower the caller has been enrolled for, provided it has the mower role
+func CallerMowerID(identity Identity) (string, bool, error) {
+	mower, err := HasRole(identity, MowerRole)
+	if err != nil || !mower {
+		return "", false, err
+	}
+
+	mowerID, found, err := identity.GetAttributeValue(MowerIDAttribute)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s attribute: %v", MowerIDAttribute, err)
+	}
+
+	return mowerID, found && mowerID != "", nil
+}
+
+// AuthorizeCustomer returns an error unless the caller is the service owner or the identity the customer with given ID
+// and owner is bound to, either by the customerId CA attribute or by the X.509 ID of the identity that created it.
+// The ID and owner must be read from the stored customer, never taken from the arguments of a transaction.
+func AuthorizeCustomer(identity Identity, customerID string, owner string) error {
+	serviceOwner, err := IsServiceOwner(identity)
+	if err != nil {
+		return err
+	}
+	if serviceOwner {
+		return nil
+	}
+
+	boundID, found, err := CallerCustomerID(identity)
+	if err != nil {
+		return err
+	}
+	if found && customerID != "" && boundID == customerID {
+		return nil
+	}
+
+	clientID, err := identity.GetID()
+	if err != nil {
+		return fmt.Errorf("failed to get client identity: %v", err)
+	}
+	if owner != "" && clientID == owner {
+		return nil
+	}
+
+	return fmt.Errorf("the caller is not allowed to access customer %s", customerID)
+}
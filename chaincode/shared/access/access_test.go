@@ -0,0 +1,110 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/nalle631/fabric-network/chaincode/shared/chaincodetest"
+)
+
+// identity is a client identity with the given X.509 ID and CA attributes
+type identity struct {
+	id    string
+	attrs map[string]string
+}
+
+func (identity identity) GetID() (string, error) {
+	return identity.id, nil
+}
+
+func (identity identity) GetAttributeValue(attrName string) (string, bool, error) {
+	value, found := identity.attrs[attrName]
+	return value, found, nil
+}
+
+func TestAuthorizeCustomer(t *testing.T) {
+	serviceOwner := identity{id: "owner", attrs: map[string]string{RoleAttribute: ServiceOwnerRole}}
+	bound := identity{id: "hsb", attrs: map[string]string{CustomerIDAttribute: "brf-1"}}
+	creator := identity{id: "alice"}
+	mower := identity{id: "mower-1", attrs: map[string]string{RoleAttribute: MowerRole}}
+
+	for _, test := range []struct {
+		name       string
+		identity   identity
+		customerID string
+		owner      string
+		allowed    bool
+	}{
+		{"service owner", serviceOwner, "brf-1", "", true},
+		{"bound identity", bound, "brf-1", "", true},
+		{"identity bound to another customer", bound, "brf-2", "", false},
+		{"owner", creator, "alice", "alice", true},
+		{"another identity", creator, "bob", "bob", false},
+		{"customer without an owner", creator, "brf-1", "", false},
+		{"mower", mower, "brf-1", "", false},
+	} {
+		err := AuthorizeCustomer(test.identity, test.customerID, test.owner)
+		if (err == nil) != test.allowed {
+			t.Errorf("%s: AuthorizeCustomer(%s, %q) = %v", test.name, test.customerID, test.owner, err)
+		}
+	}
+}
+
+func TestCallerMowerID(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		identity identity
+		mowerID  string
+		found    bool
+	}{
+		{"mower", identity{attrs: map[string]string{RoleAttribute: MowerRole, MowerIDAttribute: "mower-1"}}, "mower-1", true},
+		{"mower without an ID", identity{attrs: map[string]string{RoleAttribute: MowerRole}}, "", false},
+		{"mower ID without the mower role", identity{attrs: map[string]string{MowerIDAttribute: "mower-1"}}, "", false},
+	} {
+		mowerID, found, err := CallerMowerID(test.identity)
+		if err != nil || mowerID != test.mowerID || found != test.found {
+			t.Errorf("%s: CallerMowerID() = %q, %v, %v", test.name, mowerID, found, err)
+		}
+	}
+}
+
+// relay is a chaincode that returns the chaincode the transaction was sent to, or asks the chaincode named by its
+// argument to do so
+type relay struct{}
+
+func (relay) Init(stub shim.ChaincodeStubInterface) peer.Response {
+	return shim.Success(nil)
+}
+
+func (relay) Invoke(stub shim.ChaincodeStubInterface) peer.Response {
+	function, args := stub.GetFunctionAndParameters()
+	if function == "Relay" {
+		return stub.InvokeChaincode(args[0], [][]byte{[]byte("Invoked")}, "")
+	}
+
+	invoked, err := InvokedChaincode(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success([]byte(invoked))
+}
+
+func TestInvokedChaincode(t *testing.T) {
+	network := chaincodetest.NewNetwork("customer")
+	network.Deploy("customer", relay{})
+	network.Deploy("mower", relay{})
+	client := chaincodetest.NewIdentity(t, "Org1MSP", "alice", nil)
+
+	invoked, err := network.Submit(client, "mower", "Invoked")
+	if err != nil || string(invoked) != "mower" {
+		t.Errorf("a direct call was sent to %q, %v", invoked, err)
+	}
+	invoked, err = network.Submit(client, "customer", "Relay", "mower")
+	if err != nil || string(invoked) != "customer" {
+		t.Errorf("a relayed call was sent to %q, %v", invoked, err)
+	}
+	if _, err := network.Submit(client, "customer", "Relay", "customer"); err == nil {
+		t.Error("a chaincode was called again while it runs")
+	}
+}
@@ -0,0 +1,64 @@
+package access
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// ProposalStub is the part of the chaincode stub the proposal of a transaction is read from
+type ProposalStub interface {
+	GetSignedProposal() (*peer.SignedProposal, error)
+}
+
+// InvokedChaincode returns the name of the chaincode the client sent the proposal of the transaction to.
+// A chaincode called by another chaincode runs in the transaction of the calling chaincode, so it sees the name of the
+// chaincode that relays the call, while a chaincode the client calls directly sees its own name. The peer runs the
+// chaincode the signed proposal names, so a client cannot pretend that its call was relayed.
+func InvokedChaincode(stub ProposalStub) (string, error) {
+	signedProposal, err := stub.GetSignedProposal()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the signed proposal: %v", err)
+	}
+	if signedProposal == nil {
+		return "", fmt.Errorf("the transaction has no signed proposal")
+	}
+
+	var proposal peer.Proposal
+	err = proto.Unmarshal(signedProposal.ProposalBytes, &proposal)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal the proposal: %v", err)
+	}
+	var header common.Header
+	err = proto.Unmarshal(proposal.Header, &header)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal the proposal header: %v", err)
+	}
+	var channelHeader common.ChannelHeader
+	err = proto.Unmarshal(header.ChannelHeader, &channelHeader)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal the channel header: %v", err)
+	}
+	var extension peer.ChaincodeHeaderExtension
+	err = proto.Unmarshal(channelHeader.Extension, &extension)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal the chaincode header extension: %v", err)
+	}
+	if extension.ChaincodeId == nil || extension.ChaincodeId.Name == "" {
+		return "", fmt.Errorf("the proposal names no chaincode")
+	}
+
+	return extension.ChaincodeId.Name, nil
+}
+
+// RelayedBy returns true when the transaction was sent to the chaincode with given name, which relays it to the
+// chaincode that calls RelayedBy. That chaincode has then checked the caller, and its arguments can be trusted.
+func RelayedBy(stub ProposalStub, chaincode string) (bool, error) {
+	invoked, err := InvokedChaincode(stub)
+	if err != nil {
+		return false, err
+	}
+	return invoked == chaincode, nil
+}
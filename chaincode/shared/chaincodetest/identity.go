@@ -0,0 +1,79 @@
+// Package chaincodetest runs chaincodes in memory for their tests. A Network deploys chaincodes that can call each
+// other and invokes them as identities enrolled with CA attributes, with the signed proposal a client would send,
+// so the access rules of the chaincodes are checked the way a peer checks them.
+package chaincodetest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+// attributesOID is the X.509 extension the Fabric CA puts the attributes of an enrolled identity in
+var attributesOID = []int{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+// Identity is an identity of an MSP, enrolled with CA attributes such as a role
+type Identity struct {
+	creator []byte
+	id      string
+}
+
+// NewIdentity enrolls an identity with the common name and attributes in the MSP
+func NewIdentity(t testing.TB, mspID string, name string, attrs map[string]string) *Identity {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attrsJSON, err := json.Marshal(map[string]map[string]string{"attrs": attrs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		Subject:         pkix.Name{CommonName: name, Organization: []string{mspID}},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: attributesOID, Value: attrsJSON}},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	creator, err := proto.Marshal(&msp.SerializedIdentity{
+		Mspid:   mspID,
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the ID is read the way the chaincodes read it
+	stub := shimtest.NewMockStub(name, nil)
+	stub.Creator = creator
+	id, err := cid.GetID(stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Identity{creator: creator, id: id}
+}
+
+// ID returns the X.509 ID the chaincodes see for the identity
+func (identity *Identity) ID() string {
+	return identity.id
+}
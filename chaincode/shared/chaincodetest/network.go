@@ -0,0 +1,260 @@
+package chaincodetest
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Network is a channel with chaincodes deployed on it. Chaincodes call each other within the transaction of the
+// chaincode the client invoked, with the same signed proposal and creator, and a chaincode cannot be called again
+// while it runs, like on a peer. The writes of a transaction that fails are discarded.
+type Network struct {
+	// Time is the timestamp of the transactions, the current time when zero
+	Time time.Time
+
+	channel string
+	stubs   map[string]*Stub
+	txs     int
+
+	// the transaction that runs
+	creator  []byte
+	proposal *peer.SignedProposal
+}
+
+// Stub is the stub of a chaincode deployed on a network. Its world state can be read in tests.
+type Stub struct {
+	*shimtest.MockStub
+
+	network   *Network
+	chaincode shim.Chaincode
+	args      [][]byte
+	running   bool
+}
+
+// NewNetwork creates a channel without chaincodes
+func NewNetwork(channel string) *Network {
+	return &Network{channel: channel, stubs: make(map[string]*Stub)}
+}
+
+// Deploy deploys the chaincode on the channel with the name
+func (network *Network) Deploy(name string, chaincode shim.Chaincode) *Stub {
+	stub := &Stub{MockStub: shimtest.NewMockStub(name, chaincode), network: network, chaincode: chaincode}
+	stub.ChannelID = network.channel
+	network.stubs[name] = stub
+	return stub
+}
+
+// Invoke sends a proposal to call the function of the chaincode with the arguments as the identity and returns the response
+func (network *Network) Invoke(identity *Identity, chaincode string, function string, args ...string) peer.Response {
+	stub, ok := network.stubs[chaincode]
+	if !ok {
+		return shim.Error(fmt.Sprintf("chaincode %s is not deployed", chaincode))
+	}
+
+	proposal, err := signedProposal(network.channel, chaincode)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	network.txs++
+	network.creator = identity.creator
+	network.proposal = proposal
+	timestamp := network.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	states := make(map[*Stub]map[string][]byte)
+	for _, deployed := range network.stubs {
+		states[deployed] = copyState(deployed.State)
+	}
+
+	invokeArgs := [][]byte{[]byte(function)}
+	for _, arg := range args {
+		invokeArgs = append(invokeArgs, []byte(arg))
+	}
+	response := stub.invoke(fmt.Sprintf("tx%d", network.txs), timestamppb.New(timestamp), invokeArgs)
+
+	if response.Status >= shim.ERRORTHRESHOLD {
+		for deployed, state := range states {
+			deployed.restore(state)
+		}
+	}
+	return response
+}
+
+// Submit invokes the function of the chaincode as the identity and returns the payload of the response, or its
+// message as an error when the transaction fails
+func (network *Network) Submit(identity *Identity, chaincode string, function string, args ...string) ([]byte, error) {
+	response := network.Invoke(identity, chaincode, function, args...)
+	if response.Status >= shim.ERRORTHRESHOLD {
+		return nil, fmt.Errorf("%s", response.Message)
+	}
+	return response.Payload, nil
+}
+
+// invoke runs the chaincode in the transaction
+func (stub *Stub) invoke(txID string, timestamp *timestamppb.Timestamp, args [][]byte) peer.Response {
+	if stub.running {
+		return shim.Error(fmt.Sprintf("chaincode %s is already running in transaction %s", stub.Name, txID))
+	}
+
+	stub.running = true
+	stub.args = args
+	stub.TxID = txID
+	stub.TxTimestamp = timestamp
+	response := stub.chaincode.Invoke(stub)
+	stub.running = false
+	stub.TxID = ""
+	return response
+}
+
+// GetArgs returns the arguments of the call
+func (stub *Stub) GetArgs() [][]byte {
+	return stub.args
+}
+
+// GetStringArgs returns the arguments of the call as strings
+func (stub *Stub) GetStringArgs() []string {
+	args := make([]string, 0, len(stub.args))
+	for _, arg := range stub.args {
+		args = append(args, string(arg))
+	}
+	return args
+}
+
+// GetFunctionAndParameters returns the function of the call and its arguments
+func (stub *Stub) GetFunctionAndParameters() (string, []string) {
+	args := stub.GetStringArgs()
+	if len(args) == 0 {
+		return "", []string{}
+	}
+	return args[0], args[1:]
+}
+
+// GetCreator returns the identity the transaction is invoked as
+func (stub *Stub) GetCreator() ([]byte, error) {
+	return stub.network.creator, nil
+}
+
+// GetSignedProposal returns the proposal of the transaction, which names the chaincode the client invoked
+func (stub *Stub) GetSignedProposal() (*peer.SignedProposal, error) {
+	return stub.network.proposal, nil
+}
+
+// InvokeChaincode calls another chaincode on the network within the transaction
+func (stub *Stub) InvokeChaincode(chaincode string, args [][]byte, channel string) peer.Response {
+	if channel != "" && channel != stub.network.channel {
+		return shim.Error(fmt.Sprintf("channel %s is not the channel of the network", channel))
+	}
+	other, ok := stub.network.stubs[chaincode]
+	if !ok {
+		return shim.Error(fmt.Sprintf("chaincode %s is not deployed", chaincode))
+	}
+	return other.invoke(stub.TxID, stub.TxTimestamp, args)
+}
+
+// GetStateByRange returns the simple keys in the range with their values. Composite keys are left out, like on a peer.
+func (stub *Stub) GetStateByRange(startKey string, endKey string) (shim.StateQueryIteratorInterface, error) {
+	iterator, err := stub.MockStub.GetStateByRange(startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	results := &stateIterator{}
+	for iterator.HasNext() {
+		result, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(result.Key, compositeKeyNamespace) {
+			continue
+		}
+		results.results = append(results.results, result)
+	}
+	return results, nil
+}
+
+// restore replaces the world state of the chaincode
+func (stub *Stub) restore(state map[string][]byte) {
+	keys := make([]string, 0, len(state))
+	for key := range state {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	stub.State = state
+	stub.Keys = list.New()
+	for _, key := range keys {
+		stub.Keys.PushBack(key)
+	}
+}
+
+func copyState(state map[string][]byte) map[string][]byte {
+	copied := make(map[string][]byte, len(state))
+	for key, value := range state {
+		copied[key] = value
+	}
+	return copied
+}
+
+// signedProposal builds the proposal a client sends to invoke the chaincode on the channel
+func signedProposal(channel string, chaincode string) (*peer.SignedProposal, error) {
+	extension, err := proto.Marshal(&peer.ChaincodeHeaderExtension{ChaincodeId: &peer.ChaincodeID{Name: chaincode}})
+	if err != nil {
+		return nil, err
+	}
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: channel,
+		Extension: extension,
+	})
+	if err != nil {
+		return nil, err
+	}
+	header, err := proto.Marshal(&common.Header{ChannelHeader: channelHeader})
+	if err != nil {
+		return nil, err
+	}
+	proposal, err := proto.Marshal(&peer.Proposal{Header: header})
+	if err != nil {
+		return nil, err
+	}
+	return &peer.SignedProposal{ProposalBytes: proposal}, nil
+}
+
+// compositeKeyNamespace is the first character of every composite key
+const compositeKeyNamespace = "\x00"
+
+// stateIterator iterates over the results of a query read beforehand
+type stateIterator struct {
+	results []*queryresult.KV
+}
+
+func (iterator *stateIterator) HasNext() bool {
+	return len(iterator.results) > 0
+}
+
+func (iterator *stateIterator) Next() (*queryresult.KV, error) {
+	if len(iterator.results) == 0 {
+		return nil, fmt.Errorf("no more results")
+	}
+	result := iterator.results[0]
+	iterator.results = iterator.results[1:]
+	return result, nil
+}
+
+func (iterator *stateIterator) Close() error {
+	return nil
+}
@@ -0,0 +1,315 @@
+// Package decimal provides the fixed-point numbers and amounts of money shared by the chaincodes and applications.
+// Every number has Scale decimals and is rounded the same way everywhere, so the customer and SLA chaincodes
+// can never disagree on a grass length or a price because a float was rounded differently on the way.
+package decimal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of decimals of every Decimal
+const Scale = 2
+
+// unit is the number of units in one, 10^Scale
+const unit int64 = 100
+
+// Decimal is a fixed-point number with Scale decimals. It is kept in its canonical text form, e.g. "12.50",
+// and encoded as a JSON string, which also makes it a plain string in the contract metadata of the chaincodes.
+// Records stored before Decimal existed encoded numbers as JSON floats. Those are still accepted when decoding
+// and rounded half away from zero to Scale decimals. The empty Decimal is zero.
+type Decimal string
+
+// Zero is the Decimal 0.00
+const Zero Decimal = "0.00"
+
+// New returns the Decimal with the given number of units of 10^-Scale, e.g. New(1250) is 12.50
+func New(units int64) Decimal {
+	sign := ""
+	magnitude := uint64(units)
+	if units < 0 {
+		sign = "-"
+		magnitude = -magnitude
+	}
+	return Decimal(fmt.Sprintf("%s%d.%0*d", sign, magnitude/uint64(unit), Scale, magnitude%uint64(unit)))
+}
+
+// FromInt returns the Decimal of a whole number. It is meant for constants such as price tables.
+func FromInt(i int64) Decimal {
+	return New(i * unit)
+}
+
+// FromFloat returns the float rounded half away from zero to Scale decimals. It is only meant for reading legacy records.
+func FromFloat(f float64) (Decimal, error) {
+	units := math.Round(f * float64(unit))
+	// float64(math.MaxInt64) rounds up to 2^63, which no longer fits
+	if math.IsNaN(units) || units < math.MinInt64 || units >= math.MaxInt64 {
+		return "", fmt.Errorf("the number %v is out of range", f)
+	}
+	return New(int64(units)), nil
+}
+
+// Parse reads a decimal number such as "12", "-0.5" or "12.50". Numbers with more significant decimals than Scale
+// are rejected instead of rounded, while trailing zeros are accepted, e.g. "12.500000" as printed by %f.
+func Parse(s string) (Decimal, error) {
+	units, err := parseUnits(s)
+	if err != nil {
+		return "", err
+	}
+	return New(units), nil
+}
+
+// MustParse is like Parse but panics when the number is invalid. It is meant for constants such as price tables.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// parseUnits reads a decimal number as a whole number of units of 10^-Scale
+func parseUnits(s string) (int64, error) {
+	text := strings.TrimSpace(s)
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign = "-"
+	}
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(text, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid decimal %q", s)
+	}
+	if !digitsOnly(whole) || !digitsOnly(fraction) {
+		return 0, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	if len(fraction) > Scale {
+		if strings.Trim(fraction[Scale:], "0") != "" {
+			return 0, fmt.Errorf("the decimal %q has more than %d decimals", s, Scale)
+		}
+		fraction = fraction[:Scale]
+	}
+	fraction += strings.Repeat("0", Scale-len(fraction))
+
+	units, err := strconv.ParseInt(sign+whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid decimal %q: %v", s, err)
+	}
+	return units, nil
+}
+
+func digitsOnly(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Units returns the number as a whole number of units of 10^-Scale, e.g. 1250 for 12.50.
+// It fails when the number is not a valid decimal, which Parse and UnmarshalJSON never return,
+// but a conversion from a string can.
+func (d Decimal) Units() (int64, error) {
+	if d == "" {
+		return 0, nil
+	}
+	return parseUnits(string(d))
+}
+
+// value returns the units of the number for comparisons, where an invalid number counts as zero.
+// The arithmetic, Units and MarshalJSON report invalid numbers.
+func (d Decimal) value() int64 {
+	units, _ := d.Units()
+	return units
+}
+
+// String returns the canonical text form of the number, or the text of an invalid number as it is
+func (d Decimal) String() string {
+	units, err := d.Units()
+	if err != nil {
+		return string(d)
+	}
+	return string(New(units))
+}
+
+// Float64 returns the number as a float, for display and charts only
+func (d Decimal) Float64() float64 {
+	return float64(d.value()) / float64(unit)
+}
+
+// Add returns d + o, or an error when either number is invalid or the sum is out of range
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	a, b, err := bigUnits(d, o)
+	if err != nil {
+		return "", err
+	}
+	return fromBig(new(big.Int).Add(a, b), "%s + %s", d, o)
+}
+
+// Sub returns d - o, or an error when either number is invalid or the difference is out of range
+func (d Decimal) Sub(o Decimal) (Decimal, error) {
+	a, b, err := bigUnits(d, o)
+	if err != nil {
+		return "", err
+	}
+	return fromBig(new(big.Int).Sub(a, b), "%s - %s", d, o)
+}
+
+// Neg returns -d, or an error when the number is invalid or its negation is out of range
+func (d Decimal) Neg() (Decimal, error) {
+	return Zero.Sub(d)
+}
+
+// Mul returns d * o rounded half away from zero, or an error when either number is invalid or the product is out of range
+func (d Decimal) Mul(o Decimal) (Decimal, error) {
+	a, b, err := bigUnits(d, o)
+	if err != nil {
+		return "", err
+	}
+	product := new(big.Int).Mul(a, b)
+	return fromBig(roundDiv(product, big.NewInt(unit)), "%s * %s", d, o)
+}
+
+// MulInt returns d * i, or an error when the number is invalid or the product is out of range
+func (d Decimal) MulInt(i int64) (Decimal, error) {
+	a, b, err := bigUnits(d, Zero)
+	if err != nil {
+		return "", err
+	}
+	b.SetInt64(i)
+	return fromBig(new(big.Int).Mul(a, b), "%s * %d", d, i)
+}
+
+// Div returns d / o rounded half away from zero, or an error when either number is invalid, o is zero or the
+// quotient is out of range
+func (d Decimal) Div(o Decimal) (Decimal, error) {
+	a, b, err := bigUnits(d, o)
+	if err != nil {
+		return "", err
+	}
+	if b.Sign() == 0 {
+		return "", fmt.Errorf("division of %s by zero", d)
+	}
+	numerator := new(big.Int).Mul(a, big.NewInt(unit))
+	return fromBig(roundDiv(numerator, b), "%s / %s", d, o)
+}
+
+// DivInt returns d / i rounded half away from zero, or an error when the number is invalid or i is zero
+func (d Decimal) DivInt(i int64) (Decimal, error) {
+	if i == 0 {
+		return "", fmt.Errorf("division of %s by zero", d)
+	}
+	a, b, err := bigUnits(d, Zero)
+	if err != nil {
+		return "", err
+	}
+	b.SetInt64(i)
+	return fromBig(roundDiv(a, b), "%s / %d", d, i)
+}
+
+// Cmp returns -1, 0 or 1 when d is less than, equal to or greater than o
+func (d Decimal) Cmp(o Decimal) int {
+	a, b := d.value(), o.value()
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// IsZero returns true when the number is zero
+func (d Decimal) IsZero() bool {
+	return d.value() == 0
+}
+
+// Sign returns -1, 0 or 1 when the number is negative, zero or positive
+func (d Decimal) Sign() int {
+	return d.Cmp(Zero)
+}
+
+// MarshalJSON encodes the number as a JSON string in its canonical form. An invalid number is not encoded.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	units, err := d.Units()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(New(units)))
+}
+
+// UnmarshalJSON decodes a number from a JSON string, or from a JSON number as written by legacy records
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := strings.TrimSpace(string(data))
+	if text == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(text, "\"") {
+		var s string
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return err
+		}
+		parsed, err := Parse(s)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return fmt.Errorf("invalid decimal %s", text)
+	}
+	decoded, err := FromFloat(f)
+	if err != nil {
+		return err
+	}
+	*d = decoded
+	return nil
+}
+
+// bigUnits returns the units of both numbers, or an error when either is invalid
+func bigUnits(d Decimal, o Decimal) (*big.Int, *big.Int, error) {
+	a, err := d.Units()
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := o.Units()
+	if err != nil {
+		return nil, nil, err
+	}
+	return big.NewInt(a), big.NewInt(b), nil
+}
+
+// fromBig returns the Decimal with the units, or an error naming the operation when they do not fit in an int64
+func fromBig(units *big.Int, operation string, operands ...interface{}) (Decimal, error) {
+	if !units.IsInt64() {
+		return "", fmt.Errorf("the result of "+operation+" is out of range", operands...)
+	}
+	return New(units.Int64()), nil
+}
+
+// roundDiv returns a / b rounded half away from zero
+func roundDiv(a *big.Int, b *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(a, b, new(big.Int))
+	twiceRemainder := new(big.Int).Abs(remainder)
+	twiceRemainder.Lsh(twiceRemainder, 1)
+	if twiceRemainder.Cmp(new(big.Int).Abs(b)) >= 0 {
+		if (a.Sign() < 0) != (b.Sign() < 0) {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
@@ -0,0 +1,165 @@
+package decimal
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	valid := map[string]Decimal{
+		"12":        "12.00",
+		"12.5":      "12.50",
+		"-0.5":      "-0.50",
+		".25":       "0.25",
+		"+3":        "3.00",
+		"5.500000":  "5.50",
+		" 7.10 ":    "7.10",
+		"100000.01": "100000.01",
+	}
+	for input, expected := range valid {
+		d, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", input, err)
+			continue
+		}
+		if d != expected {
+			t.Errorf("Parse(%q) = %s, expected %s", input, d, expected)
+		}
+	}
+
+	for _, input := range []string{"", "-", ".", "abc", "1.2.3", "1e5", "5.123457", "0.001"} {
+		_, err := Parse(input)
+		if err == nil {
+			t.Errorf("Parse(%q) should have failed", input)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	if got, err := MustParse("1.10").Add("2.25"); err != nil || got != "3.35" {
+		t.Errorf("Add = %s, %v", got, err)
+	}
+	if got, err := MustParse("1.10").Sub("2.25"); err != nil || got != "-1.15" {
+		t.Errorf("Sub = %s, %v", got, err)
+	}
+	// 1.25 * 0.5 = 0.625 is rounded half away from zero
+	if got, err := MustParse("1.25").Mul("0.50"); err != nil || got != "0.63" {
+		t.Errorf("Mul = %s, %v", got, err)
+	}
+	if got, err := MustParse("-1.25").Mul("0.50"); err != nil || got != "-0.63" {
+		t.Errorf("Mul = %s, %v", got, err)
+	}
+
+	quotient, err := MustParse("100").Div("3")
+	if err != nil || quotient != "33.33" {
+		t.Errorf("Div = %s, %v", quotient, err)
+	}
+	quotient, err = MustParse("2").Div("3")
+	if err != nil || quotient != "0.67" {
+		t.Errorf("Div = %s, %v", quotient, err)
+	}
+	_, err = MustParse("2").Div(Zero)
+	if err == nil {
+		t.Errorf("Div by zero should have failed")
+	}
+
+	quotient, err = MustParse("3720.00").DivInt(31)
+	if err != nil || quotient != "120.00" {
+		t.Errorf("DivInt = %s, %v", quotient, err)
+	}
+
+	if MustParse("1.5").Cmp("1.50") != 0 || MustParse("1.49").Cmp("1.5") != -1 || Decimal("").Cmp(Zero) != 0 {
+		t.Errorf("Cmp gave unexpected results")
+	}
+}
+
+func TestInvalidAndOutOfRange(t *testing.T) {
+	largest := New(math.MaxInt64)
+	smallest := New(math.MinInt64)
+	if units, err := smallest.Units(); err != nil || units != math.MinInt64 {
+		t.Errorf("Units of %s = %d, %v", smallest, units, err)
+	}
+
+	if _, err := Decimal("12,50").Units(); err == nil {
+		t.Error("Units of an invalid number should have failed")
+	}
+	if _, err := json.Marshal(Decimal("12,50")); err == nil {
+		t.Error("Marshal of an invalid number should have failed")
+	}
+
+	for name, operation := range map[string]func() (Decimal, error){
+		"Add of an invalid number": func() (Decimal, error) { return MustParse("1").Add("one") },
+		"Mul of an invalid number": func() (Decimal, error) { return Decimal("one").Mul("1") },
+		"Add":                      func() (Decimal, error) { return largest.Add("0.01") },
+		"Sub":                      func() (Decimal, error) { return smallest.Sub("0.01") },
+		"Neg":                      func() (Decimal, error) { return smallest.Neg() },
+		"Mul":                      func() (Decimal, error) { return largest.Mul("2") },
+		"MulInt":                   func() (Decimal, error) { return largest.MulInt(2) },
+		"Div":                      func() (Decimal, error) { return largest.Div("0.50") },
+	} {
+		if got, err := operation(); err == nil {
+			t.Errorf("%s should have failed, got %s", name, got)
+		}
+	}
+
+	if _, err := FromFloat(1e18); err == nil {
+		t.Error("FromFloat out of range should have failed")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	encoded, err := json.Marshal(map[string]Decimal{"TargetGrassLength": MustParse("5.5")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `{"TargetGrassLength":"5.50"}` {
+		t.Errorf("Marshal = %s", encoded)
+	}
+
+	// legacy records store grass lengths as floats
+	var decoded map[string]Decimal
+	err = json.Unmarshal([]byte(`{"TargetGrassLength":5.5,"MaxGrassLength":"8.25","MinGrassLength":0.1}`), &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded["TargetGrassLength"] != "5.50" || decoded["MaxGrassLength"] != "8.25" || decoded["MinGrassLength"] != "0.10" {
+		t.Errorf("Unmarshal = %v", decoded)
+	}
+
+	err = json.Unmarshal([]byte(`{"TargetGrassLength":"5.555"}`), &decoded)
+	if err == nil {
+		t.Errorf("Unmarshal of too many decimals should have failed")
+	}
+}
+
+func TestMoney(t *testing.T) {
+	var legacy struct {
+		MonthlyBalance Money `json:"MonthlyBalance"`
+	}
+	err := json.Unmarshal([]byte(`{"MonthlyBalance":125}`), &legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if legacy.MonthlyBalance != NewMoney(FromInt(125), DefaultCurrency) {
+		t.Errorf("legacy Unmarshal = %v", legacy.MonthlyBalance)
+	}
+
+	var current Money
+	err = json.Unmarshal([]byte(`{"Amount":"129.33","Currency":"EUR"}`), &current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.String() != "129.33 EUR" {
+		t.Errorf("Unmarshal = %v", current)
+	}
+
+	sum, err := Money{}.Add(current)
+	if err != nil || sum != current {
+		t.Errorf("Add to zero = %v, %v", sum, err)
+	}
+	_, err = legacy.MonthlyBalance.Add(current)
+	if err == nil {
+		t.Errorf("Add of different currencies should have failed")
+	}
+}
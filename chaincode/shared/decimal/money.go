@@ -0,0 +1,114 @@
+package decimal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefaultCurrency is the currency SLAs are priced in and technicians are paid in
+const DefaultCurrency = "SEK"
+
+// Money is an amount in a currency, with the precision of Decimal.
+// Records stored before Money existed held prices and balances as JSON integers in the default currency,
+// those are still accepted when decoding.
+type Money struct {
+	Amount   Decimal `json:"Amount"`
+	Currency string  `json:"Currency"`
+}
+
+// NewMoney returns the amount in the currency. An invalid amount is kept as it is, to fail when it is used.
+func NewMoney(amount Decimal, currency string) Money {
+	return Money{Amount: Decimal(amount.String()), Currency: currency}
+}
+
+// ZeroMoney returns no money in the currency
+func ZeroMoney(currency string) Money {
+	return NewMoney(Zero, currency)
+}
+
+// Add returns m + o. Both amounts must be in the same currency, except that a zero amount without a currency
+// takes on the currency of the other amount.
+func (m Money) Add(o Money) (Money, error) {
+	currency, err := m.commonCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	sum, err := m.Amount.Add(o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(sum, currency), nil
+}
+
+// Sub returns m - o under the same currency rules as Add
+func (m Money) Sub(o Money) (Money, error) {
+	currency, err := m.commonCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	difference, err := m.Amount.Sub(o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(difference, currency), nil
+}
+
+// Cmp compares m to o under the same currency rules as Add
+func (m Money) Cmp(o Money) (int, error) {
+	_, err := m.commonCurrency(o)
+	if err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+// IsZero returns true when the amount is zero, whatever the currency
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// String returns the amount followed by the currency, e.g. "129.33 SEK"
+func (m Money) String() string {
+	return strings.TrimSpace(m.Amount.String() + " " + m.Currency)
+}
+
+func (m Money) commonCurrency(o Money) (string, error) {
+	switch {
+	case m.Currency == o.Currency:
+		return m.Currency, nil
+	case m.Currency == "" && m.IsZero():
+		return o.Currency, nil
+	case o.Currency == "" && o.IsZero():
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("cannot combine %s with %s", m, o)
+}
+
+// UnmarshalJSON decodes an amount from a JSON object, or from a JSON number in the default currency as written by legacy records
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.TrimSpace(string(data))
+	if text == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(text, "{") {
+		// decode into a type without this method to avoid recursion
+		type money Money
+		var decoded money
+		err := json.Unmarshal(data, &decoded)
+		if err != nil {
+			return err
+		}
+		*m = Money(decoded)
+		return nil
+	}
+
+	var amount Decimal
+	err := amount.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*m = NewMoney(amount, DefaultCurrency)
+	return nil
+}
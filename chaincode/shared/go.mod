@@ -0,0 +1,18 @@
+module github.com/nalle631/fabric-network/chaincode/shared
+
+go 1.21.6
+
+require (
+	github.com/golang/protobuf v1.5.3
+	github.com/hyperledger/fabric-chaincode-go v0.0.0-20240124143825-7dec3c7e7d45
+	github.com/hyperledger/fabric-protos-go v0.3.0
+	google.golang.org/protobuf v1.28.1
+)
+
+require (
+	golang.org/x/net v0.17.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	google.golang.org/genproto v0.0.0-20230110181048-76db0878b65f // indirect
+	google.golang.org/grpc v1.54.0 // indirect
+)
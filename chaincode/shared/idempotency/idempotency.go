@@ -0,0 +1,125 @@
+// Package idempotency keeps a deduplication record of every transaction a client submits with an idempotency
+// key. A transaction retried with the same key, for example after the HTTP request timed out, returns the result
+// and transaction ID of the first one instead of running again, so it neither creates a duplicate nor fails
+// because the first one already made its change.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"unicode"
+)
+
+const (
+	// ObjectType prefixes the composite keys records are stored under, keyed by the client identity and the key
+	ObjectType = "request"
+
+	// MaxKeyLength is the longest idempotency key that is accepted
+	MaxKeyLength = 255
+)
+
+// Stub is the part of the chaincode stub records are read and written with
+type Stub interface {
+	GetState(key string) ([]byte, error)
+	PutState(key string, value []byte) error
+	CreateCompositeKey(objectType string, attributes []string) (string, error)
+	GetTxID() string
+}
+
+// Record is the outcome of the first transaction submitted with an idempotency key. The result is the JSON the
+// transaction returned, empty when it returns nothing. Replayed is set on records returned to a retry.
+type Record struct {
+	Key         string `json:"Key"`
+	Function    string `json:"Function"`
+	Fingerprint string `json:"Fingerprint"`
+	TxID        string `json:"TxID"`
+	Result      string `json:"Result,omitempty"`
+	Replayed    bool   `json:"Replayed"`
+}
+
+// Run runs the transaction unless the client already submitted it with the key, and records its outcome.
+// A retry returns the record of the first transaction. Reusing a key for another function or other arguments
+// is an error, since the retry would not get the outcome of what it asked for. The client is the ID of the
+// identity that submitted the transaction, so clients cannot see the outcomes of each other's keys.
+func Run(stub Stub, client string, key string, function string, args []string, transaction func() (interface{}, error)) (*Record, error) {
+	err := ValidateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stateKey, err := stub.CreateCompositeKey(ObjectType, []string{client, key})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the key of idempotency key %s: %v", key, err)
+	}
+	fingerprint := Fingerprint(function, args)
+
+	recordJSON, err := stub.GetState(stateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read from world state: %v", err)
+	}
+	if recordJSON != nil {
+		var record Record
+		err = json.Unmarshal(recordJSON, &record)
+		if err != nil {
+			return nil, err
+		}
+		if record.Function != function || record.Fingerprint != fingerprint {
+			return nil, fmt.Errorf("the idempotency key %s has already been used for another %s request", key, record.Function)
+		}
+		record.Replayed = true
+		return &record, nil
+	}
+
+	result, err := transaction()
+	if err != nil {
+		return nil, err
+	}
+
+	record := Record{Key: key, Function: function, Fingerprint: fingerprint, TxID: stub.GetTxID()}
+	if result != nil {
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		record.Result = string(resultJSON)
+	}
+
+	recordJSON, err = json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	err = stub.PutState(stateKey, recordJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put to world state: %v", err)
+	}
+	return &record, nil
+}
+
+// ValidateKey checks that the idempotency key is 1 to MaxKeyLength printable ASCII characters
+func ValidateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("the idempotency key is empty")
+	}
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("the idempotency key is longer than %d characters", MaxKeyLength)
+	}
+	for _, r := range key {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return fmt.Errorf("the idempotency key %q has characters that are not printable ASCII", key)
+		}
+	}
+	return nil
+}
+
+// Fingerprint identifies the function and arguments of a transaction
+func Fingerprint(function string, args []string) string {
+	hash := sha256.New()
+	hash.Write([]byte(function))
+	for _, arg := range args {
+		hash.Write([]byte{0})
+		hash.Write([]byte(arg))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
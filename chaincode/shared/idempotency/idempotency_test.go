@@ -0,0 +1,125 @@
+package idempotency
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// memoryStub is a world state in memory, with the transaction ID of the transaction that runs
+type memoryStub struct {
+	state map[string][]byte
+	txID  string
+}
+
+func (stub *memoryStub) GetState(key string) ([]byte, error) {
+	return stub.state[key], nil
+}
+
+func (stub *memoryStub) PutState(key string, value []byte) error {
+	stub.state[key] = value
+	return nil
+}
+
+func (stub *memoryStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	return "\x00" + objectType + "\x00" + strings.Join(attributes, "\x00") + "\x00", nil
+}
+
+func (stub *memoryStub) GetTxID() string {
+	return stub.txID
+}
+
+type sla struct {
+	ID string `json:"ID"`
+}
+
+func TestRunReplaysTheFirstOutcome(t *testing.T) {
+	stub := &memoryStub{state: map[string][]byte{}, txID: "tx1"}
+	runs := 0
+	createSLA := func() (interface{}, error) {
+		runs++
+		return &sla{ID: "sla1"}, nil
+	}
+
+	record, err := Run(stub, "alice", "key-1", "CreateSLA", []string{"c1", "Gold"}, createSLA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.TxID != "tx1" || record.Replayed || record.Result != `{"ID":"sla1"}` {
+		t.Fatalf("record = %+v", record)
+	}
+
+	stub.txID = "tx2"
+	replay, err := Run(stub, "alice", "key-1", "CreateSLA", []string{"c1", "Gold"}, createSLA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs != 1 {
+		t.Fatalf("the transaction ran %d times", runs)
+	}
+	if replay.TxID != "tx1" || !replay.Replayed || replay.Result != record.Result {
+		t.Fatalf("replay = %+v, want the outcome of tx1", replay)
+	}
+	var replayed sla
+	if err := json.Unmarshal([]byte(replay.Result), &replayed); err != nil || replayed.ID != "sla1" {
+		t.Fatalf("replayed result = %+v, %v", replayed, err)
+	}
+
+	// the key of another client is another request
+	other, err := Run(stub, "bob", "key-1", "CreateSLA", []string{"c1", "Gold"}, createSLA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Replayed || other.TxID != "tx2" || runs != 2 {
+		t.Fatalf("record of another client = %+v after %d runs", other, runs)
+	}
+}
+
+func TestRunRejectsReusedKeys(t *testing.T) {
+	stub := &memoryStub{state: map[string][]byte{}, txID: "tx1"}
+	takeJob := func() (interface{}, error) { return nil, nil }
+
+	record, err := Run(stub, "alice", "key-1", "TakeJob", []string{"job1"}, takeJob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Result != "" {
+		t.Fatalf("a transaction without result recorded %q", record.Result)
+	}
+
+	_, err = Run(stub, "alice", "key-1", "TakeJob", []string{"job2"}, takeJob)
+	if err == nil || !strings.Contains(err.Error(), "already been used") {
+		t.Fatalf("reusing the key for other arguments: %v", err)
+	}
+	_, err = Run(stub, "alice", "key-1", "JobDoneCorrectError", []string{"job1"}, takeJob)
+	if err == nil {
+		t.Fatal("reusing the key for another function must fail")
+	}
+}
+
+func TestRunRecordsNothingWhenTheTransactionFails(t *testing.T) {
+	stub := &memoryStub{state: map[string][]byte{}, txID: "tx1"}
+	failure := errors.New("job job1 already exists on ledger")
+
+	_, err := Run(stub, "alice", "key-1", "TakeJob", []string{"job1"}, func() (interface{}, error) { return nil, failure })
+	if !errors.Is(err, failure) {
+		t.Fatalf("err = %v", err)
+	}
+	if len(stub.state) != 0 {
+		t.Fatalf("a failed transaction left %d records", len(stub.state))
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"a", "7d4c5a1e-2b1f-4e0a-9a57-1f0d9c3b8e21", strings.Repeat("k", MaxKeyLength)} {
+		if err := ValidateKey(key); err != nil {
+			t.Errorf("ValidateKey(%q) = %v", key, err)
+		}
+	}
+	for _, key := range []string{"", strings.Repeat("k", MaxKeyLength+1), "key\x00", "nyckel-å", "tab\tkey"} {
+		if err := ValidateKey(key); err == nil {
+			t.Errorf("ValidateKey(%q) must fail", key)
+		}
+	}
+}